@@ -0,0 +1,419 @@
+// Package admin provides an http.Handler exposing a REST API for inspecting
+// and managing the entries of a running cron.Cron.
+//
+// The handler serves the following endpoints, relative to the path it is
+// mounted at (use http.StripPrefix to mount it below the root):
+//
+//	GET    /entries                 list all entries
+//	GET    /entries/{id}            show one entry
+//	GET    /entries/{id}/next?n=N   show the next N activations (default 5)
+//	POST   /entries/{id}/pause      pause the entry
+//	POST   /entries/{id}/resume     resume the entry
+//	POST   /entries/{id}/trigger    run the entry's job now
+//	DELETE /entries/{id}            remove the entry
+//	GET    /health                  show the health of all entries; the
+//	                                status is 503 if any is unhealthy
+//	GET    /calendar.ics?n=N&id=ID  show the next N activations (default 10)
+//	                                of the entries with the IDs given, or of
+//	                                all entries, as an iCalendar feed
+//	GET    /openapi.json            show the OpenAPI document of the API
+//
+// Entries carry statistics of their runs.  Responses other than the feed are
+// JSON.  Errors are reported as {"error": "..."} with an appropriate status
+// code.
+//
+// The API performs no authentication by default.  Use WithMiddleware to wrap
+// it with the authentication scheme of the embedding service, or BasicAuth
+// for a simple shared credential.
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// maxNext is the maximum number of activations returned by the next endpoint,
+// and of each entry by the calendar endpoint.
+const maxNext = 100
+
+// Middleware wraps an http.Handler, typically to authenticate requests.
+type Middleware func(http.Handler) http.Handler
+
+// Option configures the handler returned by NewHandler.
+type Option func(*handler)
+
+// WithMiddleware returns an Option that wraps the handler with the given
+// middleware.  The first middleware given is the outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(h *handler) {
+		h.middleware = append(h.middleware, middleware...)
+	}
+}
+
+// BasicAuth returns a Middleware that rejects requests that do not carry the
+// given HTTP basic authentication credentials.
+func BasicAuth(username, password string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, p, ok := r.BasicAuth()
+			if !ok ||
+				subtle.ConstantTimeCompare([]byte(u), []byte(username)) != 1 ||
+				subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="cron"`)
+				writeError(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type handler struct {
+	cron       *cron.Cron
+	middleware []Middleware
+}
+
+// NewHandler returns an http.Handler serving the admin API for the given Cron.
+func NewHandler(c *cron.Cron, opts ...Option) http.Handler {
+	h := &handler{cron: c}
+	for _, opt := range opts {
+		opt(h)
+	}
+	var root http.Handler = http.HandlerFunc(h.serve)
+	for i := len(h.middleware) - 1; i >= 0; i-- {
+		root = h.middleware[i](root)
+	}
+	return root
+}
+
+// Entry is the JSON representation of a cron entry.
+type Entry struct {
+	ID     cron.EntryID `json:"id"`
+	Name   string       `json:"name,omitempty"`
+	Spec   string       `json:"spec,omitempty"`
+	Next   *time.Time   `json:"next,omitempty"`
+	Prev   *time.Time   `json:"prev,omitempty"`
+	Paused bool         `json:"paused"`
+	Tags   []string     `json:"tags,omitempty"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// RemainingRuns is omitted for entries without MaxRuns.
+	RemainingRuns *int `json:"remaining_runs,omitempty"`
+
+	Stats Stats `json:"stats"`
+}
+
+// Stats is the JSON representation of an entry's run statistics.  Durations
+// are in seconds.
+type Stats struct {
+	Runs        int     `json:"runs"`
+	Failures    int     `json:"failures"`
+	AvgDuration float64 `json:"avg_duration"`
+	MaxDuration float64 `json:"max_duration"`
+	AvgDelay    float64 `json:"avg_delay"`
+}
+
+func newEntry(e *cron.Entry) Entry {
+	entry := Entry{
+		ID:       e.ID,
+		Name:     e.Name,
+		Spec:     e.Spec,
+		Next:     timeOrNil(e.Next),
+		Prev:     timeOrNil(e.Prev),
+		Paused:   e.Paused,
+		Tags:     e.Tags,
+		Metadata: e.Metadata,
+		Stats: Stats{
+			Runs:        e.Stats.Runs,
+			Failures:    e.Stats.Failures,
+			AvgDuration: e.Stats.AvgDuration.Seconds(),
+			MaxDuration: e.Stats.MaxDuration.Seconds(),
+			AvgDelay:    e.Stats.AvgDelay.Seconds(),
+		},
+	}
+	if remaining := e.RemainingRuns(); remaining >= 0 {
+		entry.RemainingRuns = &remaining
+	}
+	return entry
+}
+
+// Health is the JSON representation of an entry's health.
+type Health struct {
+	ID                  cron.EntryID `json:"id"`
+	Name                string       `json:"name,omitempty"`
+	LastSuccess         *time.Time   `json:"last_success,omitempty"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	LastScheduled       *time.Time   `json:"last_scheduled,omitempty"`
+	Missed              bool         `json:"missed"`
+	Healthy             bool         `json:"healthy"`
+}
+
+func newHealth(h cron.EntryHealth) Health {
+	return Health{
+		ID:                  h.EntryID,
+		Name:                h.Name,
+		LastSuccess:         timeOrNil(h.LastSuccess),
+		ConsecutiveFailures: h.ConsecutiveFailures,
+		LastScheduled:       timeOrNil(h.LastScheduled),
+		Missed:              h.Missed,
+		Healthy:             h.Healthy(),
+	}
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// route is an endpoint of the API.  The routes drive both the dispatch of
+// requests and the OpenAPI document, which thus stays in sync with them.
+type route struct {
+	method  string
+	path    string // with {id} standing for an entry ID
+	id      string // the OpenAPI operationId
+	summary string
+	query   []param
+	handle  func(h *handler, w http.ResponseWriter, r *http.Request, entry *cron.Entry)
+
+	// responses are the responses the endpoint may give, besides errors
+	// for a missing entry.
+	responses []response
+}
+
+// param is a query parameter.
+type param struct {
+	name, description string
+	schema            map[string]interface{}
+}
+
+// response is a response an endpoint may give.  body is a value of the type
+// of the JSON body, or nil for none.
+type response struct {
+	status      int
+	description string
+	body        interface{}
+}
+
+// apiError is the body of error responses.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// calendar is the body of the calendar endpoint, an iCalendar feed.
+type calendar string
+
+var routes = []route{
+	{method: "GET", path: "/entries", id: "listEntries", summary: "List all entries",
+		handle:    (*handler).list,
+		responses: []response{{http.StatusOK, "The entries", []Entry{}}}},
+	{method: "GET", path: "/entries/{id}", id: "getEntry", summary: "Show one entry",
+		handle:    (*handler).show,
+		responses: []response{{http.StatusOK, "The entry", Entry{}}}},
+	{method: "DELETE", path: "/entries/{id}", id: "removeEntry", summary: "Remove the entry",
+		handle:    (*handler).remove,
+		responses: []response{{http.StatusNoContent, "The entry was removed", nil}}},
+	{method: "GET", path: "/entries/{id}/next", id: "listNextActivations", summary: "Show the next activations of the entry",
+		query: []param{{"n", "The number of activations",
+			map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxNext, "default": 5}}},
+		handle: (*handler).next,
+		responses: []response{
+			{http.StatusOK, "The activations", []time.Time{}},
+			{http.StatusBadRequest, "n is out of range", apiError{}},
+		}},
+	{method: "POST", path: "/entries/{id}/pause", id: "pauseEntry", summary: "Pause the entry",
+		handle:    action((*cron.Cron).Pause),
+		responses: []response{{http.StatusOK, "The paused entry", Entry{}}}},
+	{method: "POST", path: "/entries/{id}/resume", id: "resumeEntry", summary: "Resume the entry",
+		handle:    action((*cron.Cron).Resume),
+		responses: []response{{http.StatusOK, "The resumed entry", Entry{}}}},
+	{method: "POST", path: "/entries/{id}/trigger", id: "triggerEntry", summary: "Run the entry's job now",
+		handle:    action((*cron.Cron).Trigger),
+		responses: []response{{http.StatusOK, "The triggered entry", Entry{}}}},
+	{method: "GET", path: "/health", id: "getHealth", summary: "Show the health of all entries",
+		handle: (*handler).health,
+		responses: []response{
+			{http.StatusOK, "Every entry is healthy", []Health{}},
+			{http.StatusServiceUnavailable, "Some entry is unhealthy", []Health{}},
+		}},
+	{method: "GET", path: "/calendar.ics", id: "getCalendar", summary: "Show the next activations of entries as an iCalendar feed",
+		query: []param{
+			{"n", "The number of activations of each entry",
+				map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxNext, "default": 10}},
+			{"id", "The IDs of the entries, or none for every entry",
+				map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}}},
+		},
+		handle: (*handler).calendar,
+		responses: []response{
+			{http.StatusOK, "The feed", calendar("")},
+			{http.StatusBadRequest, "n or an ID is invalid", apiError{}},
+		}},
+}
+
+func (h *handler) serve(w http.ResponseWriter, r *http.Request) {
+	path := "/" + strings.Trim(r.URL.Path, "/")
+	if path == openAPIPath {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPI)
+		return
+	}
+
+	found := false
+	for _, route := range routes {
+		id, ok := match(route.path, path)
+		if !ok {
+			continue
+		}
+		found = true
+		if r.Method != route.method {
+			continue
+		}
+		var entry *cron.Entry
+		if strings.Contains(route.path, "{id}") {
+			if entry = h.cron.Entry(id); entry == nil {
+				writeError(w, http.StatusNotFound, "entry not found")
+				return
+			}
+		}
+		route.handle(h, w, r, entry)
+		return
+	}
+	if found {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+// match reports whether the path matches the route's, returning the entry ID
+// it holds, if any.
+func match(route, path string) (cron.EntryID, bool) {
+	routeParts, parts := strings.Split(route, "/"), strings.Split(path, "/")
+	if len(routeParts) != len(parts) {
+		return 0, false
+	}
+	var id cron.EntryID
+	for i, part := range routeParts {
+		if part == "{id}" {
+			n, err := strconv.Atoi(parts[i])
+			if err != nil {
+				return 0, false
+			}
+			id = cron.EntryID(n)
+		} else if part != parts[i] {
+			return 0, false
+		}
+	}
+	return id, true
+}
+
+func (h *handler) list(w http.ResponseWriter, r *http.Request, _ *cron.Entry) {
+	entries := []Entry{}
+	for _, e := range h.cron.Entries() {
+		entries = append(entries, newEntry(e))
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (h *handler) show(w http.ResponseWriter, r *http.Request, entry *cron.Entry) {
+	writeJSON(w, http.StatusOK, newEntry(entry))
+}
+
+func (h *handler) remove(w http.ResponseWriter, r *http.Request, entry *cron.Entry) {
+	h.cron.Remove(entry.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// action returns the handler of an endpoint applying fn to the entry.
+func action(fn func(c *cron.Cron, id cron.EntryID)) func(h *handler, w http.ResponseWriter, r *http.Request, entry *cron.Entry) {
+	return func(h *handler, w http.ResponseWriter, r *http.Request, entry *cron.Entry) {
+		fn(h.cron, entry.ID)
+		// The entry may have been removed meanwhile.
+		e := h.cron.Entry(entry.ID)
+		if e == nil {
+			writeError(w, http.StatusNotFound, "entry not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, newEntry(e))
+	}
+}
+
+// health writes the health of all entries.
+func (h *handler) health(w http.ResponseWriter, r *http.Request, _ *cron.Entry) {
+	status := http.StatusOK
+	health := []Health{}
+	for _, eh := range h.cron.Health() {
+		if !eh.Healthy() {
+			status = http.StatusServiceUnavailable
+		}
+		health = append(health, newHealth(eh))
+	}
+	writeJSON(w, status, health)
+}
+
+// next writes the upcoming activations of the entry.
+func (h *handler) next(w http.ResponseWriter, r *http.Request, entry *cron.Entry) {
+	n := 5
+	if s := r.URL.Query().Get("n"); s != "" {
+		var err error
+		n, err = strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxNext {
+			writeError(w, http.StatusBadRequest, "n must be between 1 and "+strconv.Itoa(maxNext))
+			return
+		}
+	}
+
+	next := h.cron.Upcoming(entry.ID, n)
+	if next == nil {
+		next = []time.Time{}
+	}
+	writeJSON(w, http.StatusOK, next)
+}
+
+// calendar writes the upcoming activations of the entries as an iCalendar
+// feed.
+func (h *handler) calendar(w http.ResponseWriter, r *http.Request, _ *cron.Entry) {
+	query := r.URL.Query()
+	n := 10
+	if s := query.Get("n"); s != "" {
+		var err error
+		n, err = strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxNext {
+			writeError(w, http.StatusBadRequest, "n must be between 1 and "+strconv.Itoa(maxNext))
+			return
+		}
+	}
+	var ids []cron.EntryID
+	for _, s := range query["id"] {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid entry ID "+strconv.Quote(s))
+			return
+		}
+		ids = append(ids, cron.EntryID(id))
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Write([]byte(h.cron.ExportFeed(n, ids...)))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, apiError{msg})
+}
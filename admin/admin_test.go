@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+func do(t *testing.T, h http.Handler, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestListAndShowEntries(t *testing.T) {
+	c := cron.New()
+	id, _ := c.AddFunc("0 0 0 1 1 ?", func() {}, cron.WithMetadata("owner", "billing"))
+	c.Start()
+	defer c.Stop()
+	h := NewHandler(c)
+
+	rec := do(t, h, "GET", "/entries")
+	var entries []Entry
+	if err := json.NewDecoder(rec.Body).Decode(&entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ID != id || entries[0].Spec != "0 0 0 1 1 ?" || entries[0].Next == nil {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+	if entries[0].Metadata["owner"] != "billing" {
+		t.Errorf("expected the entry's metadata, got %v", entries[0].Metadata)
+	}
+
+	if rec := do(t, h, "GET", "/entries/42"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+
+	rec = do(t, h, "GET", "/entries/1/next?n=3")
+	var next []time.Time
+	if err := json.NewDecoder(rec.Body).Decode(&next); err != nil {
+		t.Fatal(err)
+	}
+	if len(next) != 3 || next[1].Year() != next[0].Year()+1 {
+		t.Errorf("unexpected next activations: %v", next)
+	}
+}
+
+func TestPauseResumeRemove(t *testing.T) {
+	c := cron.New()
+	id, _ := c.AddFunc("0 0 0 1 1 ?", func() {})
+	c.Start()
+	defer c.Stop()
+	h := NewHandler(c)
+
+	if rec := do(t, h, "GET", "/entries/1/pause"); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+	do(t, h, "POST", "/entries/1/pause")
+	if e := c.Entry(id); !e.Paused || !e.Next.IsZero() {
+		t.Errorf("expected entry to be paused: %+v", e)
+	}
+	do(t, h, "POST", "/entries/1/resume")
+	if e := c.Entry(id); e.Paused || e.Next.IsZero() {
+		t.Errorf("expected entry to be resumed: %+v", e)
+	}
+	if rec := do(t, h, "DELETE", "/entries/1"); rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", rec.Code)
+	}
+	if len(c.Entries()) != 0 {
+		t.Error("expected entry to be removed")
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	ran := make(chan struct{}, 1)
+	c := cron.New()
+	c.AddFunc("0 0 0 1 1 ?", func() { ran <- struct{}{} })
+	h := NewHandler(c)
+
+	do(t, h, "POST", "/entries/1/trigger")
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Error("expected job to run")
+	}
+}
+
+// An action on an entry removed while it runs responds 404 rather than
+// panicking.
+func TestActionOnRemovedEntry(t *testing.T) {
+	c := cron.New()
+	id, _ := c.AddFunc("0 0 0 1 1 ?", func() {})
+	entry := c.Entry(id)
+	h := &handler{cron: c}
+
+	rec := httptest.NewRecorder()
+	action(func(c *cron.Cron, id cron.EntryID) { c.Remove(id) })(h, rec, httptest.NewRequest("POST", "/entries/1/pause", nil), entry)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestBasicAuth(t *testing.T) {
+	h := NewHandler(cron.New(), WithMiddleware(BasicAuth("admin", "secret")))
+
+	if rec := do(t, h, "GET", "/entries"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest("GET", "/entries", nil)
+	req.SetBasicAuth("admin", "secret")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	c := cron.New()
+	c.AddContextFunc("0 0 0 1 1 ?", func(ctx context.Context) error { return errors.New("boom") })
+	h := NewHandler(c)
+
+	if rec := do(t, h, "GET", "/health"); rec.Code != http.StatusOK {
+		t.Errorf("expected 200 before any failure, got %d", rec.Code)
+	}
+	c.Trigger(1)
+	<-c.Stop().Done()
+
+	rec := do(t, h, "GET", "/health")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", rec.Code)
+	}
+	var health []Health
+	if err := json.NewDecoder(rec.Body).Decode(&health); err != nil {
+		t.Fatal(err)
+	}
+	if len(health) != 1 || health[0].ConsecutiveFailures != 1 || health[0].Healthy {
+		t.Errorf("unexpected health %+v", health)
+	}
+}
+
+func TestEntryStats(t *testing.T) {
+	c := cron.New()
+	c.AddContextFunc("0 0 0 1 1 ?", func(ctx context.Context) error { return errors.New("boom") })
+	h := NewHandler(c)
+	c.Trigger(1)
+	<-c.Stop().Done()
+
+	var entry Entry
+	if err := json.NewDecoder(do(t, h, "GET", "/entries/1").Body).Decode(&entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Stats.Runs != 1 || entry.Stats.Failures != 1 || entry.Stats.AvgDuration < 0 {
+		t.Errorf("unexpected stats %+v", entry.Stats)
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	c := cron.New()
+	c.AddFunc("0 0 0 1 1 ?", func() {}, cron.WithName("new-year"))
+	c.AddFunc("@daily", func() {}, cron.WithName("nightly"))
+	h := NewHandler(c)
+
+	rec := do(t, h, "GET", "/calendar.ics?n=3")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/calendar; charset=utf-8" {
+		t.Fatalf("expected the feed, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	body := rec.Body.String()
+	if n := strings.Count(body, "BEGIN:VEVENT"); n != 6 {
+		t.Errorf("expected 6 events, got %d:\n%s", n, body)
+	}
+
+	rec = do(t, h, "GET", "/calendar.ics?id=2")
+	body = rec.Body.String()
+	if strings.Count(body, "SUMMARY:nightly") != 10 || strings.Contains(body, "new-year") {
+		t.Errorf("expected 10 events of the nightly entry:\n%s", body)
+	}
+
+	for _, path := range []string{"/calendar.ics?n=0", "/calendar.ics?n=101", "/calendar.ics?id=x"} {
+		if rec := do(t, h, "GET", path); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/webconnex/cron/internal/jsonschema"
+)
+
+// openAPIPath is the path the OpenAPI document is served at.
+const openAPIPath = "/openapi.json"
+
+// openAPI is the encoded OpenAPI document.
+var openAPI, _ = json.Marshal(OpenAPI())
+
+// components are the types described once in the document and referred to
+// by name.
+var components = map[reflect.Type]string{
+	reflect.TypeOf(Entry{}):    "Entry",
+	reflect.TypeOf(Health{}):   "Health",
+	reflect.TypeOf(apiError{}): "Error",
+}
+
+// OpenAPI returns the OpenAPI 3.0 document describing the API, as served at
+// /openapi.json, for generating clients and tools.  It is generated from the
+// routes the handler serves and the types of their bodies.
+func OpenAPI() map[string]interface{} {
+	schemas := map[string]interface{}{}
+	for t, name := range components {
+		schemas[name] = jsonschema.Of(reflect.Zero(t).Interface())
+	}
+
+	paths := map[string]map[string]interface{}{}
+	for _, route := range routes {
+		var params []interface{}
+		responses := map[string]interface{}{}
+		if strings.Contains(route.path, "{id}") {
+			params = append(params, map[string]interface{}{
+				"name":        "id",
+				"in":          "path",
+				"required":    true,
+				"description": "The ID of the entry",
+				"schema":      map[string]interface{}{"type": "integer"},
+			})
+			responses["404"] = describeResponse(response{http.StatusNotFound, "The entry does not exist", apiError{}})
+		}
+		for _, p := range route.query {
+			params = append(params, map[string]interface{}{
+				"name":        p.name,
+				"in":          "query",
+				"description": p.description,
+				"schema":      p.schema,
+			})
+		}
+		for _, r := range route.responses {
+			responses[strconv.Itoa(r.status)] = describeResponse(r)
+		}
+		op := map[string]interface{}{
+			"operationId": route.id,
+			"summary":     route.summary,
+			"responses":   responses,
+		}
+		if params != nil {
+			op["parameters"] = params
+		}
+		if paths[route.path] == nil {
+			paths[route.path] = map[string]interface{}{}
+		}
+		paths[route.path][strings.ToLower(route.method)] = op
+	}
+	paths[openAPIPath] = map[string]interface{}{
+		"get": map[string]interface{}{
+			"operationId": "getOpenAPI",
+			"summary":     "Show this document",
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "The OpenAPI document",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{"schema": map[string]interface{}{"type": "object"}},
+					},
+				},
+			},
+		},
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "cron admin API",
+			"version": "1",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	}
+}
+
+// describeResponse returns the OpenAPI description of a response.
+func describeResponse(r response) map[string]interface{} {
+	d := map[string]interface{}{"description": r.description}
+	if r.body != nil {
+		mediaType := "application/json"
+		if _, ok := r.body.(calendar); ok {
+			mediaType = "text/calendar"
+		}
+		d["content"] = map[string]interface{}{
+			mediaType: map[string]interface{}{"schema": bodySchema(reflect.TypeOf(r.body))},
+		}
+	}
+	return d
+}
+
+// bodySchema returns the schema of a body of the given type, referring to
+// components by name.
+func bodySchema(t reflect.Type) interface{} {
+	if name, ok := components[t]; ok {
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	if t.Kind() == reflect.Slice {
+		return map[string]interface{}{"type": "array", "items": bodySchema(t.Elem())}
+	}
+	return jsonschema.Of(reflect.Zero(t).Interface())
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/webconnex/cron"
+)
+
+func TestOpenAPI(t *testing.T) {
+	h := NewHandler(cron.New())
+	rec := do(t, h, "GET", "/openapi.json")
+	if rec.Code != 200 || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("expected the document, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string                     `json:"operationId"`
+			Responses   map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != "3.0.3" {
+		t.Errorf("expected OpenAPI 3.0.3, got %q", doc.OpenAPI)
+	}
+	for _, route := range routes {
+		op, ok := doc.Paths[route.path][map[string]string{"GET": "get", "POST": "post", "DELETE": "delete"}[route.method]]
+		if !ok {
+			t.Errorf("%s %s: missing from the document", route.method, route.path)
+			continue
+		}
+		if op.OperationID != route.id {
+			t.Errorf("%s %s: expected operation %q, got %q", route.method, route.path, route.id, op.OperationID)
+		}
+	}
+	if !strings.Contains(string(doc.Paths["/calendar.ics"]["get"].Responses["200"]), `"text/calendar"`) {
+		t.Error("expected the feed to be documented as a calendar")
+	}
+	if _, ok := doc.Paths["/entries/{id}/pause"]["post"].Responses["404"]; !ok {
+		t.Error("expected entry routes to document a missing entry")
+	}
+	for _, name := range []string{"Entry", "Health", "Error"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("expected a %s schema", name)
+		}
+	}
+
+	if rec := do(t, h, "POST", "/openapi.json"); rec.Code != 405 {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
+
+func TestRouting(t *testing.T) {
+	c := cron.New()
+	c.AddFunc("@daily", func() {})
+	h := NewHandler(c)
+	for _, test := range []struct {
+		method, path string
+		code         int
+	}{
+		{"GET", "/entries/", 200},
+		{"POST", "/entries", 405},
+		{"GET", "/entries/abc", 404},
+		{"GET", "/entries/99", 404},
+		{"PUT", "/entries/1", 405},
+		{"GET", "/entries/1/pause", 405},
+		{"POST", "/entries/1/stop", 404},
+		{"GET", "/nothing", 404},
+	} {
+		if rec := do(t, h, test.method, test.path); rec.Code != test.code {
+			t.Errorf("%s %s: expected %d, got %d", test.method, test.path, test.code, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BackfillOptions configures a Backfill.
+type BackfillOptions struct {
+	// ChunkSize is the number of activations run concurrently.  Each chunk
+	// finishes before the next starts.  It is at least 1.
+	ChunkSize int
+
+	// RateLimit limits how many runs may start per second.  Its Overflow
+	// is ignored, as runs are always delayed rather than skipped.  A zero
+	// Rate does not limit them.
+	RateLimit RateLimit
+
+	// MaxActivations bounds the number of activations enumerated, so that
+	// a frequent schedule over a long time does not exhaust memory.  Zero
+	// does not bound them.
+	MaxActivations int
+
+	// OnChunk, if set, is called with the runs of each chunk once they
+	// have finished, such as to checkpoint the progress of a long
+	// backfill.
+	OnChunk func(runs []Run)
+}
+
+// Backfill is a plan to run the activations of a schedule over a past span
+// of time, such as those missed during an outage, or those before an entry
+// was added when onboarding historical data.  Run it with Cron.Backfill.
+type Backfill struct {
+	// Schedule is the schedule whose activations are backfilled.
+	Schedule Schedule
+
+	// From and To bound the activations backfilled, From inclusive and To
+	// exclusive.
+	From, To time.Time
+
+	// Activations are the activations of the schedule between From and
+	// To, in order.
+	Activations []time.Time
+
+	// Truncated is true if there are activations after the last of
+	// Activations, left out by the MaxActivations option.
+	Truncated bool
+
+	opts BackfillOptions
+}
+
+// NewBackfill enumerates the activations of the schedule from from until to
+// into a Backfill.
+func NewBackfill(schedule Schedule, from, to time.Time, opts BackfillOptions) *Backfill {
+	if opts.ChunkSize < 1 {
+		opts.ChunkSize = 1
+	}
+	b := &Backfill{Schedule: schedule, From: from, To: to, opts: opts}
+	for t := schedule.Next(from.Add(-time.Nanosecond)); !t.IsZero() && t.Before(to); t = schedule.Next(t) {
+		if opts.MaxActivations > 0 && len(b.Activations) == opts.MaxActivations {
+			b.Truncated = true
+			break
+		}
+		b.Activations = append(b.Activations, t)
+	}
+	return b
+}
+
+// Chunks divides the activations into the chunks they are run in.
+func (b *Backfill) Chunks() [][]time.Time {
+	var chunks [][]time.Time
+	for i := 0; i < len(b.Activations); i += b.opts.ChunkSize {
+		end := i + b.opts.ChunkSize
+		if end > len(b.Activations) {
+			end = len(b.Activations)
+		}
+		chunks = append(chunks, b.Activations[i:end:end])
+	}
+	return chunks
+}
+
+// Backfill runs the job of the entry with the given ID once for each of the
+// backfill's activations, chunk by chunk, through the Cron's Executor.  Each
+// run is for its activation as if it had been dispatched on schedule: it has
+// the activation's RunKey and scheduled time, is recorded and reported as
+// scheduled runs are, and is not run if the Store has already recorded the
+// activation, so that a backfill may be repeated after an interruption.  The
+// rate limits and concurrency limits of the Cron do not apply, only the
+// backfill's own.
+//
+// Backfill blocks until the runs have finished, and returns them in order.
+// If ctx is done first, which the jobs' contexts follow, it returns the runs
+// started so far and the context's error.
+func (c *Cron) Backfill(ctx context.Context, id EntryID, b *Backfill) ([]Run, error) {
+	var entry *Entry
+	c.do(func() {
+		if e := c.find(id); e != nil {
+			entry = e.snapshot()
+		}
+	})
+	if entry == nil {
+		return nil, fmt.Errorf("cron: no entry with ID %d", id)
+	}
+	if _, ok := entry.Job.(*observer); ok {
+		return nil, fmt.Errorf("cron: entry %d is an observer", id)
+	}
+	// Backfilled runs are late by design.
+	entry.SLA = SLA{}
+	var limiter *tokenBucket
+	if b.opts.RateLimit.Rate > 0 {
+		limiter = newTokenBucket(b.opts.RateLimit)
+	}
+	var runs []Run
+	for _, chunk := range b.Chunks() {
+		batch := make([]activation, len(chunk))
+		for i, t := range chunk {
+			batch[i] = activation{entry: entry, scheduled: t}
+		}
+		batch = c.claim(batch)
+		results := make([]Run, len(batch))
+		var wg sync.WaitGroup
+		for i, a := range batch {
+			if limiter != nil {
+				if err := c.sleep(ctx, limiter.reserve(c.now())); err != nil {
+					wg.Wait()
+					return append(runs, results[:i]...), err
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				wg.Wait()
+				return append(runs, results[:i]...), err
+			}
+			run := Run{
+				Key:       a.key,
+				ID:        newRunID(),
+				EntryID:   entry.ID,
+				Scheduled: a.scheduled,
+				Host:      c.host,
+			}
+			runCtx := context.WithValue(ctx, runKeyContextKey, a.key)
+			runCtx = context.WithValue(runCtx, runIDContextKey, run.ID)
+			rlog := c.runLog(entry, run)
+			wg.Add(1)
+			c.jobs.Add(1)
+			c.inflight.update(1, 0)
+			go func(i int) {
+				defer wg.Done()
+				defer c.jobs.Done()
+				// A backfilled run is never late.
+				results[i] = c.execute(runCtx, entry, run, rlog, c.now())
+			}(i)
+		}
+		wg.Wait()
+		if b.opts.OnChunk != nil {
+			b.opts.OnChunk(results)
+		}
+		runs = append(runs, results...)
+	}
+	return runs, nil
+}
+
+// sleep waits for the duration on the Cron's clock, or until ctx is done.
+func (c *Cron) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := c.clock.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
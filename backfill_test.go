@@ -0,0 +1,124 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewBackfill(t *testing.T) {
+	s, _ := Parse("0 0 * * * *")
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBackfill(s, from, from.Add(5*time.Hour), BackfillOptions{ChunkSize: 2})
+	if len(b.Activations) != 5 || !b.Activations[0].Equal(from) || b.Truncated {
+		t.Errorf("expected 5 activations from %v, got %v", from, b.Activations)
+	}
+	chunks := b.Chunks()
+	if len(chunks) != 3 || len(chunks[0]) != 2 || len(chunks[2]) != 1 {
+		t.Errorf("expected chunks of 2, 2 and 1, got %v", chunks)
+	}
+
+	b = NewBackfill(s, from, from.AddDate(1, 0, 0), BackfillOptions{MaxActivations: 10})
+	if len(b.Activations) != 10 || !b.Truncated {
+		t.Errorf("expected 10 activations, truncated, got %d, %v", len(b.Activations), b.Truncated)
+	}
+	if n := len(b.Chunks()); n != 10 {
+		t.Errorf("expected chunks of 1, got %d", n)
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	store := NewMemoryStore()
+	var (
+		mu   sync.Mutex
+		keys []RunKey
+	)
+	cron := New(WithStore(store), WithExecutor(ExecutorFunc(func(ctx context.Context, e *Entry, run Run) error {
+		key, _ := RunKeyFromContext(ctx)
+		mu.Lock()
+		keys = append(keys, key)
+		mu.Unlock()
+		if run.Scheduled.Hour() == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	})))
+	cron.ErrorLog = log.New(ioutil.Discard, "", 0)
+	id, _ := cron.AddFunc("0 0 * * * *", func() {})
+
+	s, _ := Parse("0 0 * * * *")
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var chunks [][]Run
+	b := NewBackfill(s, from, from.Add(4*time.Hour), BackfillOptions{
+		ChunkSize: 3,
+		OnChunk:   func(runs []Run) { chunks = append(chunks, runs) },
+	})
+	runs, err := cron.Backfill(context.Background(), id, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 4 || len(chunks) != 2 || len(keys) != 4 {
+		t.Fatalf("expected 4 runs in 2 chunks, got %v in %v", runs, chunks)
+	}
+	for i, run := range runs {
+		expected := OutcomeSuccess
+		if i == 2 {
+			expected = OutcomeFailure
+		}
+		if !run.Scheduled.Equal(b.Activations[i]) || run.Key != NewRunKey(id, b.Activations[i]) || run.Outcome != expected {
+			t.Errorf("run %d: unexpected %+v", i, run)
+		}
+	}
+	if history, _ := cron.History(id, time.Time{}, 0); len(history) != 4 {
+		t.Errorf("expected the runs to be recorded, got %v", history)
+	}
+
+	// The activations are claimed, so a repeated backfill does not run them.
+	runs, err = cron.Backfill(context.Background(), id, b)
+	if err != nil || len(runs) != 0 {
+		t.Errorf("expected no runs, got %v, %v", runs, err)
+	}
+}
+
+func TestBackfillRateLimit(t *testing.T) {
+	cron := New()
+	id, _ := cron.AddFunc("@yearly", func() {})
+	s, _ := Parse("* * * * * *")
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBackfill(s, from, from.Add(4*time.Second), BackfillOptions{
+		ChunkSize: 4,
+		RateLimit: RateLimit{Rate: 20, Burst: 1},
+	})
+	start := time.Now()
+	if _, err := cron.Backfill(context.Background(), id, b); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 140*time.Millisecond {
+		t.Errorf("expected 4 runs at 20 a second to take 150ms, took %v", elapsed)
+	}
+}
+
+func TestBackfillCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cron := New()
+	id, _ := cron.AddFunc("@yearly", func() { cancel() })
+	s, _ := Parse("0 0 * * * *")
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBackfill(s, from, from.Add(10*time.Hour), BackfillOptions{})
+	runs, err := cron.Backfill(ctx, id, b)
+	if err != context.Canceled || len(runs) != 1 {
+		t.Errorf("expected one run before the cancellation, got %v, %v", runs, err)
+	}
+}
+
+func TestBackfillUnknownEntry(t *testing.T) {
+	s, _ := Parse("0 0 * * * *")
+	b := NewBackfill(s, time.Now(), time.Now().Add(time.Hour), BackfillOptions{})
+	if _, err := New().Backfill(context.Background(), 1, b); err == nil {
+		t.Error("expected an error for an unknown entry")
+	}
+}
@@ -0,0 +1,196 @@
+package cron
+
+import "sync"
+
+// BackpressurePolicy selects what happens to a run dispatched while all
+// workers are busy and the backlog of runs waiting for one is full.
+type BackpressurePolicy int
+
+const (
+	// DropNewest drops the run being dispatched, recording it as skipped.
+	DropNewest BackpressurePolicy = iota
+
+	// DropOldest drops the run that has waited longest in the backlog,
+	// recording it as skipped, and queues the new one.
+	DropOldest
+
+	// Block holds the run until there is room in the backlog for it.  While
+	// a run is held no entry is activated, though jobs may still call the
+	// Cron, and runs dispatched by Trigger or after a jitter delay are held
+	// too.
+	Block
+)
+
+// Backpressure bounds the number of jobs running at once, and the number of
+// runs waiting to start.
+type Backpressure struct {
+	// Workers is the number of jobs that may run at once.  It is at
+	// least 1.
+	Workers int
+
+	// Backlog is the number of runs that may wait for a free worker.
+	Backlog int
+
+	// Policy selects what happens to runs dispatched when the backlog is
+	// full.
+	Policy BackpressurePolicy
+}
+
+// WithBackpressure returns an Option that runs jobs on a bounded pool of
+// workers, applying the given Backpressure once the pool is saturated.  Each
+// time a run is dispatched to a full backlog, a BacklogFull event is sent.
+// Without this option every run starts in its own goroutine as soon as it is
+// due.
+func WithBackpressure(b Backpressure) Option {
+	return func(c *Cron) {
+		c.pool = newWorkerPool(b)
+	}
+}
+
+// task is a run waiting for a worker.  limit is the state of the namespace
+// whose concurrency limit the run counts against, if any.
+type task struct {
+	run       Run
+	rlog      runLog
+	namespace string
+	limit     *namespace
+	fn        func()
+}
+
+// start runs the task on a worker, applying the backpressure policy if
+// there is none free.
+func (c *Cron) start(t *task) {
+	if c.pool == nil {
+		go t.fn()
+		return
+	}
+	dropped, full := c.pool.offer(t)
+	if !full {
+		return
+	}
+	run := t.run
+	c.emit(BacklogFull, t.namespace, run.EntryID, &run)
+	if dropped != nil {
+		c.drop(dropped)
+	}
+}
+
+// drop records that the task was dropped for lack of a worker.
+func (c *Cron) drop(t *task) {
+	defer c.jobs.Done()
+	defer c.inflight.update(-1, 0)
+	defer t.limit.release()
+	run := t.run
+	run.Outcome = OutcomeSkipped
+	run.Error = "dispatch backlog full"
+	t.rlog.logf("cron: skipping run %s: %s", t.rlog.name, run.Error)
+	c.record(run)
+	c.health.activated(run.EntryID, false)
+	c.emit(RunSkipped, t.namespace, run.EntryID, &run)
+}
+
+// workerPool runs tasks on at most Workers goroutines, which are started as
+// tasks arrive and exit once the backlog is empty.  Under Block, tasks with
+// no room in the backlog are held until there is, and room is signalled
+// once none is held.
+type workerPool struct {
+	Backpressure
+	mu      sync.Mutex
+	room    chan struct{}
+	busy    int
+	backlog []*task
+	held    []*task
+}
+
+func newWorkerPool(b Backpressure) *workerPool {
+	if b.Workers < 1 {
+		b.Workers = 1
+	}
+	if b.Backlog < 0 {
+		b.Backlog = 0
+	}
+	return &workerPool{Backpressure: b, room: make(chan struct{}, 1)}
+}
+
+// offer starts or queues the task if there is room.  Otherwise it reports
+// the backlog as full and, unless the policy is Block, under which it holds
+// the task, returns the task it dropped to make room.
+func (p *workerPool) offer(t *task) (dropped *task, full bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.add(t) {
+		return nil, false
+	}
+	switch p.Policy {
+	case Block:
+		p.held = append(p.held, t)
+		return nil, true
+	case DropOldest:
+		if len(p.backlog) > 0 {
+			dropped = p.backlog[0]
+			p.backlog = append(p.backlog[1:], t)
+			return dropped, true
+		}
+	}
+	return t, true
+}
+
+// blocked reports whether the pool holds tasks waiting for room in the
+// backlog.  A nil pool never does.
+func (p *workerPool) blocked() bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.held) > 0
+}
+
+// add starts the task on a new worker or appends it to the backlog,
+// returning false if there is no room for it.  The caller must hold mu.
+func (p *workerPool) add(t *task) bool {
+	if p.busy < p.Workers {
+		p.busy++
+		go p.work(t)
+		return true
+	}
+	if len(p.backlog) < p.Backlog {
+		p.backlog = append(p.backlog, t)
+		return true
+	}
+	return false
+}
+
+// work runs the task, then the backlog, until it is empty.  Each task taken
+// from the backlog makes room for the oldest held one.
+func (p *workerPool) work(t *task) {
+	for t != nil {
+		t.fn()
+		p.mu.Lock()
+		t = nil
+		if len(p.backlog) > 0 {
+			t = p.backlog[0]
+			p.backlog[0] = nil
+			p.backlog = p.backlog[1:]
+		}
+		if len(p.held) > 0 {
+			if t == nil {
+				t = p.held[0]
+			} else {
+				p.backlog = append(p.backlog, p.held[0])
+			}
+			p.held[0] = nil
+			p.held = p.held[1:]
+			if len(p.held) == 0 {
+				select {
+				case p.room <- struct{}{}:
+				default:
+				}
+			}
+		}
+		if t == nil {
+			p.busy--
+		}
+		p.mu.Unlock()
+	}
+}
@@ -0,0 +1,155 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// blockingJobs adds n entries whose jobs send their index on started and then
+// wait for release to be closed.
+func blockingJobs(cron *Cron, n int, started chan<- int, release <-chan struct{}) []EntryID {
+	var ids []EntryID
+	for i := 0; i < n; i++ {
+		i := i
+		id, _ := cron.AddFunc("@yearly", func() {
+			started <- i
+			<-release
+		})
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestBackpressureDropNewest(t *testing.T) {
+	cron := New(WithBackpressure(Backpressure{Workers: 1, Backlog: 1, Policy: DropNewest}))
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+	started, release := make(chan int, 3), make(chan struct{})
+	ids := blockingJobs(cron, 3, started, release)
+
+	for _, id := range ids {
+		cron.Trigger(id)
+	}
+	if event := expectEvent(t, events, BacklogFull); event.EntryID != ids[2] {
+		t.Errorf("expected a full backlog for entry %d, got %d", ids[2], event.EntryID)
+	}
+	if event := expectEvent(t, events, RunSkipped); event.EntryID != ids[2] || event.Run.Outcome != OutcomeSkipped {
+		t.Errorf("expected entry %d to be dropped, got %+v", ids[2], event)
+	}
+
+	close(release)
+	<-cron.Stop().Done()
+	close(started)
+	var order []int
+	for i := range started {
+		order = append(order, i)
+	}
+	if len(order) != 2 || order[0] != 0 || order[1] != 1 {
+		t.Errorf("expected the first two jobs to run in order, got %v", order)
+	}
+}
+
+func TestBackpressureDropOldest(t *testing.T) {
+	cron := New(WithBackpressure(Backpressure{Workers: 1, Backlog: 1, Policy: DropOldest}))
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+	started, release := make(chan int, 3), make(chan struct{})
+	ids := blockingJobs(cron, 3, started, release)
+
+	for _, id := range ids {
+		cron.Trigger(id)
+	}
+	if event := expectEvent(t, events, RunSkipped); event.EntryID != ids[1] {
+		t.Errorf("expected entry %d to be dropped, got %d", ids[1], event.EntryID)
+	}
+
+	close(release)
+	<-cron.Stop().Done()
+	close(started)
+	var order []int
+	for i := range started {
+		order = append(order, i)
+	}
+	if len(order) != 2 || order[0] != 0 || order[1] != 2 {
+		t.Errorf("expected the first and last jobs to run, got %v", order)
+	}
+}
+
+func TestBackpressureBlock(t *testing.T) {
+	cron := New(WithBackpressure(Backpressure{Workers: 1, Policy: Block}))
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+	started, release := make(chan int, 2), make(chan struct{})
+	ids := blockingJobs(cron, 2, started, release)
+
+	cron.Trigger(ids[0])
+	<-started
+	cron.Trigger(ids[1])
+	expectEvent(t, events, BacklogFull)
+	select {
+	case i := <-started:
+		t.Fatalf("expected job %d to be held while the worker is busy", i)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case i := <-started:
+		if i != 1 {
+			t.Errorf("expected the second job to run, got %d", i)
+		}
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the held run to start once the worker is free")
+	}
+	<-cron.Stop().Done()
+}
+
+func TestBackpressureLimitsWorkers(t *testing.T) {
+	cron := New(WithBackpressure(Backpressure{Workers: 2, Backlog: 10}))
+	started, release := make(chan int, 5), make(chan struct{})
+	for _, id := range blockingJobs(cron, 5, started, release) {
+		cron.Trigger(id)
+	}
+	<-started
+	<-started
+	select {
+	case i := <-started:
+		t.Fatalf("expected only two jobs to run at once, job %d started", i)
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	<-cron.Stop().Done()
+	if len(started) != 3 {
+		t.Errorf("expected the backlog to drain, %d jobs left", 3-len(started))
+	}
+}
+
+// A job calling the Cron while a run is held for room does not deadlock it.
+func TestBackpressureBlockCallback(t *testing.T) {
+	cron := New(WithBackpressure(Backpressure{Workers: 1, Policy: Block}))
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+	held, done := make(chan struct{}), make(chan struct{})
+	var first EntryID
+	first, _ = cron.AddFunc("@yearly", func() {
+		<-held
+		cron.Pause(first)
+		close(done)
+	})
+	second, _ := cron.AddFunc("@yearly", func() {})
+	cron.Start()
+	defer cron.Stop()
+
+	cron.Trigger(first)
+	cron.Trigger(second)
+	expectEvent(t, events, BacklogFull)
+	close(held)
+	select {
+	case <-done:
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the job to call the Cron while a run is held")
+	}
+	if e := cron.Entry(first); !e.Paused {
+		t.Error("expected the entry to be paused by its job")
+	}
+}
@@ -0,0 +1,94 @@
+package cron
+
+import "time"
+
+// BatchClaimer may be implemented by a Store to claim several activations at
+// once.  When the configured Store implements it, the activations due at the
+// same wakeup are claimed with a single call rather than one Claim each.
+type BatchClaimer interface {
+	// ClaimBatch is Claim for each of the keys, returning whether each was
+	// claimed, in order.
+	ClaimBatch(keys []RunKey) ([]bool, error)
+}
+
+// WithBatchHook returns an Option that calls hook for each batch of
+// activations dispatched together, with the batch's scheduled time and a
+// snapshot of its entries, before their jobs start.  Activations the Store
+// reports as already executed are not included.  The hook runs on the
+// scheduler's goroutine, so it should return quickly.
+func WithBatchHook(hook func(scheduled time.Time, batch []*Entry)) Option {
+	return func(c *Cron) {
+		c.batchHook = hook
+	}
+}
+
+// activation is a run of an entry about to be dispatched.
+type activation struct {
+	entry     *Entry
+	scheduled time.Time
+	delay     time.Duration
+	key       RunKey
+	location  *time.Location // of an entry with several, see WithLocations
+}
+
+// claim assigns each activation its RunKey and returns those successfully
+// claimed in the configured Store.
+func (c *Cron) claim(batch []activation) []activation {
+	for i := range batch {
+		batch[i].key = NewRunKey(batch[i].entry.ID, batch[i].scheduled)
+		if loc := batch[i].location; loc != nil {
+			batch[i].key += RunKey("/" + loc.String())
+		}
+	}
+	if c.store == nil || len(batch) == 0 {
+		return batch
+	}
+	claimed := make([]activation, 0, len(batch))
+	if bc, ok := c.store.(BatchClaimer); ok {
+		keys := make([]RunKey, len(batch))
+		for i, a := range batch {
+			keys[i] = a.key
+		}
+		oks, err := bc.ClaimBatch(keys)
+		if err != nil {
+			c.logf("cron: failed to claim %d runs: %v", len(keys), err)
+			for _, a := range batch {
+				c.health.activated(a.entry.ID, false)
+			}
+			return nil
+		}
+		for i, a := range batch {
+			if oks[i] {
+				claimed = append(claimed, a)
+			}
+		}
+		return claimed
+	}
+	for _, a := range batch {
+		ok, err := c.store.Claim(a.key)
+		if err != nil {
+			c.logf("cron: failed to claim run %s: %v", a.key, err)
+			c.health.activated(a.entry.ID, false)
+			continue
+		}
+		if ok {
+			claimed = append(claimed, a)
+		}
+	}
+	return claimed
+}
+
+// batchStarted calls the batch hook for each scheduled time in the batch.
+func (c *Cron) batchStarted(batch []activation) {
+	if c.batchHook == nil {
+		return
+	}
+	for i := 0; i < len(batch); {
+		scheduled := batch[i].scheduled
+		var entries []*Entry
+		for ; i < len(batch) && batch[i].scheduled.Equal(scheduled); i++ {
+			entries = append(entries, batch[i].entry.snapshot())
+		}
+		c.batchHook(scheduled, entries)
+	}
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreClaimBatch(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	a, b := NewRunKey(1, now), NewRunKey(2, now)
+	store.Claim(a)
+
+	oks, err := store.ClaimBatch([]RunKey{a, b, b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oks[0] || !oks[1] || oks[2] {
+		t.Errorf("expected only the first claim of b to succeed, got %v", oks)
+	}
+}
+
+// Entries firing on the same second are handed to the batch hook together.
+func TestBatchHook(t *testing.T) {
+	batches := make(chan []*Entry, 10)
+	cron := New(WithBatchHook(func(scheduled time.Time, batch []*Entry) {
+		for _, e := range batch {
+			if !e.Next.Equal(scheduled) {
+				t.Errorf("entry %d scheduled at %v, batch at %v", e.ID, e.Next, scheduled)
+			}
+		}
+		batches <- batch
+	}))
+	for i := 0; i < 5; i++ {
+		cron.AddFunc("* * * * * ?", func() {})
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case batch := <-batches:
+		if len(batch) != 5 {
+			t.Errorf("expected a batch of 5 entries, got %d", len(batch))
+		}
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected a batch")
+	}
+}
+
+// Activations already claimed are left out of the batch.
+func TestBatchSkipsClaimed(t *testing.T) {
+	store := NewMemoryStore()
+	cron := New(WithStore(store))
+	a := cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+	b := cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+	scheduled := time.Now()
+	store.Claim(NewRunKey(a, scheduled))
+
+	batch := cron.claim([]activation{
+		{entry: cron.find(a), scheduled: scheduled},
+		{entry: cron.find(b), scheduled: scheduled},
+	})
+	if len(batch) != 1 || batch[0].entry.ID != b {
+		t.Fatalf("expected only entry %d claimed, got %v", b, batch)
+	}
+	if batch[0].key != NewRunKey(b, scheduled) {
+		t.Errorf("unexpected key %s", batch[0].key)
+	}
+}
@@ -0,0 +1,60 @@
+package cron
+
+import "time"
+
+// Blackout is a recurring window during which no scheduled runs are
+// dispatched, see WithBlackout.
+type Blackout struct {
+	// Schedule gives the starts of the windows.
+	Schedule Schedule
+
+	// Duration is the length of each window.
+	Duration time.Duration
+}
+
+// ParseBlackout returns the Blackout whose windows start at the activations
+// of the spec, in the syntax of Parse, and last for d, as in
+// ParseBlackout("0 0 2 * * SUN", 2*time.Hour) for two hours from 2am every
+// Sunday.
+func ParseBlackout(spec string, d time.Duration) (Blackout, error) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return Blackout{}, err
+	}
+	return Blackout{Schedule: schedule, Duration: d}, nil
+}
+
+// WithBlackout returns an Option that skips the scheduled runs of all
+// entries due within the windows of the blackout, for maintenance windows,
+// recording them as skipped.  It may be given several times, to add several
+// blackouts.  Entries added with IgnoreBlackouts still run, as do runs
+// started with Trigger or Backfill.
+func WithBlackout(b Blackout) Option {
+	return func(c *Cron) {
+		c.blackouts = append(c.blackouts, b)
+	}
+}
+
+// IgnoreBlackouts returns an EntryOption that lets the entry run within the
+// windows set with WithBlackout, as a job doing the maintenance would.
+func IgnoreBlackouts() EntryOption {
+	return func(e *Entry) {
+		e.IgnoreBlackouts = true
+	}
+}
+
+// blackedOut reports whether the scheduled runs of the entry due at t are
+// within a blackout window.
+func (c *Cron) blackedOut(e *Entry, t time.Time) bool {
+	if e.IgnoreBlackouts {
+		return false
+	}
+	for _, b := range c.blackouts {
+		// The last window starting no later than t, if any covers it.
+		start := b.Schedule.Next(t.Add(-b.Duration))
+		if !start.IsZero() && !start.After(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlackedOut(t *testing.T) {
+	b, err := ParseBlackout("0 0 2 * * SUN", 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cron := NewWithLocation(time.UTC, WithBlackout(b))
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2024, time.March, day, hour, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		t    time.Time
+		want bool
+	}{
+		{at(10, 1, 59), false},
+		{at(10, 2, 0), true},
+		{at(10, 3, 59), true},
+		{at(10, 4, 0), false},
+		{at(11, 2, 30), false},
+	}
+	for _, test := range tests {
+		if got := cron.blackedOut(&Entry{}, test.t); got != test.want {
+			t.Errorf("%v: got %v, want %v", test.t, got, test.want)
+		}
+	}
+	if cron.blackedOut(&Entry{IgnoreBlackouts: true}, at(10, 2, 30)) {
+		t.Error("expected an entry ignoring blackouts not to be blacked out")
+	}
+
+	if _, err := ParseBlackout("bogus", time.Hour); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+}
+
+// Runs due within a blackout are skipped, except those of entries ignoring
+// it.
+func TestBlackoutSkipsRuns(t *testing.T) {
+	cron := New(WithBlackout(Blackout{Schedule: Every(time.Hour), Duration: 2 * time.Hour}))
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+	ran := make(chan struct{}, 10)
+	cron.AddFunc("* * * * * ?", func() {})
+	cron.AddFunc("* * * * * ?", func() { ran <- struct{}{} }, IgnoreBlackouts())
+	cron.Start()
+	defer cron.Stop()
+
+	expectEvent(t, events, RunSkipped)
+	select {
+	case <-ran:
+	case <-time.After(2 * ONE_SECOND):
+		t.Fatal("expected the entry ignoring blackouts to run")
+	}
+}
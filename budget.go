@@ -0,0 +1,103 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// Budget limits the total time an entry's runs may take per window, see
+// WithBudget.
+type Budget struct {
+	// Runtime is the total time the runs started in a window may take.
+	Runtime time.Duration
+
+	// Window is the length of the windows, which start at multiples of it
+	// from the zero time, as time.Truncate rounds.  Zero means calendar
+	// days, from midnight in the Cron's time zone.
+	Window time.Duration
+}
+
+// WithBudget returns an EntryOption that limits the total time the entry's
+// runs may take per window, to protect shared resources, such as a
+// database, from a runaway reprocessing job.  A run is charged to the
+// window it started in when it finishes.  Once the runs of a window have
+// taken the budget's Runtime, a BudgetExhausted event is sent, and the
+// entry's activations are skipped until the next window.  Runs already
+// running are not stopped, and runs started with Trigger or Backfill are
+// charged but not skipped.
+func WithBudget(budget Budget) EntryOption {
+	return func(e *Entry) {
+		e.Budget = budget
+	}
+}
+
+// budgetTracker records the time the runs of entries with a Budget took
+// in the current window.  Runs finish on their own goroutines, and are
+// activated on the scheduler's.
+type budgetTracker struct {
+	mu      sync.Mutex
+	entries map[EntryID]*budgetUsage
+}
+
+type budgetUsage struct {
+	window    time.Time
+	used      time.Duration
+	exhausted bool
+}
+
+// window returns the start of the budget's window containing t.
+func (c *Cron) window(b Budget, t time.Time) time.Time {
+	if b.Window > 0 {
+		return t.Truncate(b.Window)
+	}
+	t = t.In(c.Location())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// spend charges the finished run to its entry's budget, reporting whether
+// it exhausted the budget.
+func (c *Cron) spend(entry *Entry, run Run) bool {
+	b := entry.Budget
+	if b.Runtime <= 0 {
+		return false
+	}
+	window := c.window(b, run.Start)
+	t := &c.budgets
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.entries == nil {
+		t.entries = make(map[EntryID]*budgetUsage)
+	}
+	u := t.entries[entry.ID]
+	if u == nil || !u.window.Equal(window) {
+		u = &budgetUsage{window: window}
+		t.entries[entry.ID] = u
+	}
+	u.used += run.End.Sub(run.Start)
+	if u.exhausted || u.used < b.Runtime {
+		return false
+	}
+	u.exhausted = true
+	return true
+}
+
+// exhausted reports whether the entry's budget for the window containing
+// now is exhausted.
+func (c *Cron) exhausted(e *Entry, now time.Time) bool {
+	if e.Budget.Runtime <= 0 {
+		return false
+	}
+	window := c.window(e.Budget, now)
+	t := &c.budgets
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u := t.entries[e.ID]
+	return u != nil && u.exhausted && u.window.Equal(window)
+}
+
+// forget drops the usage of a removed entry.
+func (t *budgetTracker) forget(id EntryID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, id)
+}
@@ -0,0 +1,57 @@
+package cron
+
+import (
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestBudget(t *testing.T) {
+	cron := New()
+	cron.ErrorLog = log.New(ioutil.Discard, "", 0)
+	id, _ := cron.AddFunc("* * * * * ?", func() { time.Sleep(300 * time.Millisecond) },
+		WithBudget(Budget{Runtime: 250 * time.Millisecond, Window: time.Hour}))
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+	cron.Start()
+	defer cron.Stop()
+
+	if event := expectEvent(t, events, BudgetExhausted); event.EntryID != id || event.Run == nil {
+		t.Errorf("unexpected event %+v", event)
+	}
+	event := expectEvent(t, events, RunSkipped)
+	if event.Run.Outcome != OutcomeSkipped || event.Run.Error != "runtime budget exhausted" {
+		t.Errorf("expected the activation to be skipped, got %+v", event.Run)
+	}
+}
+
+func TestBudgetWindows(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	cron := NewWithLocation(newYork)
+	e := &Entry{ID: 1, Budget: Budget{Runtime: time.Minute}}
+	start := time.Date(2024, time.January, 1, 23, 0, 0, 0, newYork)
+	run := func(at time.Time, d time.Duration) bool {
+		return cron.spend(e, Run{Start: at, End: at.Add(d)})
+	}
+
+	if run(start, 40*time.Second) || cron.exhausted(e, start) {
+		t.Error("expected the budget to remain")
+	}
+	if !run(start.Add(time.Minute), 30*time.Second) || !cron.exhausted(e, start.Add(2*time.Minute)) {
+		t.Error("expected the budget to be exhausted")
+	}
+	if run(start.Add(2*time.Minute), time.Second) {
+		t.Error("expected the budget to be reported exhausted once")
+	}
+	// The day ends at midnight in the Cron's time zone.
+	if next := start.Add(time.Hour); cron.exhausted(e, next) || run(next, 50*time.Second) {
+		t.Error("expected a new budget the next day")
+	}
+
+	cron.budgets.forget(e.ID)
+	e.Budget.Window = 10 * time.Minute
+	if !run(start, 2*time.Minute) || cron.exhausted(e, start.Add(10*time.Minute)) {
+		t.Error("expected a new budget each window")
+	}
+}
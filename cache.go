@@ -0,0 +1,117 @@
+package cron
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ParseCache is a size-limited cache of parsed specs, for programs parsing
+// the same specs repeatedly, such as per request.  It evicts the least
+// recently used schedule when full.  A ParseCache is safe for concurrent
+// use, and may be shared by several Crons, whatever their options.
+//
+// The schedules it returns are shared between callers, and must not be
+// modified.  Specs that fail to parse are not cached.
+type ParseCache struct {
+	size int
+
+	mu      sync.Mutex
+	lru     *list.List // of *cached, most recently used first
+	entries map[parseKey]*list.Element
+	stats   ParseCacheStats
+}
+
+// ParseCacheStats counts the lookups of a ParseCache.
+type ParseCacheStats struct {
+	// Hits and Misses count the specs found and not found in the cache.
+	Hits, Misses uint64
+
+	// Evictions counts the schedules evicted to make room for others.
+	Evictions uint64
+
+	// Len is the number of schedules in the cache.
+	Len int
+}
+
+// parseKey identifies a spec and the options of the parser it is parsed
+// with.
+type parseKey struct {
+	spec         string
+	robfigSpecs  bool
+	millisSpecs  bool
+	monthlyAtEnd bool
+	resolution   time.Duration
+	fields       *FieldRegistry
+}
+
+type cached struct {
+	key      parseKey
+	schedule Schedule
+}
+
+// NewParseCache returns a ParseCache holding up to size schedules.
+func NewParseCache(size int) *ParseCache {
+	if size < 1 {
+		size = 1
+	}
+	return &ParseCache{size: size, lru: list.New(), entries: make(map[parseKey]*list.Element)}
+}
+
+// WithParseCache returns an Option that makes the Cron look up the specs it
+// parses, in AddJob and Parse, in the cache.
+func WithParseCache(cache *ParseCache) Option {
+	return func(c *Cron) {
+		c.parseCache = cache
+	}
+}
+
+// Parse returns the schedule for a spec, as Parse does, from the cache if it
+// holds it.
+func (pc *ParseCache) Parse(spec string) (Schedule, error) {
+	return pc.get(parseKey{spec: spec}, Parse)
+}
+
+// Stats returns the counts of the cache's lookups.
+func (pc *ParseCache) Stats() ParseCacheStats {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	stats := pc.stats
+	stats.Len = pc.lru.Len()
+	return stats
+}
+
+// get returns the schedule for the key, parsing its spec with parse and
+// caching the result if it is not in the cache.
+func (pc *ParseCache) get(key parseKey, parse func(string) (Schedule, error)) (Schedule, error) {
+	pc.mu.Lock()
+	if e, ok := pc.entries[key]; ok {
+		pc.lru.MoveToFront(e)
+		pc.stats.Hits++
+		pc.mu.Unlock()
+		return e.Value.(*cached).schedule, nil
+	}
+	pc.stats.Misses++
+	pc.mu.Unlock()
+
+	// Parse without holding the lock, so that lookups of other specs are
+	// not held up.  Concurrent misses of a spec may parse it more than once.
+	schedule, err := parse(key.spec)
+	if err != nil {
+		return nil, err
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if e, ok := pc.entries[key]; ok {
+		return e.Value.(*cached).schedule, nil
+	}
+	pc.entries[key] = pc.lru.PushFront(&cached{key, schedule})
+	for pc.lru.Len() > pc.size {
+		oldest := pc.lru.Back()
+		pc.lru.Remove(oldest)
+		delete(pc.entries, oldest.Value.(*cached).key)
+		pc.stats.Evictions++
+	}
+	return schedule, nil
+}
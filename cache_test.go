@@ -0,0 +1,84 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseCache(t *testing.T) {
+	cache := NewParseCache(2)
+	a, err := cache.Parse("0 30 9 * * MON-FRI")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := cache.Parse("0 30 9 * * MON-FRI"); b != a {
+		t.Error("expected the cached schedule to be shared")
+	}
+	if _, err := cache.Parse("0 0 25 * * *"); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+	cache.Parse("@hourly")
+	cache.Parse("@daily")
+
+	stats := cache.Stats()
+	expected := ParseCacheStats{Hits: 1, Misses: 4, Evictions: 1, Len: 2}
+	if stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+	if b, _ := cache.Parse("0 30 9 * * MON-FRI"); b == a {
+		t.Error("expected the least recently used schedule to be evicted")
+	}
+}
+
+func TestParseCacheConcurrent(t *testing.T) {
+	cache := NewParseCache(10)
+	specs := []string{"@hourly", "@daily", "*/5 * * * * *", "0 0 12 1 * *"}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := cache.Parse(specs[j%len(specs)]); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if stats := cache.Stats(); stats.Hits+stats.Misses != 800 || stats.Len != len(specs) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestWithParseCache(t *testing.T) {
+	cache := NewParseCache(10)
+	seconds := New(WithParseCache(cache))
+	minutes := New(WithParseCache(cache), WithResolution(time.Minute))
+
+	if _, err := seconds.AddFunc("*/10 * * * * *", func() {}); err != nil {
+		t.Fatal(err)
+	}
+	// The same spec, parsed with other options, is not shared.
+	if _, err := minutes.AddFunc("*/10 * * * * *", func() {}); err == nil {
+		t.Error("expected the spec to be finer than the resolution")
+	}
+	if _, err := minutes.AddFunc("30 9 * * *", func() {}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := minutes.Parse("30 9 * * *"); err != nil {
+		t.Fatal(err)
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Len != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func BenchmarkParseCache(b *testing.B) {
+	cache := NewParseCache(len(benchmarkSpecs))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		cache.Parse(benchmarkSpecs[i%len(benchmarkSpecs)])
+	}
+}
@@ -0,0 +1,62 @@
+package cron
+
+import "time"
+
+// Clock is the source of time for a Cron.  The default is the system clock;
+// the simulate package provides a virtual one.
+//
+// Implementations must be safe for concurrent use.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer returns a Timer that sends the current time on its channel
+	// after at least the given duration.
+	NewTimer(d time.Duration) Timer
+
+	// AfterFunc returns a Timer that calls f in its own goroutine after at
+	// least the given duration.  Its channel is nil.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a single event scheduled on a Clock, see time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is sent when the timer fires.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing, returning false if it already
+	// fired or was stopped.
+	Stop() bool
+}
+
+// WithClock returns an Option that makes the Cron take time from the given
+// clock rather than the system clock.
+func WithClock(clock Clock) Option {
+	return func(c *Cron) {
+		c.clock = clock
+	}
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return systemTimer{time.AfterFunc(d, f)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time { return t.Timer.C }
+
+// now returns the current time on the Cron's clock, in its location.
+func (c *Cron) now() time.Time {
+	return c.clock.Now().In(c.Location())
+}
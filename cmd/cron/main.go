@@ -0,0 +1,187 @@
+// Command cron evaluates cron specs from the command line.
+//
+// Usage:
+//
+//	cron validate [-standard] SPEC...
+//	cron next [-standard] [-n N] [-tz ZONE] [-from TIME] SPEC
+//	cron describe [-standard] SPEC
+//	cron diff [-standard] [-n N | -horizon DURATION] [-tz ZONE] [-from TIME] SPEC_A SPEC_B
+//
+// Specs are parsed with cron.Parse, or with cron.ParseStandard if -standard
+// is given.  Times are printed in RFC 3339 format in the zone given by -tz
+// (the local zone by default), starting after -from (now by default).
+//
+// diff compares the activations of the specs up to the earlier of their
+// Nth, or, given -horizon, all of their activations within it, summarized
+// as cron.Diff summarizes them.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+const usage = `usage:
+  cron validate [-standard] SPEC...
+  cron next [-standard] [-n N] [-tz ZONE] [-from TIME] SPEC
+  cron describe [-standard] SPEC
+  cron diff [-standard] [-n N | -horizon DURATION] [-tz ZONE] [-from TIME] SPEC_A SPEC_B
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command given by args and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var (
+		standard = fs.Bool("standard", false, "parse specs as standard 5-field crontab specs")
+		n        = fs.Int("n", 10, "number of activations to print")
+		tz       = fs.String("tz", "Local", "time zone to evaluate specs in")
+		from     = fs.String("from", "", "RFC 3339 time to start from (default now)")
+		horizon  = fs.Duration("horizon", 0, "period to compare activations over, for diff")
+	)
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	parse := cron.Parse
+	if *standard {
+		parse = cron.ParseStandard
+	}
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		fmt.Fprintf(stderr, "cron: %v\n", err)
+		return 2
+	}
+	start := time.Now()
+	if *from != "" {
+		if start, err = time.Parse(time.RFC3339, *from); err != nil {
+			fmt.Fprintf(stderr, "cron: %v\n", err)
+			return 2
+		}
+	}
+	start = start.In(loc)
+
+	schedules := make([]cron.Schedule, fs.NArg())
+	for i, spec := range fs.Args() {
+		if schedules[i], err = parse(spec); err != nil {
+			fmt.Fprintf(stderr, "cron: %s: %v\n", spec, err)
+			return 1
+		}
+	}
+
+	switch args[0] {
+	case "validate":
+		if len(schedules) == 0 {
+			break
+		}
+		for _, spec := range fs.Args() {
+			fmt.Fprintf(stdout, "%s: ok\n", spec)
+		}
+		return 0
+
+	case "next":
+		if len(schedules) != 1 {
+			break
+		}
+		for _, t := range next(schedules[0], start, *n) {
+			fmt.Fprintln(stdout, t.Format(time.RFC3339))
+		}
+		return 0
+
+	case "describe":
+		if len(schedules) != 1 {
+			break
+		}
+		fmt.Fprintln(stdout, cron.Describe(schedules[0]))
+		return 0
+
+	case "diff":
+		if len(schedules) != 2 {
+			break
+		}
+		if *horizon > 0 {
+			d := cron.Diff(schedules[0], schedules[1], start, *horizon)
+			fmt.Fprintln(stdout, d)
+			if !d.Empty() {
+				return 1
+			}
+			return 0
+		}
+		if !diff(stdout, schedules[0], schedules[1], start, *n) {
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprint(stderr, usage)
+	return 2
+}
+
+// next returns up to n activations of the schedule after t.
+func next(schedule cron.Schedule, t time.Time, n int) []time.Time {
+	var times []time.Time
+	for len(times) < n {
+		t = schedule.Next(t)
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// diff prints the activations of a and b after t, merged in time order, up
+// to the earlier of their nth activations, as the other's beyond it are
+// unknown.  Activations only in a are prefixed by "-", only in b by "+", and
+// common to both by " ".  It returns true if the activations are identical.
+func diff(w io.Writer, a, b cron.Schedule, t time.Time, n int) bool {
+	ta, tb := next(a, t, n), next(b, t, n)
+	if len(ta) == n && len(tb) == n && n > 0 {
+		end := ta[n-1]
+		if tb[n-1].Before(end) {
+			end = tb[n-1]
+		}
+		ta, tb = until(ta, end), until(tb, end)
+	}
+	same := true
+	for i, j := 0, 0; i < len(ta) || j < len(tb); {
+		switch {
+		case j == len(tb) || i < len(ta) && ta[i].Before(tb[j]):
+			fmt.Fprintf(w, "-%s\n", ta[i].Format(time.RFC3339))
+			same = false
+			i++
+		case i == len(ta) || tb[j].Before(ta[i]):
+			fmt.Fprintf(w, "+%s\n", tb[j].Format(time.RFC3339))
+			same = false
+			j++
+		default:
+			fmt.Fprintf(w, " %s\n", ta[i].Format(time.RFC3339))
+			i++
+			j++
+		}
+	}
+	return same
+}
+
+// until returns the leading times that are not after end.
+func until(times []time.Time, end time.Time) []time.Time {
+	i := 0
+	for i < len(times) && !times[i].After(end) {
+		i++
+	}
+	return times[:i]
+}
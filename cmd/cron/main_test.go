@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args   []string
+		status int
+		stdout string
+	}{
+		{[]string{"validate", "0 0 * * * *", "@daily"}, 0, "0 0 * * * *: ok\n@daily: ok\n"},
+		{[]string{"validate", "0 0 * * * xyz"}, 1, ""},
+		{[]string{"validate", ""}, 1, ""},
+		{
+			[]string{"next", "-n", "2", "-tz", "America/New_York", "-from", "2016-09-14T12:00:00Z", "0 0 9 * * *"},
+			0,
+			"2016-09-14T09:00:00-04:00\n2016-09-15T09:00:00-04:00\n",
+		},
+		{[]string{"describe", "-standard", "30 9 * * MON-FRI"}, 0, "At 09:30:00, on Monday through Friday\n"},
+		{
+			[]string{"diff", "-n", "2", "-tz", "UTC", "-from", "2016-09-14T12:00:00Z", "0 0 * * * *", "0 0 */2 * * *"},
+			1,
+			"-2016-09-14T13:00:00Z\n 2016-09-14T14:00:00Z\n",
+		},
+		{
+			[]string{"diff", "-n", "2", "-tz", "UTC", "-from", "2016-09-14T12:00:00Z", "0 0 */2 * * *", "0 0 1-23/2 * * *"},
+			1,
+			"+2016-09-14T13:00:00Z\n-2016-09-14T14:00:00Z\n+2016-09-14T15:00:00Z\n",
+		},
+		{
+			[]string{"diff", "-horizon", "6h", "-tz", "UTC", "-from", "2016-09-14T12:00:00Z", "0 0 * * * *", "0 0 */2 * * *"},
+			1,
+			"-3 +0 activations, 3 unchanged, from 2016-09-14T12:00:00Z to 2016-09-14T18:00:00Z\n" +
+				"- 2016-09-14T13:00:00Z\n- 2016-09-14T15:00:00Z\n- 2016-09-14T17:00:00Z\n",
+		},
+		{[]string{"bogus", "@daily"}, 2, ""},
+	}
+
+	for _, c := range tests {
+		var stdout, stderr bytes.Buffer
+		status := run(c.args, &stdout, &stderr)
+		if status != c.status || stdout.String() != c.stdout {
+			t.Errorf("%q: (expected) %d %q != %d %q (actual)", c.args, c.status, c.stdout, status, stdout.String())
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package cron
+
+import "time"
+
+// Collisions returns the times after from, and within horizon of it, at
+// which both schedules activate, in order.  It is meant for checking that
+// heavy jobs are staggered, and for planning for those that are not.
+//
+// The activations of each schedule are found with Next, from from and then
+// from each previous activation.  Those of a SpecSchedule, possibly in a
+// LocationSchedule or a ScheduleTable, do not depend on when Next is called,
+// so they are skipped ahead to the other schedule's; other schedules are
+// stepped through every activation.
+func Collisions(a, b Schedule, from time.Time, horizon time.Duration) []time.Time {
+	var times []time.Time
+	collisions(a, b, from, horizon, func(t time.Time) bool {
+		times = append(times, t)
+		return true
+	})
+	return times
+}
+
+// Collides reports whether the schedules activate at the same time after
+// from, within horizon of it, see Collisions.  For two SpecSchedules in the
+// same location, it first checks whether the schedules share a second, a
+// minute, an hour, a month and a day of some month, without which they never
+// collide, and returns at the first collision otherwise.
+func Collides(a, b Schedule, from time.Time, horizon time.Duration) bool {
+	found := false
+	collisions(a, b, from, horizon, func(time.Time) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// collisions calls f with each time both schedules activate at, until it
+// returns false.
+func collisions(a, b Schedule, from time.Time, horizon time.Duration, f func(time.Time) bool) {
+	if disjoint(a, b) {
+		return
+	}
+	end := from.Add(horizon)
+	skipA, skipB := skips(a), skips(b)
+	ta, tb := a.Next(from), b.Next(from)
+	for !ta.IsZero() && !tb.IsZero() && !ta.After(end) && !tb.After(end) {
+		switch {
+		case ta.Equal(tb):
+			if !f(ta) {
+				return
+			}
+			ta, tb = a.Next(ta), b.Next(tb)
+		case ta.Before(tb):
+			if skipA {
+				ta = a.Next(tb.Add(-time.Nanosecond))
+			} else {
+				ta = a.Next(ta)
+			}
+		default:
+			if skipB {
+				tb = b.Next(ta.Add(-time.Nanosecond))
+			} else {
+				tb = b.Next(tb)
+			}
+		}
+	}
+}
+
+// skips reports whether the activations of the schedule do not depend on
+// when Next is called, so that Next may be called from any time.
+func skips(schedule Schedule) bool {
+	if l, ok := schedule.(LocationSchedule); ok {
+		schedule = l.Schedule
+	}
+	switch schedule.(type) {
+	case *SpecSchedule, PackedSchedule:
+		return true
+	}
+	return false
+}
+
+// disjoint reports whether the schedules are SpecSchedules, in the same
+// location, that never activate at the same time.
+func disjoint(a, b Schedule) bool {
+	sa, la, ok := specIn(a)
+	if !ok {
+		return false
+	}
+	sb, lb, ok := specIn(b)
+	if !ok || la != lb {
+		return false
+	}
+	fields := [...]struct {
+		a, b uint64
+	}{
+		{sa.Second, sb.Second},
+		{sa.Minute, sb.Minute},
+		{sa.Hour, sb.Hour},
+		{sa.Month, sb.Month},
+	}
+	for _, f := range fields {
+		if f.a&f.b&^starBit == 0 {
+			return true
+		}
+	}
+	for month := months.min; month <= months.max; month++ {
+		if 1<<month&sa.Month&sb.Month == 0 {
+			continue
+		}
+		// Every month starts on every weekday in some year.
+		last := uint(daysIn(time.Month(month), 2000))
+		for first := uint(0); first < 7; first++ {
+			if nextBit(sa.dayMask(first)&sb.dayMask(first), 0, last) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// specIn returns the SpecSchedule of the schedule, and the location of a
+// LocationSchedule it is in, or nil, if it is one.
+func specIn(schedule Schedule) (*SpecSchedule, *time.Location, bool) {
+	var loc *time.Location
+	if l, ok := schedule.(LocationSchedule); ok {
+		schedule, loc = l.Schedule, l.Location
+	}
+	switch s := schedule.(type) {
+	case *SpecSchedule:
+		return s, loc, true
+	case PackedSchedule:
+		return s.Spec(), loc, true
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,92 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCollisions(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2024, month, day, hour, min, 0, 0, time.UTC)
+	}
+	entries := []struct {
+		a, b     string
+		horizon  time.Duration
+		expected []time.Time
+	}{
+		{"0 */15 * * * *", "0 */20 * * * *", 2 * time.Hour, []time.Time{
+			at(time.January, 1, 1, 0),
+			at(time.January, 1, 2, 0),
+		}},
+		{"0 0 9 * * MON", "0 0 9 1 * *", 100 * 24 * time.Hour, []time.Time{
+			at(time.January, 1, 9, 0),
+			at(time.April, 1, 9, 0),
+		}},
+		{"0 0 * * * *", "30 0 * * * *", 24 * time.Hour, nil},
+		{"0 0 0 * * MON", "0 0 0 * * TUE", 30 * 24 * time.Hour, nil},
+		{"0 0 0 31 * *", "0 0 0 * Feb *", 365 * 24 * time.Hour, nil},
+		{"@every 40m", "0 0 * * * *", 3 * time.Hour, []time.Time{
+			at(time.January, 1, 2, 0),
+		}},
+	}
+	for _, c := range entries {
+		a, err := Parse(c.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := Parse(c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual := Collisions(a, b, from, c.horizon)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%q and %q: expected %v, got %v", c.a, c.b, c.expected, actual)
+		}
+		if collides := Collides(a, b, from, c.horizon); collides != (len(c.expected) > 0) {
+			t.Errorf("%q and %q: expected collides %v, got %v", c.a, c.b, len(c.expected) > 0, collides)
+		}
+		if reversed := Collisions(b, a, from, c.horizon); !reflect.DeepEqual(reversed, actual) {
+			t.Errorf("%q and %q: expected the same collisions in either order, got %v", c.a, c.b, reversed)
+		}
+	}
+}
+
+func TestDisjoint(t *testing.T) {
+	entries := []struct {
+		a, b     string
+		disjoint bool
+	}{
+		{"0 0 * * * *", "30 0 * * * *", true},
+		{"0 0 9 * * *", "0 0 10 * * *", true},
+		{"0 0 0 * Jan *", "0 0 0 * Feb *", true},
+		{"0 0 0 * * MON", "0 0 0 * * TUE", true},
+		{"0 0 0 30 * *", "0 0 0 * Feb *", true},
+		{"0 0 0 * * MON", "0 0 0 13 * *", false},
+		{"0 0 0 1 * *", "0 0 0 13 * FRI", false},
+		{"TZ=UTC 0 0 * * *", "TZ=UTC 0 0 * * *", false},
+		{"TZ=UTC 0 0 * * *", "TZ=UTC 0 1 * * *", true},
+		{"TZ=UTC 0 0 * * *", "TZ=Asia/Tokyo 0 1 * * *", false},
+		{"@every 1h", "0 0 * * *", false},
+	}
+	for _, c := range entries {
+		a, err := ParseRobfig(c.a)
+		if err != nil {
+			a, err = Parse(c.a)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseRobfig(c.b)
+		if err != nil {
+			b, err = Parse(c.b)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := disjoint(a, b); actual != c.disjoint {
+			t.Errorf("%q and %q: expected disjoint %v, got %v", c.a, c.b, c.disjoint, actual)
+		}
+	}
+}
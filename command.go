@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"context"
+	"strings"
+)
+
+// CommandJob is a Job that runs a command line with the shell.
+//
+// The command runs with the process's environment, extended by Env and by
+// the environment carried by the run's context (see EnvFromContext), later
+// settings taking precedence.  As in Vixie cron, the shell is taken from the
+// SHELL setting of Env or of the context environment, defaulting to /bin/sh;
+// the process's own SHELL is ignored.
+//
+// The command's standard output and standard error are recorded as the run's
+// Output, and the command is killed if the run's context is cancelled.
+// Commands cannot run in WebAssembly or TinyGo builds, where RunContext
+// returns an error.
+type CommandJob struct {
+	// Command is the command line to run, passed to the shell with "-c".
+	Command string
+
+	// Env holds additional "NAME=value" settings for the command.
+	Env []string
+
+	// Dir is the working directory of the command.  If empty, the command
+	// runs in the process's current directory.
+	Dir string
+}
+
+// Run runs the command, ignoring its result.
+func (j CommandJob) Run() {
+	j.RunContext(context.Background())
+}
+
+// RunContext runs the command, returning an error if it could not be started
+// or did not exit successfully.
+func (j CommandJob) RunContext(ctx context.Context) error {
+	env := append(append([]string{}, j.Env...), EnvFromContext(ctx)...)
+	shell := "/bin/sh"
+	for _, kv := range env {
+		if strings.HasPrefix(kv, "SHELL=") && len(kv) > len("SHELL=") {
+			shell = kv[len("SHELL="):]
+		}
+	}
+	return j.run(ctx, shell, env)
+}
@@ -0,0 +1,28 @@
+//go:build !js && !wasip1 && !tinygo
+// +build !js,!wasip1,!tinygo
+
+package cron
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// commandWaitDelay bounds how long a cancelled command's output is drained.
+const commandWaitDelay = time.Second
+
+// run runs the command with the shell, adding env to the process's
+// environment.
+func (j CommandJob) run(ctx context.Context, shell string, env []string) error {
+	cmd := exec.CommandContext(ctx, shell, "-c", j.Command)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Dir = j.Dir
+	output := OutputFromContext(ctx)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// Don't wait on the output of processes left behind by a killed shell.
+	cmd.WaitDelay = commandWaitDelay
+	return cmd.Run()
+}
@@ -0,0 +1,14 @@
+//go:build js || wasip1 || tinygo
+// +build js wasip1 tinygo
+
+package cron
+
+import (
+	"context"
+	"errors"
+)
+
+// run fails, as there are no processes to run commands in.
+func (j CommandJob) run(ctx context.Context, shell string, env []string) error {
+	return errors.New("cron: commands cannot run on this platform")
+}
@@ -0,0 +1,83 @@
+//go:build !js && !wasip1 && !tinygo
+// +build !js,!wasip1,!tinygo
+
+package cron
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runCommand runs the job through a Cron and returns its recorded run.
+func runCommand(t *testing.T, job CommandJob) Run {
+	store := NewMemoryStore()
+	cron := New(WithStore(store))
+	id := cron.Schedule(Every(time.Hour), job)
+	cron.Trigger(id)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if history, _ := cron.History(id, time.Time{}, 1); len(history) == 1 {
+			return history[0]
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("expected command to run")
+	return Run{}
+}
+
+func TestCommandJobOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	run := runCommand(t, CommandJob{
+		Command: `echo "$GREETING from $PWD"; echo oops >&2`,
+		Env:     []string{"GREETING=hello"},
+		Dir:     dir,
+	})
+	if run.Outcome != OutcomeSuccess {
+		t.Errorf("expected success, got %s: %s", run.Outcome, run.Error)
+	}
+	if !strings.HasPrefix(run.Output, "hello from ") || !strings.HasSuffix(run.Output, "\noops\n") {
+		t.Errorf("unexpected output %q", run.Output)
+	}
+}
+
+func TestCommandJobFailure(t *testing.T) {
+	run := runCommand(t, CommandJob{Command: "exit 3"})
+	if run.Outcome != OutcomeFailure || !strings.Contains(run.Error, "exit status 3") {
+		t.Errorf("expected failure, got %s: %s", run.Outcome, run.Error)
+	}
+}
+
+func TestCommandJobContextEnv(t *testing.T) {
+	out := &runState{}
+	ctx := context.WithValue(context.Background(), runStateContextKey, out)
+	ctx = ContextWithEnv(ctx, []string{"A=context"})
+	job := CommandJob{Command: "echo $A", Env: []string{"A=job"}}
+	if err := job.RunContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if out.outputString() != "context\n" {
+		t.Errorf("expected the context environment to take precedence, got %q", out.outputString())
+	}
+}
+
+func TestCommandJobCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := (CommandJob{Command: "sleep 5"}).RunContext(ctx); err == nil {
+		t.Error("expected an error")
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Error("expected the command to be killed")
+	}
+}
@@ -0,0 +1,144 @@
+package cron
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Entries may be added, removed, paused and rescheduled from any number of
+// goroutines while the Cron runs.  Run with -race.
+func TestConcurrentChangesWhileRunning(t *testing.T) {
+	cron := New()
+	var runs int32
+	job := FuncJob(func() { atomic.AddInt32(&runs, 1) })
+	cron.Start()
+	defer cron.Stop()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			var ids []EntryID
+			for i := 0; i < 200; i++ {
+				switch op := r.Intn(6); {
+				case op == 0 || len(ids) == 0:
+					id, err := cron.AddJob("* * * * * ?", job)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					ids = append(ids, id)
+				case op == 1:
+					cron.Remove(ids[0])
+					ids = ids[1:]
+				case op == 2:
+					cron.Pause(ids[r.Intn(len(ids))])
+				case op == 3:
+					cron.Resume(ids[r.Intn(len(ids))])
+				case op == 4:
+					cron.UpdateSchedule(ids[r.Intn(len(ids))], Every(time.Duration(1+r.Intn(3))*time.Second))
+				default:
+					cron.Entries()
+				}
+			}
+			for _, id := range ids {
+				cron.Remove(id)
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+
+	if n := len(cron.Entries()); n != 0 {
+		t.Errorf("expected all entries removed, got %d", n)
+	}
+}
+
+// A schedule updated while running applies from the scheduler's next wakeup.
+func TestUpdateScheduleWhileRunning(t *testing.T) {
+	ran := make(chan struct{}, 10)
+	cron := New()
+	id := cron.Schedule(Every(time.Hour), FuncJob(func() { ran <- struct{}{} }))
+	cron.Start()
+	defer cron.Stop()
+
+	cron.UpdateSchedule(id, Every(time.Second))
+	select {
+	case <-ran:
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the updated schedule to apply without restarting")
+	}
+}
+
+// Jobs may change the Cron that runs them.
+func TestChangesFromJob(t *testing.T) {
+	cron := New()
+	added := make(chan EntryID, 1)
+	var id EntryID
+	id = cron.Schedule(Every(time.Second), FuncJob(func() {
+		cron.Remove(id)
+		added <- cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+	}))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case next := <-added:
+		if cron.Entry(id) != nil || cron.Entry(next) == nil {
+			t.Error("expected the job to have replaced itself")
+		}
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the job to run")
+	}
+}
+
+// The parsers and the schedules they return may be used from any number of
+// goroutines.  Run with -race.
+func TestConcurrentParseAndNext(t *testing.T) {
+	shared := map[string]Schedule{}
+	for _, spec := range []string{"0 30 9 * * MON-FRI", "@every 90s", "0 0 0 29 Feb ?"} {
+		shared[spec], _ = Parse(spec)
+	}
+	ny, _ := time.LoadLocation("America/New_York")
+	shared["location"] = LocationSchedule{shared["0 30 9 * * MON-FRI"], ny}
+	shared["eventbridge"], _ = ParseEventBridge("0 9 ? * MON-FRI 2024-2030")
+	cache := NewParseCache(4)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			for i := 0; i < 500; i++ {
+				from = from.Add(time.Duration(r.Int63n(int64(48 * time.Hour))))
+				for name, s := range shared {
+					if next := s.Next(from); !next.IsZero() && !next.After(from) {
+						t.Errorf("%s: expected the activation after %v to follow it, got %v", name, from, next)
+						return
+					}
+					Describe(s)
+				}
+				spec := benchmarkSpecs[r.Intn(len(benchmarkSpecs))]
+				if _, err := Parse(spec); err != nil {
+					t.Error(err)
+					return
+				}
+				s, err := cache.Parse(spec)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				s.Next(from)
+				_ = s.(*SpecSchedule).String()
+				ParseRobfig("CRON_TZ=America/New_York 30 9 * * *")
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+}
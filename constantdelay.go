@@ -25,3 +25,9 @@ func Every(duration time.Duration) ConstantDelaySchedule {
 func (schedule ConstantDelaySchedule) Next(t time.Time) time.Time {
 	return t.Add(schedule.Delay - time.Duration(t.Nanosecond())*time.Nanosecond)
 }
+
+// String returns the schedule as an "@every" descriptor, which Parse parses
+// to an equal schedule.
+func (schedule ConstantDelaySchedule) String() string {
+	return "@every " + schedule.Delay.String()
+}
@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+// maxOutput is the maximum number of bytes of output recorded for a run.
+const maxOutput = 64 << 10
+
+// contextKey is the type of the keys under which the Cron stores values in
+// the context passed to a ContextJob.
+type contextKey int
+
+const (
+	runKeyContextKey contextKey = iota
+	envContextKey
+	runStateContextKey
+	runIDContextKey
+	locationContextKey
+)
+
+// runState collects what a job reports about its run while it is running.
+// now is the Cron's clock.
+type runState struct {
+	mu     sync.Mutex
+	output bytes.Buffer
+	next   time.Time
+	now    func() time.Time
+}
+
+// Write appends to the run's output, discarding anything beyond maxOutput.
+func (s *runState) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if room := maxOutput - s.output.Len(); room > 0 {
+		if len(p) > room {
+			s.output.Write(p[:room])
+		} else {
+			s.output.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+func (s *runState) outputString() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.output.String()
+}
+
+// RunKeyFromContext returns the RunKey of the activation the context was
+// created for, if any.
+func RunKeyFromContext(ctx context.Context) (RunKey, bool) {
+	key, ok := ctx.Value(runKeyContextKey).(RunKey)
+	return key, ok
+}
+
+// RunIDFromContext returns the ID of the invocation the context was created
+// for, if any, see Run.ID.  It lets application logs and traces be correlated
+// with the Cron's history.
+func RunIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(runIDContextKey).(string)
+	return id, ok
+}
+
+// ContextWithEnv returns a copy of ctx carrying the given environment, a list
+// of "NAME=value" strings, for the job to run with.
+func ContextWithEnv(ctx context.Context, env []string) context.Context {
+	return context.WithValue(ctx, envContextKey, env)
+}
+
+// EnvFromContext returns the environment carried by the context, if any.
+func EnvFromContext(ctx context.Context) []string {
+	env, _ := ctx.Value(envContextKey).([]string)
+	return env
+}
+
+// OutputFromContext returns a writer for the output of the run the context was
+// created for.  What is written is recorded as the run's Output in its
+// history, up to 64KB.  Outside of a run, the writer discards its input.
+func OutputFromContext(ctx context.Context) io.Writer {
+	if state, ok := ctx.Value(runStateContextKey).(*runState); ok {
+		return state
+	}
+	return ioutil.Discard
+}
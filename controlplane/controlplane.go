@@ -0,0 +1,122 @@
+// Package controlplane implements the ControlPlane service described by
+// controlplane.proto, for a central controller to manage the entries of a
+// cron.Cron and stream its run events.
+//
+// Server implements the service's methods on plain Go types, independently
+// of any transport, so that this module does not depend on gRPC.  To serve
+// it over gRPC, generate the stubs from controlplane.proto with protoc and
+// protoc-gen-go-grpc, and implement the generated server interface by
+// converting between the generated messages and this package's types.
+// Entries and events are those of package cronpb, whose Marshal methods
+// produce the wire format of the cron.v1 messages controlplane.proto uses:
+//
+//	func (s *grpcServer) PauseEntry(ctx context.Context, req *controlplanepb.EntryRequest) (*cronv1.Entry, error) {
+//		e, err := s.cp.PauseEntry(ctx, cron.EntryID(req.Id))
+//		if err == controlplane.ErrNotFound {
+//			return nil, status.Error(codes.NotFound, err.Error())
+//		}
+//		...
+//		m := &cronv1.Entry{}
+//		return m, proto.Unmarshal(e.Marshal(), m)
+//	}
+package controlplane
+
+import (
+	"context"
+	"errors"
+
+	"github.com/webconnex/cron"
+	"github.com/webconnex/cron/cronpb"
+)
+
+// ErrNotFound is returned for an entry ID that is not in the Cron.
+var ErrNotFound = errors.New("controlplane: entry not found")
+
+// Server implements the ControlPlane service for a Cron.
+type Server struct {
+	cron *cron.Cron
+}
+
+// NewServer returns a Server managing the given Cron.
+func NewServer(c *cron.Cron) *Server {
+	return &Server{cron: c}
+}
+
+// ListEntries lists all entries.
+func (s *Server) ListEntries(ctx context.Context) ([]*cronpb.Entry, error) {
+	entries := []*cronpb.Entry{}
+	for _, e := range s.cron.Entries() {
+		entries = append(entries, cronpb.FromEntry(e))
+	}
+	return entries, nil
+}
+
+// GetEntry shows one entry.
+func (s *Server) GetEntry(ctx context.Context, id cron.EntryID) (*cronpb.Entry, error) {
+	return s.entry(id)
+}
+
+// PauseEntry pauses an entry.
+func (s *Server) PauseEntry(ctx context.Context, id cron.EntryID) (*cronpb.Entry, error) {
+	return s.apply(id, s.cron.Pause)
+}
+
+// ResumeEntry resumes a paused entry.
+func (s *Server) ResumeEntry(ctx context.Context, id cron.EntryID) (*cronpb.Entry, error) {
+	return s.apply(id, s.cron.Resume)
+}
+
+// TriggerEntry runs an entry's job now.
+func (s *Server) TriggerEntry(ctx context.Context, id cron.EntryID) (*cronpb.Entry, error) {
+	return s.apply(id, s.cron.Trigger)
+}
+
+// RemoveEntry removes an entry.
+func (s *Server) RemoveEntry(ctx context.Context, id cron.EntryID) error {
+	if _, err := s.entry(id); err != nil {
+		return err
+	}
+	s.cron.Remove(id)
+	return nil
+}
+
+// StreamEvents sends the Cron's events to send until ctx is done, returning
+// its error, or until send fails, returning send's error.  Up to buffer
+// events are buffered for a slow stream, 100 if buffer is not positive;
+// events beyond it are dropped.
+func (s *Server) StreamEvents(ctx context.Context, buffer int, send func(*cronpb.Event) error) error {
+	if buffer <= 0 {
+		buffer = 100
+	}
+	events, cancel := s.cron.Subscribe(buffer)
+	defer cancel()
+	for {
+		select {
+		case event := <-events:
+			if err := send(cronpb.FromEvent(event)); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// entry returns the entry with the given ID.
+func (s *Server) entry(id cron.EntryID) (*cronpb.Entry, error) {
+	e := s.cron.Entry(id)
+	if e == nil {
+		return nil, ErrNotFound
+	}
+	return cronpb.FromEntry(e), nil
+}
+
+// apply applies the operation to the entry with the given ID, and returns
+// the entry as it is afterwards.
+func (s *Server) apply(id cron.EntryID, op func(cron.EntryID)) (*cronpb.Entry, error) {
+	if _, err := s.entry(id); err != nil {
+		return nil, err
+	}
+	op(id)
+	return s.entry(id)
+}
@@ -0,0 +1,93 @@
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+	"github.com/webconnex/cron/cronpb"
+)
+
+func TestServer(t *testing.T) {
+	c := cron.New()
+	id, _ := c.AddFunc("@hourly", func() {}, cron.WithName("report"))
+	s := NewServer(c)
+	ctx := context.Background()
+
+	entries, _ := s.ListEntries(ctx)
+	if len(entries) != 1 || entries[0].ID != id || entries[0].Name != "report" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if e, err := s.PauseEntry(ctx, id); err != nil || !e.Paused {
+		t.Errorf("expected the entry to be paused, got %+v, %v", e, err)
+	}
+	if e, err := s.ResumeEntry(ctx, id); err != nil || e.Paused {
+		t.Errorf("expected the entry to be resumed, got %+v, %v", e, err)
+	}
+	if err := s.RemoveEntry(ctx, id); err != nil {
+		t.Error(err)
+	}
+	if _, err := s.GetEntry(ctx, id); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := s.TriggerEntry(ctx, id); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	c := cron.New()
+	id, _ := c.AddFunc("@hourly", func() {})
+	s := NewServer(c)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan *cronpb.Event, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.StreamEvents(ctx, 0, func(e *cronpb.Event) error {
+			events <- e
+			return nil
+		})
+	}()
+	// Wait for the stream to subscribe before triggering.
+	deadline := time.After(time.Second)
+	for {
+		c.Trigger(id)
+		select {
+		case e := <-events:
+			if e.EntryID != id || e.Run == nil {
+				t.Errorf("unexpected event %+v", e)
+			}
+		case <-time.After(10 * time.Millisecond):
+			continue
+		case <-deadline:
+			t.Fatal("expected an event")
+		}
+		break
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected the stream to end with the context, got %v", err)
+	}
+
+	failed := errors.New("stream closed")
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			c.Trigger(id)
+			select {
+			case <-stop:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+	if err := s.StreamEvents(context.Background(), 0, func(*cronpb.Event) error { return failed }); err != failed {
+		t.Errorf("expected the send error, got %v", err)
+	}
+}
@@ -3,9 +3,14 @@
 package cron
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"os"
 	"runtime"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -13,13 +18,67 @@ import (
 // specified by the schedule. It may be started, stopped, and the entries may
 // be inspected while running.
 type Cron struct {
-	entries  []*Entry
-	stop     chan struct{}
-	add      chan *Entry
-	snapshot chan []*Entry
-	running  bool
-	ErrorLog *log.Logger
-	location *time.Location
+	queue     entryQueue
+	wheel     bool
+	byID      map[EntryID]*Entry
+	stop      chan struct{}
+	snapshot  chan *entryView
+	view      atomic.Value // *entryView
+	version   uint64
+	ops       chan func()
+	running   bool
+	runningMu sync.Mutex
+	jobs      sync.WaitGroup
+	ErrorLog  *log.Logger
+	location  atomic.Value // *time.Location
+	store     Store
+	clock     Clock
+	splay     time.Duration
+	limiter   *tokenBucket
+	limiters  map[string]*tokenBucket
+	events    eventBus
+	watchdog  *Watchdog
+	health    healthTracker
+	batchHook func(scheduled time.Time, batch []*Entry)
+	nextID    EntryID
+	frozen    bool
+	draining  bool
+	catchUp   time.Time
+	dryRun    bool
+	host      string
+	logger    Logger
+
+	lateness     time.Duration
+	latenessHook func(run Run, late time.Duration)
+	slaHook      func(breach SLABreach)
+	resolution   time.Duration
+	pool         *workerPool
+	inflight     inflight
+	budgets      budgetTracker
+	executor     Executor
+	namespaces   map[string]*namespace
+	namespacesMu sync.Mutex
+	shard        *shard
+	persistNext  bool
+	robfigSpecs  bool
+	millisSpecs  bool
+	monthlyAtEnd bool
+	parseCache   *ParseCache
+	fields       *FieldRegistry
+	offPeak      []OffPeak
+	blackouts    []Blackout
+	timeScale    float64
+}
+
+// Option configures a Cron.
+type Option func(*Cron)
+
+// WithStore returns an Option that records each activation in the given
+// Store before it is dispatched, and each finished run in its history.
+func WithStore(store Store) Option {
+	return func(c *Cron) {
+		c.store = store
+	}
 }
 
 // Job is an interface for submitted cron jobs.
@@ -28,14 +87,37 @@ type Job interface {
 }
 
 // The Schedule describes a job's duty cycle.
+//
+// The schedules of this package never change once created, and may be shared
+// between Crons and goroutines.  Implementations should be safe for
+// concurrent calls of Next too, as the same schedule may be added to several
+// entries.
 type Schedule interface {
 	// Return the next activation time, later than the given time.
 	// Next is invoked initially, and then each time the job is run.
 	Next(time.Time) time.Time
 }
 
+// ContextJob is implemented by jobs that want access to the context of the
+// activation they are running for, such as its RunKey.
+type ContextJob interface {
+	Job
+	RunContext(ctx context.Context) error
+}
+
+// EntryID identifies an entry within a Cron instance. IDs are assigned in
+// registration order, starting at 1.
+type EntryID int
+
 // Entry consists of a schedule and the func to execute on that schedule.
 type Entry struct {
+	// ID is the cron-assigned ID of this entry.
+	ID EntryID
+
+	// Name is the name given to the entry with WithName, if any.  Unlike
+	// its ID, an entry's name is stable across processes.
+	Name string
+
 	// The schedule on which this job should be run.
 	Schedule Schedule
 
@@ -49,43 +131,157 @@ type Entry struct {
 
 	// The Job to run.
 	Job Job
-}
 
-// byTime is a wrapper for sorting the entry array by time
-// (with zero time at the end).
-type byTime []*Entry
+	// Spec is the spec the schedule was parsed from, if the entry was added
+	// with AddFunc or AddJob.
+	Spec string
+
+	// Paused is true if the entry has been paused.  A paused entry has a zero
+	// Next time and is not run until it is resumed.
+	Paused bool
+
+	// Tags are the groups the entry belongs to, see WithTags.
+	Tags []string
+
+	// Namespace is the name of the tenant the entry belongs to, see
+	// Cron.Namespace.
+	Namespace string
+
+	// Metadata holds arbitrary key/value pairs attached to the entry, see
+	// WithMetadata.
+	Metadata map[string]string
+
+	// LogFields are added to every message logged about the entry, see
+	// WithLogField.
+	LogFields map[string]string
+
+	// ValidFrom and ValidUntil bound the times at which the entry may be
+	// activated, see the ValidFrom and ValidUntil options.  A zero time
+	// leaves that side unbounded.
+	ValidFrom, ValidUntil time.Time
+
+	// RemoveWhenExpired is true if the entry is removed from the Cron once
+	// it has no activation left before ValidUntil or within MaxRuns.
+	RemoveWhenExpired bool
+
+	// MaxRuns is the number of scheduled activations after which the entry
+	// expires, or zero if it is unlimited, see the MaxRuns option.
+	MaxRuns int
+
+	// Runs is the number of scheduled activations of the entry so far.  Runs
+	// started with Trigger are not counted.
+	Runs int
+
+	// StartAt and InitialDelay postpone the entry's first activation, see
+	// the StartAt and InitialDelay options.
+	StartAt      time.Time
+	InitialDelay time.Duration
+
+	// Jitter is the maximum random delay added to each scheduled run of the
+	// entry, see the WithJitter option.
+	Jitter time.Duration
+
+	// Flexibility is the maximum delay by which each scheduled run of the
+	// entry may be moved into an off-peak window, see the WithFlexibility
+	// option.
+	Flexibility time.Duration
+
+	// MisfireThreshold and MisfirePolicy select what happens to activations
+	// dispatched late, see the WithMisfire option.
+	MisfireThreshold time.Duration
+	MisfirePolicy    MisfirePolicy
+
+	// SLA is the service level the entry's runs are held to, see the
+	// WithSLA option.
+	SLA SLA
+
+	// Locations are the time zones the entry's schedule is evaluated in,
+	// see the WithLocations option.  If there are none, it is evaluated in
+	// the Cron's.
+	Locations []*time.Location
+
+	// Budget limits the total time the entry's runs may take per window,
+	// see the WithBudget option.
+	Budget Budget
+
+	// IgnoreBlackouts is true if the entry runs within the Cron's blackout
+	// windows, see the IgnoreBlackouts option.
+	IgnoreBlackouts bool
+
+	// Stats summarizes the entry's runs.  It is set in the snapshots
+	// returned by Entries and Entry.
+	Stats RunStats
+
+	// scheduled is set once the entry's first activation has been computed.
+	scheduled bool
+
+	// imported is the next activation time recorded in the snapshot the
+	// entry was imported from, see Import.
+	imported time.Time
+
+	// expired is set once the entry has no activation left before ValidUntil
+	// or has used up its MaxRuns.
+	expired bool
+
+	// index is the entry's position in the Cron's queue, or -1 if it is not
+	// in it.  bucket is its slot, for queues that have them.
+	index  int
+	bucket int
+}
 
-func (s byTime) Len() int      { return len(s) }
-func (s byTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-func (s byTime) Less(i, j int) bool {
-	// Two zero times should return false.
-	// Otherwise, zero is "greater" than any other time.
-	// (To sort it at the end of the list.)
-	if s[i].Next.IsZero() {
-		return false
-	}
-	if s[j].Next.IsZero() {
-		return true
+// Before reports whether e comes before other in activation order: by Next
+// time, with zero times last, then by registration order (ID), then by name.
+// Entries due at the same instant are dispatched, listed and handed to hooks
+// in this order, so that runs are reproducible.
+func (e *Entry) Before(other *Entry) bool {
+	switch {
+	case e.Next.IsZero() != other.Next.IsZero():
+		return other.Next.IsZero()
+	case !e.Next.Equal(other.Next):
+		return e.Next.Before(other.Next)
+	case e.ID != other.ID:
+		return e.ID < other.ID
 	}
-	return s[i].Next.Before(s[j].Next)
+	return e.Name < other.Name
 }
 
+// byTime is a wrapper for sorting the entry array in activation order.
+type byTime []*Entry
+
+func (s byTime) Len() int           { return len(s) }
+func (s byTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byTime) Less(i, j int) bool { return s[i].Before(s[j]) }
+
 // New returns a new Cron job runner, in the Local time zone.
-func New() *Cron {
-	return NewWithLocation(time.Now().Location())
+func New(opts ...Option) *Cron {
+	return NewWithLocation(time.Now().Location(), opts...)
 }
 
 // NewWithLocation returns a new Cron job runner.
-func NewWithLocation(location *time.Location) *Cron {
-	return &Cron{
-		entries:  nil,
-		add:      make(chan *Entry),
+func NewWithLocation(location *time.Location, opts ...Option) *Cron {
+	c := &Cron{
+		queue:    &entryHeap{},
+		byID:     make(map[EntryID]*Entry),
 		stop:     make(chan struct{}),
-		snapshot: make(chan []*Entry),
+		snapshot: make(chan *entryView),
+		ops:      make(chan func()),
 		running:  false,
 		ErrorLog: nil,
-		location: location,
+		clock:    systemClock{},
+		executor: LocalExecutor{},
+	}
+	c.location.Store(location)
+	c.host, _ = os.Hostname()
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.timeScale > 0 {
+		c.clock = newScaledClock(c.clock, c.timeScale)
+	}
+	if c.wheel {
+		c.queue = newTimingWheel(c.clock.Now())
 	}
+	return c
 }
 
 // A wrapper that turns a func() into a cron.Job
@@ -93,52 +289,257 @@ type FuncJob func()
 
 func (f FuncJob) Run() { f() }
 
+// A wrapper that turns a func(context.Context) error into a cron.ContextJob
+type ContextFuncJob func(ctx context.Context) error
+
+func (f ContextFuncJob) Run() { f(context.Background()) }
+
+func (f ContextFuncJob) RunContext(ctx context.Context) error { return f(ctx) }
+
+// EntryOption configures an entry as it is added to a Cron.
+type EntryOption func(*Entry)
+
+// WithName returns an EntryOption that names the entry.
+func WithName(name string) EntryOption {
+	return func(e *Entry) {
+		e.Name = name
+	}
+}
+
 // AddFunc adds a func to the Cron to be run on the given schedule.
-func (c *Cron) AddFunc(spec string, cmd func()) error {
-	return c.AddJob(spec, FuncJob(cmd))
+func (c *Cron) AddFunc(spec string, cmd func(), opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, FuncJob(cmd), opts...)
+}
+
+// AddContextFunc adds a context-aware func to the Cron to be run on the given
+// schedule.
+func (c *Cron) AddContextFunc(spec string, cmd func(ctx context.Context) error, opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, ContextFuncJob(cmd), opts...)
 }
 
 // AddJob adds a Job to the Cron to be run on the given schedule.
-func (c *Cron) AddJob(spec string, cmd Job) error {
-	schedule, err := Parse(spec)
+func (c *Cron) AddJob(spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
+	schedule, err := c.parse(spec)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	c.Schedule(schedule, cmd)
-	return nil
+	return c.schedule(&Entry{Schedule: schedule, Job: cmd, Spec: spec}, opts), nil
 }
 
 // Schedule adds a Job to the Cron to be run on the given schedule.
-func (c *Cron) Schedule(schedule Schedule, cmd Job) {
-	entry := &Entry{
-		Schedule: schedule,
-		Job:      cmd,
-	}
-	if !c.running {
-		c.entries = append(c.entries, entry)
-		return
+func (c *Cron) Schedule(schedule Schedule, cmd Job, opts ...EntryOption) EntryID {
+	return c.schedule(&Entry{Schedule: schedule, Job: cmd}, opts)
+}
+
+// schedule applies the options to the entry, assigns it an ID and adds it to
+// the Cron.
+func (c *Cron) schedule(entry *Entry, opts []EntryOption) EntryID {
+	for _, opt := range opts {
+		opt(entry)
 	}
+	c.do(func() {
+		c.add(entry)
+	})
+	return entry.ID
+}
 
-	c.add <- entry
+// add assigns the entry an ID and adds it to the Cron, scheduling it if the
+// Cron is running.
+func (c *Cron) add(entry *Entry) {
+	c.nextID++
+	entry.ID = c.nextID
+	entry.index = -1
+	c.insert(entry)
+	if c.running {
+		c.advance(entry, c.now())
+	}
 }
 
 // Entries returns a snapshot of the cron entries.
 func (c *Cron) Entries() []*Entry {
+	entries := c.entries()
+	c.withStats(entries...)
+	return entries
+}
+
+func (c *Cron) entries() []*Entry {
+	if v := c.entryView(); v != nil {
+		return v.copy()
+	}
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
 	if c.running {
 		c.snapshot <- nil
-		x := <-c.snapshot
-		return x
+		return (<-c.snapshot).copy()
 	}
 	return c.entrySnapshot()
 }
 
+// Entry returns a snapshot of the given entry, or nil if it couldn't be found.
+func (c *Cron) Entry(id EntryID) *Entry {
+	entry := c.entry(id)
+	c.withStats(entry)
+	return entry
+}
+
+func (c *Cron) entry(id EntryID) *Entry {
+	if v := c.entryView(); v != nil {
+		return v.entry(id)
+	}
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		c.snapshot <- nil
+		return (<-c.snapshot).entry(id)
+	}
+	if e := c.find(id); e != nil {
+		return e.snapshot()
+	}
+	return nil
+}
+
+// Remove removes the given entry from being run in the future.
+func (c *Cron) Remove(id EntryID) {
+	c.do(func() {
+		if e := c.find(id); e != nil {
+			c.removeEntry(e)
+		}
+	})
+}
+
+// Pause stops the given entry from being run until it is resumed.
+func (c *Cron) Pause(id EntryID) {
+	c.do(func() {
+		if e := c.find(id); e != nil {
+			c.pause(e)
+		}
+	})
+}
+
+// Resume resumes a paused entry.  Its next activation is computed from the
+// current time; activations that fell within the pause are not run.
+func (c *Cron) Resume(id EntryID) {
+	c.do(func() {
+		if e := c.find(id); e != nil {
+			c.resume(e, c.now())
+		}
+	})
+}
+
+// UpdateSchedule replaces the schedule of the given entry, keeping its ID and
+// job.  Its next activation is recomputed from the current time.
+func (c *Cron) UpdateSchedule(id EntryID, schedule Schedule) {
+	c.do(func() {
+		if e := c.find(id); e != nil {
+			e.Schedule = schedule
+			e.Spec = ""
+			if c.running {
+				c.advance(e, c.now())
+			}
+		}
+	})
+}
+
+// Trigger runs the given entry's job immediately, outside of its schedule.
+// The entry's Next and Prev times are not affected.
+func (c *Cron) Trigger(id EntryID) {
+	c.do(func() {
+		if e := c.find(id); e != nil && !observe(e, c.now()) {
+			c.dispatch([]activation{{entry: e, scheduled: c.now()}})
+		}
+	})
+}
+
+// do runs fn with exclusive access to the entries: on the scheduler goroutine
+// if the Cron is running, or directly if it is not.
+func (c *Cron) do(fn func()) {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if !c.running {
+		fn()
+		return
+	}
+	c.send(fn)
+}
+
+// send runs fn on the scheduler goroutine and waits for it to return.  The
+// caller must hold runningMu, and the Cron must be running.
+func (c *Cron) send(fn func()) {
+	done := make(chan struct{})
+	c.ops <- func() {
+		fn()
+		c.changed()
+		close(done)
+	}
+	<-done
+}
+
+// find returns the entry with the given ID, or nil if there is none.
+func (c *Cron) find(id EntryID) *Entry {
+	return c.byID[id]
+}
+
+// pause marks the entry as paused.
+func (c *Cron) pause(e *Entry) {
+	e.Paused = true
+	e.Next = time.Time{}
+	c.fix(e)
+}
+
+// resume resumes the entry if it is paused, scheduling it from now.
+func (c *Cron) resume(e *Entry, now time.Time) {
+	if !e.Paused {
+		return
+	}
+	e.Paused = false
+	if c.running {
+		c.advance(e, now)
+	}
+}
+
+// advance computes the next activation of the entry after the given time.
+func (c *Cron) advance(e *Entry, now time.Time) {
+	defer c.fix(e)
+	if e.MaxRuns > 0 && e.Runs >= e.MaxRuns {
+		e.expired = true
+	}
+	if e.Paused || e.expired {
+		e.Next = time.Time{}
+		return
+	}
+	var restored time.Time
+	if !e.scheduled {
+		e.scheduled = true
+		restored = c.restoreNext(e, now)
+		if start := e.start(now); start.After(now) {
+			now = start.Add(-time.Nanosecond)
+		}
+	}
+	if !e.ValidFrom.IsZero() && now.Before(e.ValidFrom) {
+		// Allow an activation at exactly ValidFrom.
+		now = e.ValidFrom.Add(-time.Nanosecond)
+	}
+	if restored.IsZero() {
+		e.Next = c.next(e, now)
+	} else {
+		e.Next = restored
+	}
+	if !e.ValidUntil.IsZero() && e.Next.After(e.ValidUntil) {
+		e.expired = true
+		e.Next = time.Time{}
+	}
+	c.saveNext(e)
+}
+
 // Location gets the time zone location
 func (c *Cron) Location() *time.Location {
-	return c.location
+	return c.location.Load().(*time.Location)
 }
 
 // Start the cron scheduler in its own go-routine, or no-op if already started.
 func (c *Cron) Start() {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
 	if c.running {
 		return
 	}
@@ -146,100 +547,272 @@ func (c *Cron) Start() {
 	go c.run()
 }
 
-func (c *Cron) runWithRecovery(j Job) {
+// activate dispatches the scheduled runs of the due entries this replica
+// owns as one batch, applying their off-peak delays, jitter and the rate
+// limits.
+func (c *Cron) activate(due []*Entry, now time.Time) {
+	batch := make([]activation, 0, len(due))
+	for _, e := range due {
+		if observe(e, e.Next) {
+			continue
+		}
+		if !c.Owns(e) {
+			continue
+		}
+		if e.misfired(e.Next, now) && e.MisfirePolicy == MisfireSkip {
+			c.skip(e, e.Next, "misfired")
+			continue
+		}
+		if c.blackedOut(e, e.Next) {
+			c.skip(e, e.Next, "blackout window")
+			continue
+		}
+		if c.exhausted(e, now) {
+			c.skip(e, e.Next, "runtime budget exhausted")
+			continue
+		}
+		wait, ok := c.limit(e, now)
+		if !ok {
+			c.skip(e, e.Next, "rate limited")
+			continue
+		}
+		for _, loc := range c.locationsAt(e) {
+			batch = append(batch, activation{entry: e, scheduled: e.Next, delay: c.offPeakDelay(e, e.Next) + e.jitter() + wait, location: loc})
+		}
+	}
+	c.dispatch(batch)
+}
+
+// skip logs and records that the run of the entry at the given time was
+// skipped.
+func (c *Cron) skip(e *Entry, scheduled time.Time, reason string) {
+	run := Run{
+		Key:       NewRunKey(e.ID, scheduled),
+		ID:        newRunID(),
+		EntryID:   e.ID,
+		Scheduled: scheduled,
+		Outcome:   OutcomeSkipped,
+		Error:     reason,
+		Host:      c.host,
+	}
+	rlog := c.runLog(e, run)
+	rlog.logf("cron: skipping run %s: %s", rlog.name, reason)
+	c.record(run)
+	c.health.activated(e.ID, false)
+	c.emit(RunSkipped, e.Namespace, e.ID, &run)
+}
+
+// dispatch starts the jobs of the given activations, after their delays,
+// except those the configured Store reports as already executed.
+func (c *Cron) dispatch(batch []activation) {
+	for _, a := range batch {
+		c.health.activated(a.entry.ID, true)
+	}
+	if c.dryRun {
+		c.dryDispatch(batch)
+		return
+	}
+	batch = c.claim(batch)
+	c.batchStarted(batch)
+	for _, a := range batch {
+		limit := c.limited(a.entry)
+		if limit != nil && !limit.acquire() {
+			c.skip(a.entry, a.scheduled, "namespace concurrency limit")
+			continue
+		}
+		run := Run{
+			Key:       a.key,
+			ID:        newRunID(),
+			EntryID:   a.entry.ID,
+			Scheduled: a.scheduled,
+			Host:      c.host,
+		}
+		ctx := context.WithValue(context.Background(), runKeyContextKey, a.key)
+		ctx = context.WithValue(ctx, runIDContextKey, run.ID)
+		if a.location != nil {
+			ctx = context.WithValue(ctx, locationContextKey, a.location)
+		}
+		entry, rlog, due := a.entry.snapshot(), c.runLog(a.entry, run), a.scheduled.Add(a.delay)
+		t := &task{run: run, rlog: rlog, namespace: entry.Namespace, limit: limit}
+		t.fn = func() {
+			defer limit.release()
+			c.runWithRecovery(ctx, entry, run, rlog, due)
+		}
+		c.jobs.Add(1)
+		c.inflight.update(1, 0)
+		if a.delay > 0 {
+			c.clock.AfterFunc(a.delay, func() { c.start(t) })
+			continue
+		}
+		c.start(t)
+	}
+}
+
+// runWithRecovery runs the entry's job with the Executor, recovering from any
+// panic, and records the outcome of the run.  Messages about the run go to
+// rlog, and the run is late if it starts well after due.
+func (c *Cron) runWithRecovery(ctx context.Context, entry *Entry, run Run, rlog runLog, due time.Time) {
+	defer c.jobs.Done()
+	c.execute(ctx, entry, run, rlog, due)
+}
+
+// execute is runWithRecovery, returning the finished run.
+func (c *Cron) execute(ctx context.Context, entry *Entry, run Run, rlog runLog, due time.Time) (result Run) {
+	state := &runState{now: c.now}
+	ctx = context.WithValue(ctx, runStateContextKey, state)
+	run.Start = c.now()
+	c.inflight.update(-1, 1)
+	defer c.inflight.update(0, -1)
+	started := run
+	c.emit(RunStarted, entry.Namespace, run.EntryID, &started)
+	c.checkLateness(run, due)
+	defer c.watchSLA(entry, run, rlog)()
+	ctx, finished := c.watch(ctx, &run, rlog)
+	defer finished()
 	defer func() {
 		if r := recover(); r != nil {
 			const size = 64 << 10
 			buf := make([]byte, size)
 			buf = buf[:runtime.Stack(buf, false)]
-			c.logf("cron: panic running job: %v\n%s", r, buf)
+			rlog.logf("cron: panic running %s: %v\n%s", rlog.name, r, buf)
+			run.Outcome = OutcomePanic
+			run.Error = fmt.Sprint(r)
+		}
+		run.End = c.now()
+		run.Output = state.outputString()
+		c.record(run)
+		c.health.finished(run)
+		c.emit(RunFinished, entry.Namespace, run.EntryID, &run)
+		if c.spend(entry, run) {
+			rlog.logf("cron: %s exhausted its runtime budget of %s", rlog.name, entry.Budget.Runtime)
+			c.emit(BudgetExhausted, entry.Namespace, run.EntryID, &run)
 		}
+		if next := state.nextHint(); !next.IsZero() {
+			c.reschedule(run.EntryID, next)
+		}
+		result = run
 	}()
-	j.Run()
+	if err := c.executor.Execute(ctx, entry, run); err != nil {
+		rlog.logf("cron: run %s failed: %v", rlog.name, err)
+		run.Outcome = OutcomeFailure
+		run.Error = err.Error()
+	}
+	return run
 }
 
 // Run the scheduler.. this is private just due to the need to synchronize
 // access to the 'running' state variable.
 func (c *Cron) run() {
 	// Figure out the next activation times for each entry.
-	now := time.Now().In(c.location)
-	for _, entry := range c.entries {
-		entry.Next = entry.Schedule.Next(now)
+	now := c.now()
+	for _, entry := range c.queue.list() {
+		c.advance(entry, now)
 	}
 
 	for {
 		// Determine the next entry to run.
-		sort.Sort(byTime(c.entries))
+		effective := c.queue.wakeup()
+		if effective.IsZero() || c.frozen || c.draining {
+			// If there are no entries yet, or the scheduler is frozen or
+			// draining, just sleep - it still handles new entries and stop
+			// requests.
+			effective = now.AddDate(10, 0, 0)
+		}
 
-		var effective time.Time
-		if len(c.entries) == 0 || c.entries[0].Next.IsZero() {
-			// If there are no entries yet, just sleep - it still handles new entries
-			// and stop requests.
+		// While runs are held for room in the backlog, no entry is
+		// activated until there is.
+		var room <-chan struct{}
+		if c.pool.blocked() {
 			effective = now.AddDate(10, 0, 0)
-		} else {
-			effective = c.entries[0].Next
+			room = c.pool.room
 		}
 
-		timer := time.NewTimer(effective.Sub(now))
+		timer := c.clock.NewTimer(effective.Sub(now))
 		select {
-		case now = <-timer.C:
-			// Run every entry whose next time was this effective time.
-			for _, e := range c.entries {
-				if e.Next != effective {
-					break
-				}
-				go c.runWithRecovery(e.Job)
+		case now = <-timer.C():
+			// Run every entry whose next time has come, as one batch.
+			due := c.queue.due(now)
+			c.activate(due, now)
+			for _, e := range due {
 				e.Prev = e.Next
-				e.Next = e.Schedule.Next(now)
+				e.Runs++
+				from := c.advanceFrom(e.Prev, now)
+				if e.misfired(e.Prev, now) && from.Equal(now) {
+					from = c.inPhase(e, e.Prev, now)
+				}
+				c.advance(e, from)
+				if e.expired && e.RemoveWhenExpired {
+					c.removeEntry(e)
+				} else {
+					c.queue.push(e)
+				}
 			}
+			c.changed()
 			continue
 
-		case newEntry := <-c.add:
-			c.entries = append(c.entries, newEntry)
-			newEntry.Next = newEntry.Schedule.Next(time.Now().In(c.location))
-
 		case <-c.snapshot:
-			c.snapshot <- c.entrySnapshot()
+			c.snapshot <- c.publish()
+
+		case op := <-c.ops:
+			op()
+
+		case <-room:
 
 		case <-c.stop:
 			timer.Stop()
+			c.stop <- struct{}{}
 			return
 		}
 
-		// 'now' should be updated after newEntry and snapshot cases.
-		now = time.Now().In(c.location)
+		// 'now' should be updated after op and snapshot cases.
+		now = c.now()
 		timer.Stop()
 	}
 }
 
 // Logs an error to stderr or to the configured error log
 func (c *Cron) logf(format string, args ...interface{}) {
-	if c.ErrorLog != nil {
-		c.ErrorLog.Printf(format, args...)
-	} else {
-		log.Printf(format, args...)
-	}
+	c.log(fmt.Sprintf(format, args...), nil)
 }
 
 // Stop stops the cron scheduler if it is running; otherwise it does nothing.
-func (c *Cron) Stop() {
-	if !c.running {
-		return
+// Jobs already running are not stopped.  The returned context is done once
+// the scheduler has stopped and all running jobs, including those waiting
+// out a delay, have finished.
+func (c *Cron) Stop() context.Context {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		c.stop <- struct{}{}
+		<-c.stop
+		c.running = false
+		c.view.Store((*entryView)(nil))
+		c.emit(SchedulerStopped, "", 0, nil)
 	}
-	c.stop <- struct{}{}
-	c.running = false
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		c.jobs.Wait()
+		cancel()
+	}()
+	return ctx
 }
 
 // entrySnapshot returns a copy of the current cron entry list.
 func (c *Cron) entrySnapshot() []*Entry {
 	entries := []*Entry{}
-	for _, e := range c.entries {
-		entries = append(entries, &Entry{
-			Schedule: e.Schedule,
-			Next:     e.Next,
-			Prev:     e.Prev,
-			Job:      e.Job,
-		})
+	for _, e := range c.queue.list() {
+		entries = append(entries, e.snapshot())
 	}
+	sort.Sort(byTime(entries))
 	return entries
 }
+
+// snapshot returns a copy of the entry.
+func (e *Entry) snapshot() *Entry {
+	entry := *e
+	entry.Tags = append([]string(nil), e.Tags...)
+	entry.Metadata = copyMetadata(e.Metadata)
+	entry.LogFields = copyMetadata(e.LogFields)
+	entry.Locations = append([]*time.Location(nil), e.Locations...)
+	return &entry
+}
@@ -3,6 +3,7 @@ package cron
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -115,11 +116,11 @@ func TestAddWhileRunningWithDelay(t *testing.T) {
 	cron.Start()
 	defer cron.Stop()
 	time.Sleep(5 * time.Second)
-	var calls = 0
-	cron.AddFunc("* * * * * *", func() { calls += 1 })
+	var calls int32
+	cron.AddFunc("* * * * * *", func() { atomic.AddInt32(&calls, 1) })
 
 	<-time.After(ONE_SECOND)
-	if calls != 1 {
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
 		fmt.Printf("called %d times, expected 1\n", calls)
 		t.Fail()
 	}
@@ -268,7 +269,44 @@ func TestNonLocalTimezone(t *testing.T) {
 // blocking the stop channel.
 func TestStopWithoutStart(t *testing.T) {
 	cron := New()
-	cron.Stop()
+	select {
+	case <-cron.Stop().Done():
+	case <-time.After(time.Second):
+		t.Error("expected the context to be done")
+	}
+}
+
+// The context returned by Stop is done once running jobs have finished.
+func TestStopWaitsForJobs(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cron := New()
+	cron.AddFunc("* * * * * ?", func() {
+		select {
+		case started <- struct{}{}:
+			<-release
+		default:
+		}
+	})
+	cron.Start()
+
+	select {
+	case <-started:
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the job to start")
+	}
+	ctx := cron.Stop()
+	select {
+	case <-ctx.Done():
+		t.Fatal("expected the context not to be done while the job runs")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the context to be done after the job finished")
+	}
 }
 
 type testJob struct {
@@ -318,6 +356,73 @@ func TestJob(t *testing.T) {
 	}
 }
 
+// Remove an entry while running, expect it doesn't run.
+func TestRemoveWhileRunning(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	cron := New()
+	cron.Start()
+	defer cron.Stop()
+	id, _ := cron.AddFunc("* * * * * ?", func() { wg.Done() })
+	cron.Remove(id)
+
+	select {
+	case <-time.After(ONE_SECOND):
+	case <-wait(wg):
+		t.FailNow()
+	}
+	if cron.Entry(id) != nil {
+		t.Error("expected entry to be removed")
+	}
+}
+
+// Pause an entry, expect it doesn't run until resumed.
+func TestPauseAndResume(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	cron := New()
+	id, _ := cron.AddFunc("* * * * * ?", func() { wg.Done() })
+	cron.Pause(id)
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(ONE_SECOND):
+	case <-wait(wg):
+		t.FailNow()
+	}
+
+	cron.Resume(id)
+	select {
+	case <-time.After(ONE_SECOND):
+		t.FailNow()
+	case <-wait(wg):
+	}
+}
+
+// Trigger an entry, expect it runs immediately.
+func TestTrigger(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	cron := New()
+	id, _ := cron.AddFunc("0 0 0 1 1 ?", func() { wg.Done() })
+	cron.Start()
+	defer cron.Stop()
+	cron.Trigger(id)
+
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.FailNow()
+	case <-wait(wg):
+	}
+	if !cron.Entry(id).Prev.IsZero() {
+		t.Error("expected Prev to be unaffected by Trigger")
+	}
+}
+
 func wait(wg *sync.WaitGroup) chan bool {
 	ch := make(chan bool)
 	go func() {
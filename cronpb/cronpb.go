@@ -0,0 +1,469 @@
+// Package cronpb provides Go types for the messages of cron.proto, with
+// converters to and from this module's types, so that services can exchange
+// schedules, entries and run events over RPC without inventing their own
+// encoding.
+//
+// The types are written by hand rather than generated with protoc-gen-go, so
+// that this module does not depend on the protobuf runtime.  Their Marshal
+// methods produce, and their Unmarshal methods accept, the protobuf wire
+// format of the messages, so a service using this package can talk to one
+// using code generated from cron.proto.
+package cronpb
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// Schedule is the Schedule message.  At most one of Spec, Fields and Every
+// is set.
+type Schedule struct {
+	Spec     string
+	Fields   *SpecFields
+	Every    time.Duration
+	TimeZone string
+}
+
+// SpecFields is the SpecFields message.
+type SpecFields struct {
+	Second, Minute, Hour, Dom, Month, Dow uint64
+}
+
+// Entry is the Entry message.
+type Entry struct {
+	ID        cron.EntryID
+	Name      string
+	Spec      string
+	Schedule  *Schedule
+	Next      time.Time
+	Prev      time.Time
+	Paused    bool
+	Tags      []string
+	Metadata  map[string]string
+	Namespace string
+}
+
+// Run is the Run message.  Its Outcome enum numbers cron.Outcome's values.
+type Run struct {
+	Key       cron.RunKey
+	ID        string
+	EntryID   cron.EntryID
+	Scheduled time.Time
+	Start     time.Time
+	End       time.Time
+	Outcome   cron.Outcome
+	Error     string
+	Output    string
+	Host      string
+}
+
+// Event is the Event message.  Its EventType enum numbers cron.EventType's
+// values.
+type Event struct {
+	Type      cron.EventType
+	Time      time.Time
+	EntryID   cron.EntryID
+	Namespace string
+	Run       *Run
+	Breach    *SLABreach
+}
+
+// SLABreach is the SLABreach message.  Its SLAKind enum numbers
+// cron.SLAKind's values.
+type SLABreach struct {
+	Kind  cron.SLAKind
+	Run   *Run
+	Limit time.Duration
+}
+
+// FromSchedule returns the message for a schedule.  Specs are sent as their
+// fields, so the receiver needs no parser.  It returns an error for a
+// schedule other than a SpecSchedule or a ConstantDelaySchedule, possibly in
+// a LocationSchedule.
+func FromSchedule(schedule cron.Schedule) (*Schedule, error) {
+	m := &Schedule{}
+	if l, ok := schedule.(cron.LocationSchedule); ok {
+		schedule = l.Schedule
+		if l.Location != nil {
+			m.TimeZone = l.Location.String()
+		}
+	}
+	switch s := schedule.(type) {
+	case *cron.SpecSchedule:
+		m.Fields = &SpecFields{s.Second, s.Minute, s.Hour, s.Dom, s.Month, s.Dow}
+	case cron.ConstantDelaySchedule:
+		m.Every = s.Delay
+	default:
+		return nil, fmt.Errorf("cronpb: cannot represent %s", cron.Describe(schedule))
+	}
+	return m, nil
+}
+
+// ToSchedule returns the schedule of a message, parsing its Spec with
+// cron.Parse.
+func ToSchedule(m *Schedule) (cron.Schedule, error) {
+	var schedule cron.Schedule
+	switch {
+	case m.Spec != "":
+		var err error
+		if schedule, err = cron.Parse(m.Spec); err != nil {
+			return nil, err
+		}
+	case m.Fields != nil:
+		f := m.Fields
+		schedule = &cron.SpecSchedule{Second: f.Second, Minute: f.Minute, Hour: f.Hour, Dom: f.Dom, Month: f.Month, Dow: f.Dow}
+	case m.Every > 0:
+		schedule = cron.Every(m.Every)
+	default:
+		return nil, fmt.Errorf("cronpb: empty schedule")
+	}
+	if m.TimeZone == "" {
+		return schedule, nil
+	}
+	location, err := time.LoadLocation(m.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("cronpb: unknown time zone %q: %v", m.TimeZone, err)
+	}
+	return cron.LocationSchedule{Schedule: schedule, Location: location}, nil
+}
+
+// FromEntry returns the message for an entry.  Its Schedule is nil if
+// FromSchedule cannot represent the entry's schedule.
+func FromEntry(e *cron.Entry) *Entry {
+	schedule, _ := FromSchedule(e.Schedule)
+	return &Entry{
+		ID:        e.ID,
+		Name:      e.Name,
+		Spec:      e.Spec,
+		Schedule:  schedule,
+		Next:      e.Next,
+		Prev:      e.Prev,
+		Paused:    e.Paused,
+		Tags:      e.Tags,
+		Metadata:  e.Metadata,
+		Namespace: e.Namespace,
+	}
+}
+
+// ToEntry returns the entry of a message, without a Job.  Its schedule is
+// taken from the message's Schedule, or parsed from its Spec if it has
+// none.
+func ToEntry(m *Entry) (*cron.Entry, error) {
+	schedule := m.Schedule
+	if schedule == nil {
+		schedule = &Schedule{Spec: m.Spec}
+	}
+	s, err := ToSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return &cron.Entry{
+		ID:        m.ID,
+		Name:      m.Name,
+		Spec:      m.Spec,
+		Schedule:  s,
+		Next:      m.Next,
+		Prev:      m.Prev,
+		Paused:    m.Paused,
+		Tags:      m.Tags,
+		Metadata:  m.Metadata,
+		Namespace: m.Namespace,
+	}, nil
+}
+
+// FromRun returns the message for a run.
+func FromRun(r cron.Run) *Run {
+	m := Run(r)
+	return &m
+}
+
+// ToRun returns the run of a message.
+func ToRun(m *Run) cron.Run {
+	return cron.Run(*m)
+}
+
+// FromEvent returns the message for an event.
+func FromEvent(e cron.Event) *Event {
+	m := &Event{Type: e.Type, Time: e.Time, EntryID: e.EntryID, Namespace: e.Namespace}
+	if e.Run != nil {
+		m.Run = FromRun(*e.Run)
+	}
+	if b := e.Breach; b != nil {
+		m.Breach = &SLABreach{Kind: b.Kind, Run: FromRun(b.Run), Limit: b.Limit}
+	}
+	return m
+}
+
+// ToEvent returns the event of a message.
+func ToEvent(m *Event) cron.Event {
+	e := cron.Event{Type: m.Type, Time: m.Time, EntryID: m.EntryID, Namespace: m.Namespace}
+	if m.Run != nil {
+		run := ToRun(m.Run)
+		e.Run = &run
+	}
+	if b := m.Breach; b != nil {
+		e.Breach = &cron.SLABreach{Kind: b.Kind, Limit: b.Limit}
+		if b.Run != nil {
+			e.Breach.Run = ToRun(b.Run)
+		}
+	}
+	return e
+}
+
+// Marshal returns the wire encoding of the message.
+func (m *Schedule) Marshal() []byte {
+	var e encoder
+	m.encode(&e)
+	return e.buf
+}
+
+func (m *Schedule) encode(e *encoder) {
+	switch {
+	case m.Spec != "":
+		e.string(1, m.Spec)
+	case m.Fields != nil:
+		f := m.Fields
+		e.message(2, func(e *encoder) {
+			for i, v := range []uint64{f.Second, f.Minute, f.Hour, f.Dom, f.Month, f.Dow} {
+				e.varint(i+1, v)
+			}
+		})
+	case m.Every != 0:
+		e.duration(3, m.Every)
+	}
+	e.string(4, m.TimeZone)
+}
+
+// Unmarshal decodes the message from its wire encoding.
+func (m *Schedule) Unmarshal(b []byte) error {
+	*m = Schedule{}
+	d := decoder{buf: b}
+	return d.each(m.decode)
+}
+
+func (m *Schedule) decode(d *decoder, field int) (err error) {
+	switch field {
+	case 1:
+		m.Spec, err = d.string()
+	case 2:
+		f := &SpecFields{}
+		fields := []*uint64{&f.Second, &f.Minute, &f.Hour, &f.Dom, &f.Month, &f.Dow}
+		err = d.message(func(d *decoder, field int) (err error) {
+			if field >= 1 && field <= len(fields) {
+				*fields[field-1], err = d.varint()
+			}
+			return err
+		})
+		m.Fields = f
+	case 3:
+		m.Every, err = d.duration()
+	case 4:
+		m.TimeZone, err = d.string()
+	}
+	return err
+}
+
+// Marshal returns the wire encoding of the message.
+func (m *Entry) Marshal() []byte {
+	var e encoder
+	e.varint(1, uint64(m.ID))
+	e.string(2, m.Name)
+	e.string(3, m.Spec)
+	if m.Schedule != nil {
+		e.message(4, m.Schedule.encode)
+	}
+	e.timestamp(5, m.Next)
+	e.timestamp(6, m.Prev)
+	e.bool(7, m.Paused)
+	for _, tag := range m.Tags {
+		e.bytes(8, []byte(tag))
+	}
+	keys := make([]string, 0, len(m.Metadata))
+	for k := range m.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		e.message(9, func(e *encoder) {
+			e.string(1, k)
+			e.string(2, m.Metadata[k])
+		})
+	}
+	e.string(10, m.Namespace)
+	return e.buf
+}
+
+// Unmarshal decodes the message from its wire encoding.
+func (m *Entry) Unmarshal(b []byte) error {
+	*m = Entry{}
+	d := decoder{buf: b}
+	return d.each(func(d *decoder, field int) (err error) {
+		var v uint64
+		switch field {
+		case 1:
+			v, err = d.varint()
+			m.ID = cron.EntryID(v)
+		case 2:
+			m.Name, err = d.string()
+		case 3:
+			m.Spec, err = d.string()
+		case 4:
+			m.Schedule = &Schedule{}
+			err = d.message(m.Schedule.decode)
+		case 5:
+			m.Next, err = d.timestamp()
+		case 6:
+			m.Prev, err = d.timestamp()
+		case 7:
+			v, err = d.varint()
+			m.Paused = v != 0
+		case 8:
+			var tag string
+			tag, err = d.string()
+			m.Tags = append(m.Tags, tag)
+		case 9:
+			var k, v string
+			err = d.message(func(d *decoder, field int) (err error) {
+				switch field {
+				case 1:
+					k, err = d.string()
+				case 2:
+					v, err = d.string()
+				}
+				return err
+			})
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			m.Metadata[k] = v
+		case 10:
+			m.Namespace, err = d.string()
+		}
+		return err
+	})
+}
+
+// Marshal returns the wire encoding of the message.
+func (m *Run) Marshal() []byte {
+	var e encoder
+	m.encode(&e)
+	return e.buf
+}
+
+func (m *Run) encode(e *encoder) {
+	e.string(1, string(m.Key))
+	e.string(2, m.ID)
+	e.varint(3, uint64(m.EntryID))
+	e.timestamp(4, m.Scheduled)
+	e.timestamp(5, m.Start)
+	e.timestamp(6, m.End)
+	e.varint(7, uint64(m.Outcome))
+	e.string(8, m.Error)
+	e.string(9, m.Output)
+	e.string(10, m.Host)
+}
+
+// Unmarshal decodes the message from its wire encoding.
+func (m *Run) Unmarshal(b []byte) error {
+	*m = Run{}
+	d := decoder{buf: b}
+	return d.each(m.decode)
+}
+
+func (m *Run) decode(d *decoder, field int) (err error) {
+	var v uint64
+	switch field {
+	case 1:
+		var key string
+		key, err = d.string()
+		m.Key = cron.RunKey(key)
+	case 2:
+		m.ID, err = d.string()
+	case 3:
+		v, err = d.varint()
+		m.EntryID = cron.EntryID(v)
+	case 4:
+		m.Scheduled, err = d.timestamp()
+	case 5:
+		m.Start, err = d.timestamp()
+	case 6:
+		m.End, err = d.timestamp()
+	case 7:
+		v, err = d.varint()
+		m.Outcome = cron.Outcome(v)
+	case 8:
+		m.Error, err = d.string()
+	case 9:
+		m.Output, err = d.string()
+	case 10:
+		m.Host, err = d.string()
+	}
+	return err
+}
+
+// Marshal returns the wire encoding of the message.
+func (m *Event) Marshal() []byte {
+	var e encoder
+	e.varint(1, uint64(m.Type))
+	e.timestamp(2, m.Time)
+	e.varint(3, uint64(m.EntryID))
+	e.string(4, m.Namespace)
+	if m.Run != nil {
+		e.message(5, m.Run.encode)
+	}
+	if b := m.Breach; b != nil {
+		e.message(6, func(e *encoder) {
+			e.varint(1, uint64(b.Kind))
+			if b.Run != nil {
+				e.message(2, b.Run.encode)
+			}
+			e.duration(3, b.Limit)
+		})
+	}
+	return e.buf
+}
+
+// Unmarshal decodes the message from its wire encoding.
+func (m *Event) Unmarshal(b []byte) error {
+	*m = Event{}
+	d := decoder{buf: b}
+	return d.each(func(d *decoder, field int) (err error) {
+		var v uint64
+		switch field {
+		case 1:
+			v, err = d.varint()
+			m.Type = cron.EventType(v)
+		case 2:
+			m.Time, err = d.timestamp()
+		case 3:
+			v, err = d.varint()
+			m.EntryID = cron.EntryID(v)
+		case 4:
+			m.Namespace, err = d.string()
+		case 5:
+			m.Run = &Run{}
+			err = d.message(m.Run.decode)
+		case 6:
+			b := &SLABreach{}
+			err = d.message(func(d *decoder, field int) (err error) {
+				switch field {
+				case 1:
+					var v uint64
+					v, err = d.varint()
+					b.Kind = cron.SLAKind(v)
+				case 2:
+					b.Run = &Run{}
+					err = d.message(b.Run.decode)
+				case 3:
+					b.Limit, err = d.duration()
+				}
+				return err
+			})
+			m.Breach = b
+		}
+		return err
+	})
+}
@@ -0,0 +1,179 @@
+package cronpb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+func TestScheduleConversion(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	spec, _ := cron.Parse("0 30 9 * * MON-FRI")
+	from := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	for _, schedule := range []cron.Schedule{
+		spec,
+		cron.Every(90 * time.Second),
+		cron.LocationSchedule{Schedule: spec, Location: loc},
+	} {
+		m, err := FromSchedule(schedule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Schedule
+		if err := decoded.Unmarshal(m.Marshal()); err != nil {
+			t.Fatal(err)
+		}
+		actual, err := ToSchedule(&decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(actual, schedule) {
+			t.Errorf("(expected) %#v != %#v (actual)", schedule, actual)
+		}
+		if next := actual.Next(from); !next.Equal(schedule.Next(from)) {
+			t.Errorf("%s: expected %v, got %v", cron.Describe(schedule), schedule.Next(from), next)
+		}
+	}
+
+	if _, err := FromSchedule(&cron.EventBridgeSchedule{}); err == nil {
+		t.Error("expected an error for an EventBridge schedule")
+	}
+	if _, err := ToSchedule(&Schedule{}); err == nil {
+		t.Error("expected an error for an empty schedule")
+	}
+	if s, err := ToSchedule(&Schedule{Spec: "@hourly", TimeZone: "UTC"}); err != nil || s.(cron.LocationSchedule).Location != time.UTC {
+		t.Errorf("expected an hourly schedule in UTC, got %v, %v", s, err)
+	}
+}
+
+func TestEntryRoundTrip(t *testing.T) {
+	schedule, _ := cron.Parse("@daily")
+	entry := &cron.Entry{
+		ID:        7,
+		Name:      "report",
+		Spec:      "@daily",
+		Schedule:  schedule,
+		Next:      time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+		Paused:    true,
+		Tags:      []string{"a", "b"},
+		Metadata:  map[string]string{"team": "ops", "tier": "1"},
+		Namespace: "tenant",
+	}
+	var m Entry
+	if err := m.Unmarshal(FromEntry(entry).Marshal()); err != nil {
+		t.Fatal(err)
+	}
+	actual, err := ToEntry(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, entry) {
+		t.Errorf("(expected) %+v != %+v (actual)", entry, actual)
+	}
+}
+
+func TestEventRoundTrip(t *testing.T) {
+	at := time.Date(2024, time.March, 5, 0, 0, 0, 500, time.UTC)
+	event := cron.Event{
+		Type:      cron.RunFinished,
+		Time:      at,
+		EntryID:   3,
+		Namespace: "tenant",
+		Run: &cron.Run{
+			Key:       "3/1709596800",
+			ID:        "run-1",
+			EntryID:   3,
+			Scheduled: at,
+			Start:     at,
+			End:       at.Add(time.Second),
+			Outcome:   cron.OutcomeFailure,
+			Error:     "exit status 1",
+			Output:    "oops\n",
+			Host:      "worker-1",
+		},
+	}
+	breach := cron.Event{
+		Type:    cron.SLABreached,
+		Time:    at,
+		EntryID: 3,
+		Breach: &cron.SLABreach{
+			Kind:  cron.SLAFinish,
+			Run:   cron.Run{Key: "3/1709596800", ID: "run-1", EntryID: 3, Scheduled: at, Start: at},
+			Limit: 90 * time.Second,
+		},
+	}
+	for _, event := range []cron.Event{event, breach} {
+		var m Event
+		if err := m.Unmarshal(FromEvent(event).Marshal()); err != nil {
+			t.Fatal(err)
+		}
+		if actual := ToEvent(&m); !reflect.DeepEqual(actual, event) {
+			t.Errorf("(expected) %+v != %+v (actual)", event, actual)
+		}
+	}
+}
+
+// TestEventTypesDeclared checks that cron.proto declares every
+// cron.EventType, under its number.
+func TestEventTypesDeclared(t *testing.T) {
+	proto, err := ioutil.ReadFile("cron.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for typ := cron.EventType(0); typ.String() != "unknown"; typ++ {
+		name := "EVENT_TYPE_" + strings.ToUpper(strings.Replace(typ.String(), " ", "_", -1))
+		if value := fmt.Sprintf("  %s = %d;\n", name, typ); !bytes.Contains(proto, []byte(value)) {
+			t.Errorf("%s: expected cron.proto to declare %q", typ, strings.TrimSpace(value))
+		}
+	}
+}
+
+// TestWireFormat checks encodings against the protobuf wire format, as
+// generated code would produce it.
+func TestWireFormat(t *testing.T) {
+	for _, test := range []struct {
+		actual, expected []byte
+	}{
+		{(&Schedule{Spec: "@daily", TimeZone: "UTC"}).Marshal(),
+			[]byte("\x0a\x06@daily\x22\x03UTC")},
+		{(&Schedule{Every: 90 * time.Second}).Marshal(),
+			[]byte("\x1a\x02\x08\x5a")},
+		{(&Event{Type: cron.RunStarted, Time: time.Unix(1, 0), EntryID: 300}).Marshal(),
+			[]byte("\x08\x02\x12\x02\x08\x01\x18\xac\x02")},
+		{(&Entry{Tags: []string{"x"}, Metadata: map[string]string{"k": "v"}}).Marshal(),
+			[]byte("\x42\x01x\x4a\x06\x0a\x01k\x12\x01v")},
+	} {
+		if !bytes.Equal(test.actual, test.expected) {
+			t.Errorf("expected % x, got % x", test.expected, test.actual)
+		}
+	}
+}
+
+func TestUnmarshalSkipsUnknownFields(t *testing.T) {
+	// Field 15 as a varint, field 14 as fixed64, then the name.
+	b := []byte("\x78\x01\x71\x00\x00\x00\x00\x00\x00\x00\x00\x12\x01n")
+	var m Entry
+	if err := m.Unmarshal(b); err != nil || m.Name != "n" {
+		t.Errorf("expected the name to be decoded, got %q, %v", m.Name, err)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, b := range [][]byte{
+		[]byte("\x12\x05ab"),   // truncated string
+		[]byte("\x08"),         // truncated varint
+		[]byte("\x12\x01"),     // truncated string length
+		[]byte("\x0a\x01\x00"), // ID as bytes
+	} {
+		var m Entry
+		if err := m.Unmarshal(b); err == nil {
+			t.Errorf("% x: expected an error", b)
+		}
+	}
+}
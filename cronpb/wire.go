@@ -0,0 +1,217 @@
+package cronpb
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// The protobuf wire types this package uses.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// errTruncated is returned for a message that ends in the middle of a field.
+var errTruncated = errors.New("cronpb: truncated message")
+
+// encoder appends the fields of a message to buf.  Fields holding their zero
+// value are left out, as in proto3.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) key(field, wire int) {
+	e.uvarint(uint64(field)<<3 | uint64(wire))
+}
+
+func (e *encoder) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf = append(e.buf, b[:binary.PutUvarint(b[:], v)]...)
+}
+
+func (e *encoder) varint(field int, v uint64) {
+	if v != 0 {
+		e.key(field, wireVarint)
+		e.uvarint(v)
+	}
+}
+
+func (e *encoder) bool(field int, v bool) {
+	if v {
+		e.varint(field, 1)
+	}
+}
+
+func (e *encoder) string(field int, s string) {
+	if s != "" {
+		e.bytes(field, []byte(s))
+	}
+}
+
+func (e *encoder) bytes(field int, b []byte) {
+	e.key(field, wireBytes)
+	e.uvarint(uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// message appends a nested message, which is present even if empty.
+func (e *encoder) message(field int, fn func(e *encoder)) {
+	var nested encoder
+	fn(&nested)
+	e.bytes(field, nested.buf)
+}
+
+// timestamp appends a google.protobuf.Timestamp, leaving out zero times.
+func (e *encoder) timestamp(field int, t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	e.message(field, func(e *encoder) {
+		e.varint(1, uint64(t.Unix()))
+		e.varint(2, uint64(t.Nanosecond()))
+	})
+}
+
+// duration appends a google.protobuf.Duration.
+func (e *encoder) duration(field int, d time.Duration) {
+	e.message(field, func(e *encoder) {
+		e.varint(1, uint64(int64(d/time.Second)))
+		e.varint(2, uint64(int64(d%time.Second)))
+	})
+}
+
+// errWireType is returned for a field whose wire type does not match its
+// type.
+var errWireType = errors.New("cronpb: wrong wire type")
+
+// decoder reads the fields of a message from buf.
+type decoder struct {
+	buf  []byte
+	wire int // of the field being read
+}
+
+// each calls fn with the number of each field of the message, for it to read
+// the field's value with the decoder.  Fields fn does not read are skipped.
+func (d *decoder) each(fn func(d *decoder, field int) error) error {
+	for len(d.buf) > 0 {
+		key, err := d.uvarint()
+		if err != nil {
+			return err
+		}
+		d.wire = int(key & 7)
+		rest := len(d.buf)
+		if err := fn(d, int(key>>3)); err != nil {
+			return err
+		}
+		if len(d.buf) == rest {
+			if err := d.skip(d.wire); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// varint reads the value of a varint field.
+func (d *decoder) varint() (uint64, error) {
+	if d.wire != wireVarint {
+		return 0, errWireType
+	}
+	return d.uvarint()
+}
+
+func (d *decoder) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	d.buf = d.buf[n:]
+	return v, nil
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	if d.wire != wireBytes {
+		return nil, errWireType
+	}
+	n, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(d.buf)) {
+		return nil, errTruncated
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b, nil
+}
+
+func (d *decoder) string() (string, error) {
+	b, err := d.bytes()
+	return string(b), err
+}
+
+// message decodes a nested message with fn.
+func (d *decoder) message(fn func(d *decoder, field int) error) error {
+	b, err := d.bytes()
+	if err != nil {
+		return err
+	}
+	nested := decoder{buf: b}
+	return nested.each(fn)
+}
+
+// seconds decodes a google.protobuf.Timestamp or Duration, as its seconds
+// and nanoseconds.
+func (d *decoder) seconds() (secs, nanos int64, err error) {
+	err = d.message(func(d *decoder, field int) error {
+		var v uint64
+		var err error
+		switch field {
+		case 1:
+			v, err = d.varint()
+			secs = int64(v)
+		case 2:
+			v, err = d.varint()
+			nanos = int64(v)
+		}
+		return err
+	})
+	return secs, nanos, err
+}
+
+func (d *decoder) timestamp() (time.Time, error) {
+	secs, nanos, err := d.seconds()
+	return time.Unix(secs, nanos).UTC(), err
+}
+
+func (d *decoder) duration() (time.Duration, error) {
+	secs, nanos, err := d.seconds()
+	return time.Duration(secs)*time.Second + time.Duration(nanos), err
+}
+
+// skip skips the value of a field of the given wire type.
+func (d *decoder) skip(wire int) error {
+	var n int
+	switch wire {
+	case wireVarint:
+		_, err := d.uvarint()
+		return err
+	case wireBytes:
+		_, err := d.bytes()
+		return err
+	case wireFixed64:
+		n = 8
+	case wireFixed32:
+		n = 4
+	default:
+		return errors.New("cronpb: unsupported wire type")
+	}
+	if len(d.buf) < n {
+		return errTruncated
+	}
+	d.buf = d.buf[n:]
+	return nil
+}
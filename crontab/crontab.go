@@ -0,0 +1,186 @@
+// Package crontab loads cron entries from crontab-format files.
+//
+// Each non-blank line of a crontab is either a comment, starting with '#', an
+// environment setting, or an entry consisting of a standard 5-field spec (or
+// a descriptor such as "@daily" or "@every 1h") followed by a command:
+//
+//	# m h dom mon dow command
+//	PATH=/usr/local/bin:/usr/bin:/bin
+//	30 9 * * MON-FRI report
+//	@every 10m       poll
+//
+// As in Vixie cron, environment settings have the form "name = value", where
+// the spaces around '=' are optional and the value may be quoted to preserve
+// leading or trailing blanks.  A setting applies to the entries following it
+// in the same file, overriding any earlier setting of the same name.
+//
+// A comment of the form "# name: <name>" names the entry of the line following
+// it, see cron.WithName.  Names must be unique across the crontab, or across
+// the files of a directory loaded together.
+//
+// Commands are turned into jobs by a JobFactory supplied by the caller, such
+// as Commands, which runs them with the shell.  The Loader runs them with the
+// accumulated environment in their context, see cron.EnvFromContext.
+package crontab
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/webconnex/cron"
+)
+
+// Line is an entry parsed from a crontab.
+type Line struct {
+	// File is the name of the file the line was read from.
+	File string
+
+	// Number is the 1-based line number within File.
+	Number int
+
+	// Name is the name given to the line's entry by a name comment, or
+	// empty.
+	Name string
+
+	// Spec is the schedule part of the line.
+	Spec string
+
+	// Schedule is the schedule parsed from Spec.
+	Schedule cron.Schedule
+
+	// Command is the rest of the line following the spec.
+	Command string
+
+	// Env is the environment set by the crontab for the line, as a list of
+	// "NAME=value" strings.
+	Env []string
+}
+
+// ParseError describes a problem with a line of a crontab.
+type ParseError struct {
+	File string
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+}
+
+// Parse reads the entries of a crontab.  The file name is used to identify
+// the crontab in the returned lines and errors.
+func Parse(file string, r io.Reader) ([]Line, error) {
+	var (
+		lines []Line
+		env   []string
+		name  string
+	)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if text[0] == '#' {
+			if rest := strings.TrimSpace(text[1:]); strings.HasPrefix(rest, "name:") {
+				name = strings.TrimSpace(rest[len("name:"):])
+			}
+			continue
+		}
+		if key, value, ok := parseEnv(text); ok {
+			env = setEnv(env, key, value)
+			continue
+		}
+		line, err := parseLine(text)
+		if err != nil {
+			return nil, &ParseError{File: file, Line: n, Err: err}
+		}
+		line.File = file
+		line.Number = n
+		line.Name = name
+		line.Env = env
+		lines = append(lines, line)
+		name = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseLine parses a single non-comment crontab line.
+func parseLine(text string) (Line, error) {
+	n := 5
+	if text[0] == '@' {
+		n = 1
+		if strings.HasPrefix(text, "@every ") {
+			n = 2
+		}
+	}
+
+	fields, command := splitFields(text, n)
+	if len(fields) < n || command == "" {
+		return Line{}, fmt.Errorf("expected a spec followed by a command: %s", text)
+	}
+	spec := strings.Join(fields, " ")
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return Line{}, err
+	}
+	return Line{Spec: spec, Schedule: schedule, Command: command}, nil
+}
+
+// splitFields returns the first n whitespace-separated fields of text, and the
+// remainder of text with surrounding whitespace removed.
+func splitFields(text string, n int) ([]string, string) {
+	var fields []string
+	for len(fields) < n {
+		text = strings.TrimLeft(text, " \t")
+		if text == "" {
+			break
+		}
+		i := strings.IndexAny(text, " \t")
+		if i < 0 {
+			i = len(text)
+		}
+		fields = append(fields, text[:i])
+		text = text[i:]
+	}
+	return fields, strings.TrimSpace(text)
+}
+
+// parseEnv parses an environment setting, returning false if text is not one.
+func parseEnv(text string) (key, value string, ok bool) {
+	i := strings.IndexByte(text, '=')
+	if i < 0 {
+		return "", "", false
+	}
+	key = strings.TrimSpace(text[:i])
+	if key == "" {
+		return "", "", false
+	}
+	for j, r := range key {
+		if !(r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || j > 0 && '0' <= r && r <= '9') {
+			return "", "", false
+		}
+	}
+
+	value = strings.TrimSpace(text[i+1:])
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return key, value, true
+}
+
+// setEnv returns a copy of env with key set to value.
+func setEnv(env []string, key, value string) []string {
+	var result []string
+	for _, kv := range env {
+		if !strings.HasPrefix(kv, key+"=") {
+			result = append(result, kv)
+		}
+	}
+	return append(result, key+"="+value)
+}
@@ -0,0 +1,77 @@
+package crontab
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	const tab = `
+# m h dom mon dow command
+30 9 * * MON-FRI  /usr/local/bin/report --daily
+	@every 10m	poll
+@hourly echo "on the hour"
+`
+	lines, err := Parse("tab", strings.NewReader(tab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Line{
+		{File: "tab", Number: 3, Spec: "30 9 * * MON-FRI", Command: "/usr/local/bin/report --daily"},
+		{File: "tab", Number: 4, Spec: "@every 10m", Command: "poll"},
+		{File: "tab", Number: 5, Spec: "@hourly", Command: `echo "on the hour"`},
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %d", len(expected), len(lines))
+	}
+	for i, line := range lines {
+		if line.Schedule == nil {
+			t.Errorf("line %d: expected a schedule", line.Number)
+		}
+		line.Schedule = nil
+		if !reflect.DeepEqual(line, expected[i]) {
+			t.Errorf("(expected) %+v != %+v (actual)", expected[i], line)
+		}
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	const tab = `
+SHELL=/bin/bash
+0 0 * * * a
+ MAILTO = " ops@example.com "
+SHELL='/bin/zsh'
+0 0 * * * b
+`
+	lines, err := Parse("tab", strings.NewReader(tab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{
+		{"SHELL=/bin/bash"},
+		{"MAILTO= ops@example.com ", "SHELL=/bin/zsh"},
+	}
+	for i, line := range lines {
+		if strings.Join(line.Env, "|") != strings.Join(expected[i], "|") {
+			t.Errorf("line %d: (expected) %q != %q (actual)", line.Number, expected[i], line.Env)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		tab, err string
+	}{
+		{"* * * * *", "tab:1: expected a spec followed by a command"},
+		{"# ok\n61 * * * * cmd", "tab:2: End of range"},
+		{"@every", "tab:1: expected a spec followed by a command"},
+		{"@bogus cmd", "tab:1: Unrecognized descriptor"},
+	}
+	for _, c := range tests {
+		_, err := Parse("tab", strings.NewReader(c.tab))
+		if err == nil || !strings.HasPrefix(err.Error(), c.err) {
+			t.Errorf("%q: expected %q, got %v", c.tab, c.err, err)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package crontab
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// JobFactory returns the job that runs the given command.
+type JobFactory func(command string) (cron.Job, error)
+
+// Commands is a JobFactory that runs each command with the shell, as
+// cron.CommandJob does.
+func Commands(command string) (cron.Job, error) {
+	return cron.CommandJob{Command: command}, nil
+}
+
+// Loader keeps the entries of a Cron in sync with a crontab file, or with a
+// directory of crontab fragments such as /etc/cron.d.  As in cron.d, only
+// the files of a directory whose names consist of letters, digits,
+// underscores and hyphens are loaded, skipping hidden files, editor backups
+// and package manager leftovers such as "job.dpkg-old".  The fragments are
+// merged into one set of entries, whose names must be unique across them.
+//
+// Entries are identified by their command: when a line's spec changes, the
+// schedule of its entry is updated in place; when a command appears or
+// disappears, its entry is added or removed.  An entry whose environment or
+// name changes is replaced.
+//
+// Jobs are run with their line's environment in their context, see
+// cron.EnvFromContext.
+type Loader struct {
+	ErrorLog *log.Logger
+
+	cron    *cron.Cron
+	path    string
+	factory JobFactory
+
+	mu          sync.Mutex
+	loaded      map[string]loaded
+	fingerprint string
+	stop        chan struct{}
+}
+
+// loaded records the entry added for a crontab line.
+type loaded struct {
+	id   cron.EntryID
+	spec string
+	env  string
+	name string
+}
+
+// NewLoader returns a Loader that reconciles the entries of c with the crontab
+// at path, which may be a file or a directory.
+func NewLoader(c *cron.Cron, path string, factory JobFactory) *Loader {
+	return &Loader{
+		cron:    c,
+		path:    path,
+		factory: factory,
+		loaded:  make(map[string]loaded),
+	}
+}
+
+// Load reads the crontab and reconciles the entries of the Cron with it.  If
+// the crontab cannot be read or contains an invalid line, the entries are left
+// unchanged and the error is returned.
+func (l *Loader) Load() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stamp, err := l.stamp()
+	if err != nil {
+		return err
+	}
+	lines, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	// Key each line by its command, numbering repeated commands in order.
+	keyed := make(map[string]Line)
+	var keys []string
+	seen := make(map[string]int)
+	for _, line := range lines {
+		seen[line.Command]++
+		key := fmt.Sprintf("%s#%d", line.Command, seen[line.Command])
+		keyed[key] = line
+		keys = append(keys, key)
+	}
+
+	// Build the jobs for new lines before touching the Cron.
+	jobs := make(map[string]cron.Job)
+	for _, key := range keys {
+		line := keyed[key]
+		if entry, ok := l.loaded[key]; ok && entry.env == envString(line.Env) && entry.name == line.Name {
+			continue
+		}
+		job, err := l.factory(line.Command)
+		if err != nil {
+			return &ParseError{File: line.File, Line: line.Number, Err: err}
+		}
+		jobs[key] = envJob{job, line.Env}
+	}
+
+	for key, entry := range l.loaded {
+		if _, ok := keyed[key]; !ok || jobs[key] != nil {
+			l.cron.Remove(entry.id)
+			delete(l.loaded, key)
+		}
+	}
+	for _, key := range keys {
+		line := keyed[key]
+		entry, ok := l.loaded[key]
+		switch {
+		case !ok:
+			id := l.cron.Schedule(line.Schedule, jobs[key], cron.WithName(line.Name))
+			l.loaded[key] = loaded{id, line.Spec, envString(line.Env), line.Name}
+		case entry.spec != line.Spec:
+			l.cron.UpdateSchedule(entry.id, line.Schedule)
+			entry.spec = line.Spec
+			l.loaded[key] = entry
+		}
+	}
+	l.fingerprint = stamp
+	return nil
+}
+
+// envJob runs a job with the environment of its crontab line in its context.
+type envJob struct {
+	cron.Job
+	env []string
+}
+
+func (j envJob) RunContext(ctx context.Context) error {
+	ctx = cron.ContextWithEnv(ctx, j.env)
+	if cj, ok := j.Job.(cron.ContextJob); ok {
+		return cj.RunContext(ctx)
+	}
+	j.Job.Run()
+	return nil
+}
+
+// envString returns a comparable representation of env.
+func envString(env []string) string {
+	return strings.Join(env, "\x00")
+}
+
+// Start loads the crontab and then polls it for changes at the given interval,
+// reloading it whenever it changes.
+func (l *Loader) Start(interval time.Duration) error {
+	if err := l.Load(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stop != nil {
+		return nil
+	}
+	l.stop = make(chan struct{})
+	go l.watch(interval, l.stop)
+	return nil
+}
+
+// Stop stops polling the crontab for changes.  Loaded entries are left in
+// the Cron.
+func (l *Loader) Stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stop != nil {
+		close(l.stop)
+		l.stop = nil
+	}
+}
+
+func (l *Loader) watch(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			l.mu.Lock()
+			stamp, err := l.stamp()
+			changed := err == nil && stamp != l.fingerprint
+			l.mu.Unlock()
+			if err != nil {
+				l.logf("crontab: %v", err)
+				continue
+			}
+			if changed {
+				if err := l.Load(); err != nil {
+					l.logf("crontab: %v", err)
+				}
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// files returns the crontab files at the loader's path, in name order.
+// Subdirectories of a directory, and files cron.d would not load, are
+// skipped.
+func (l *Loader) files() ([]string, error) {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{l.path}, nil
+	}
+	infos, err := ioutil.ReadDir(l.path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, info := range infos {
+		if info.IsDir() || !fragmentName(info.Name()) {
+			continue
+		}
+		files = append(files, filepath.Join(l.path, info.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// fragmentName reports whether cron.d would load a file of the given name.
+func fragmentName(name string) bool {
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// read parses all of the crontab files, checking that the names of their
+// entries are unique.
+func (l *Loader) read() ([]Line, error) {
+	files, err := l.files()
+	if err != nil {
+		return nil, err
+	}
+	var lines []Line
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fileLines, err := Parse(file, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, fileLines...)
+	}
+	named := make(map[string]Line)
+	for _, line := range lines {
+		if line.Name == "" {
+			continue
+		}
+		if prev, ok := named[line.Name]; ok {
+			return nil, &ParseError{File: line.File, Line: line.Number,
+				Err: fmt.Errorf("entry %q is already defined at %s:%d", line.Name, prev.File, prev.Number)}
+		}
+		named[line.Name] = line
+	}
+	return lines, nil
+}
+
+// stamp returns a fingerprint of the names, sizes and modification times of
+// the crontab files, which changes whenever one of them does.
+func (l *Loader) stamp() (string, error) {
+	files, err := l.files()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&buf, "%s %d %d\n", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return buf.String(), nil
+}
+
+func (l *Loader) logf(format string, args ...interface{}) {
+	if l.ErrorLog != nil {
+		l.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
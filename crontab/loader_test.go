@@ -0,0 +1,221 @@
+package crontab
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+type commandJob string
+
+func (commandJob) Run() {}
+
+func factory(command string) (cron.Job, error) {
+	if command == "fail" {
+		return nil, errors.New("unknown command")
+	}
+	return commandJob(command), nil
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// entries returns the entries of c keyed by their command.
+func entries(c *cron.Cron) map[string]*cron.Entry {
+	m := make(map[string]*cron.Entry)
+	for _, e := range c.Entries() {
+		m[string(e.Job.(envJob).Job.(commandJob))] = e
+	}
+	return m
+}
+
+func TestLoaderReconciles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crontab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "crontab")
+
+	c := cron.New()
+	l := NewLoader(c, path, factory)
+
+	writeFile(t, path, "0 * * * * a\n0 * * * * b\n")
+	if err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+	before := entries(c)
+	if len(before) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(before))
+	}
+
+	// Edit a, remove b, add c.
+	writeFile(t, path, "30 * * * * a\n0 * * * * c\n")
+	if err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+	after := entries(c)
+	if len(after) != 2 || after["b"] != nil || after["c"] == nil {
+		t.Fatalf("unexpected entries: %v", after)
+	}
+	if after["a"].ID != before["a"].ID {
+		t.Error("expected edited entry to keep its ID")
+	}
+	if after["a"].Schedule == before["a"].Schedule {
+		t.Error("expected edited entry to have a new schedule")
+	}
+
+	// An invalid crontab leaves the entries unchanged.
+	writeFile(t, path, "0 * * * * fail\n")
+	if err := l.Load(); err == nil {
+		t.Error("expected an error")
+	}
+	if len(entries(c)) != 2 {
+		t.Error("expected entries to be unchanged")
+	}
+}
+
+func TestLoaderWatchesDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crontab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFile(t, filepath.Join(dir, "a"), "0 * * * * a\n")
+	writeFile(t, filepath.Join(dir, ".hidden"), "0 * * * * hidden\n")
+
+	c := cron.New()
+	l := NewLoader(c, dir, factory)
+	if err := l.Start(10 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Stop()
+	if len(entries(c)) != 1 {
+		t.Fatalf("expected 1 entry, got %v", entries(c))
+	}
+
+	writeFile(t, filepath.Join(dir, "b"), "0 * * * * b\n")
+	deadline := time.Now().Add(time.Second)
+	for len(entries(c)) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the new file to be loaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestLoaderEnv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crontab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "crontab")
+
+	envs := make(chan []string, 1)
+	c := cron.New()
+	l := NewLoader(c, path, func(command string) (cron.Job, error) {
+		return cron.ContextFuncJob(func(ctx context.Context) error {
+			envs <- cron.EnvFromContext(ctx)
+			return nil
+		}), nil
+	})
+
+	writeFile(t, path, "A=1\n0 0 1 1 * a\n")
+	if err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+	id := c.Entries()[0].ID
+
+	// Changing the environment replaces the entry.
+	writeFile(t, path, "A=2\n0 0 1 1 * a\n")
+	if err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+	entry := c.Entries()[0]
+	if entry.ID == id {
+		t.Error("expected the entry to be replaced")
+	}
+
+	c.Trigger(entry.ID)
+	select {
+	case env := <-envs:
+		if len(env) != 1 || env[0] != "A=2" {
+			t.Errorf("unexpected environment %q", env)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected job to run")
+	}
+}
+
+func TestCommands(t *testing.T) {
+	job, _ := Commands("echo hello")
+	if cmd, ok := job.(cron.CommandJob); !ok || cmd.Command != "echo hello" {
+		t.Errorf("unexpected job %#v", job)
+	}
+}
+
+func TestLoaderCronD(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crontab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFile(t, filepath.Join(dir, "billing"), "# name: invoices\n0 * * * * a\n")
+	writeFile(t, filepath.Join(dir, "ops_2"), "# name: rotate\n0 0 * * * b\n")
+	for _, skipped := range []string{"billing~", "billing.dpkg-old", "ops.swp"} {
+		writeFile(t, filepath.Join(dir, skipped), "0 * * * * skipped\n")
+	}
+
+	c := cron.New()
+	l := NewLoader(c, dir, factory)
+	if err := l.Start(10 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Stop()
+	loaded := entries(c)
+	if len(loaded) != 2 || loaded["a"].Name != "invoices" || loaded["b"].Name != "rotate" {
+		t.Fatalf("unexpected entries: %v", loaded)
+	}
+
+	// A fragment reusing a name is rejected, leaving the entries unchanged.
+	dup := filepath.Join(dir, "zz")
+	writeFile(t, dup, "\n# name: invoices\n0 * * * * c\n")
+	err = l.Load()
+	if err == nil || err.Error() != dup+`:3: entry "invoices" is already defined at `+filepath.Join(dir, "billing")+":2" {
+		t.Errorf("expected a duplicate name error, got %v", err)
+	}
+	if len(entries(c)) != 2 {
+		t.Error("expected entries to be unchanged")
+	}
+
+	// Removing fragments removes their entries.
+	os.Remove(dup)
+	os.Remove(filepath.Join(dir, "ops_2"))
+	deadline := time.Now().Add(time.Second)
+	for len(entries(c)) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the removed fragment's entry to be removed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Renaming an entry replaces it.
+	id := entries(c)["a"].ID
+	writeFile(t, filepath.Join(dir, "billing"), "# name: invoicing\n0 * * * * a\n")
+	if err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if e := entries(c)["a"]; e.ID == id || e.Name != "invoicing" {
+		t.Errorf("expected the entry to be replaced under its new name, got %+v", e)
+	}
+}
@@ -0,0 +1,168 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Describe returns a description of the schedule in English, e.g.
+// "At 09:30:00, on Monday through Friday".  Schedules other than those
+// returned by Parse are described by their Go syntax representation.
+func Describe(schedule Schedule) string {
+	switch s := schedule.(type) {
+	case ConstantDelaySchedule:
+		return "Every " + s.Delay.String()
+	case *SpecSchedule:
+		return describeSpec(s)
+	case PredicateSchedule:
+		return describeSpec(s.Spec) + ", when its predicate holds"
+	case PackedSchedule:
+		return describeSpec(s.Spec())
+	case MonthEndSchedule:
+		return "At 00:00:00, on the last day of the month"
+	}
+	return fmt.Sprintf("%#v", schedule)
+}
+
+func describeSpec(s *SpecSchedule) string {
+	var clauses []string
+
+	sec, secOK := single(s.Second, seconds)
+	min, minOK := single(s.Minute, minutes)
+	hour, hourOK := single(s.Hour, hours)
+	if secOK && minOK && hourOK {
+		clauses = append(clauses, fmt.Sprintf("at %02d:%02d:%02d", hour, min, sec))
+	} else {
+		secAll, minAll := isAll(s.Second, seconds), isAll(s.Minute, minutes)
+		clauses = append(clauses, describeField(s.Second, seconds, "second", nil))
+		if !secAll || !minAll {
+			clauses = append(clauses, describeField(s.Minute, minutes, "minute", nil))
+		}
+		if !isAll(s.Hour, hours) || !minAll {
+			clauses = append(clauses, describeField(s.Hour, hours, "hour", nil))
+		}
+		if !strings.HasPrefix(clauses[0], "every") {
+			clauses[0] = "at " + clauses[0]
+		}
+	}
+
+	byDom, byDow, both := s.restrictsDays()
+	var days string
+	switch {
+	case !byDom && !byDow:
+	case !byDom:
+		days = "on " + describeField(s.Dow, dow, "", weekdayName)
+	case !byDow:
+		days = "on " + describeField(s.Dom, dom, "day", nil) + " of the month"
+	default:
+		conj := " or on "
+		if both {
+			conj = " and on "
+		}
+		days = "on " + describeField(s.Dom, dom, "day", nil) + " of the month" + conj +
+			describeField(s.Dow, dow, "", weekdayName)
+	}
+	if days != "" {
+		clauses = append(clauses, days)
+	}
+
+	if !isAll(s.Month, months) {
+		clauses = append(clauses, "in "+describeField(s.Month, months, "", monthName))
+	}
+
+	desc := strings.Join(clauses, ", ")
+	return strings.ToUpper(desc[:1]) + desc[1:]
+}
+
+// describeField describes the values set in bits.  If name is nil, values are
+// written as numbers following the given unit; otherwise they are named.
+func describeField(bits uint64, r bounds, unit string, name func(uint) string) string {
+	if isAll(bits, r) {
+		return "every " + unit
+	}
+	vals := values(bits, r)
+	if name == nil {
+		name = func(v uint) string { return fmt.Sprint(v) }
+
+		// Describe steps, e.g. "every 15 minutes".
+		if len(vals) > 2 {
+			step := vals[1] - vals[0]
+			for i := 2; i < len(vals); i++ {
+				if vals[i]-vals[i-1] != step {
+					step = 0
+					break
+				}
+			}
+			if step > 1 && vals[len(vals)-1]+step > r.max {
+				desc := fmt.Sprintf("every %d %ss", step, unit)
+				if vals[0] != r.min {
+					desc += fmt.Sprintf(" from %s %d", unit, vals[0])
+				}
+				return desc
+			}
+		}
+	}
+
+	// Collapse runs of three or more consecutive values into ranges.
+	var items []string
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, name(vals[i])+" through "+name(vals[j]))
+			i = j + 1
+			continue
+		}
+		items = append(items, name(vals[i]))
+		i++
+	}
+
+	list := items[0]
+	if len(items) > 1 {
+		list = strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+	}
+	if unit == "" {
+		return list
+	}
+	if len(vals) > 1 {
+		unit += "s"
+	}
+	return unit + " " + list
+}
+
+// values returns the values set in bits, in ascending order.
+func values(bits uint64, r bounds) []uint {
+	var vals []uint
+	for i := r.min; i <= r.max; i++ {
+		if bits&(1<<i) > 0 {
+			vals = append(vals, i)
+		}
+	}
+	return vals
+}
+
+// single returns the only value set in bits, if there is exactly one.
+func single(bits uint64, r bounds) (uint, bool) {
+	vals := values(bits, r)
+	if len(vals) != 1 {
+		return 0, false
+	}
+	return vals[0], true
+}
+
+// isAll returns true if every value within the bounds is set in bits.
+func isAll(bits uint64, r bounds) bool {
+	full := getBits(r.min, r.max, 1)
+	return bits&full == full
+}
+
+func weekdayName(v uint) string {
+	return time.Weekday(v).String()
+}
+
+func monthName(v uint) string {
+	return time.Month(v).String()
+}
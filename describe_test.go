@@ -0,0 +1,35 @@
+package cron
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"* * * * * *", "Every second"},
+		{"0 * * * * *", "At second 0, every minute"},
+		{"0 30 * * * *", "At second 0, minute 30, every hour"},
+		{"0 30 9 * * *", "At 09:30:00"},
+		{"0 */15 9-17 * * MON-FRI", "At second 0, every 15 minutes, hours 9 through 17, on Monday through Friday"},
+		{"0 5/15 * * * *", "At second 0, every 15 minutes from minute 5, every hour"},
+		{"0 0 0 1,15 * *", "At 00:00:00, on days 1 and 15 of the month"},
+		{"0 0 0 1 * Sun", "At 00:00:00, on day 1 of the month or on Sunday"},
+		{"0 0 9 1-31 * MON", "At 09:00:00"},
+		{"0 0 0 */2 * MON", "At 00:00:00, on every 2 days of the month and on Monday"},
+		{"0 0 0 * Jan,Jul ?", "At 00:00:00, in January and July"},
+		{"0 0 12 ? * MON,WED,FRI", "At 12:00:00, on Monday, Wednesday and Friday"},
+		{"@weekly", "At 00:00:00, on Sunday"},
+		{"@every 1h30m", "Every 1h30m0s"},
+	}
+
+	for _, c := range tests {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual := Describe(sched); actual != c.expected {
+			t.Errorf("%s: (expected) %q != %q (actual)", c.spec, c.expected, actual)
+		}
+	}
+}
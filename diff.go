@@ -0,0 +1,117 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// maxDiffTimes bounds the removed and added activations a ScheduleDiff
+	// lists; it counts the rest.
+	maxDiffTimes = 20
+
+	// maxDiffActivations bounds the times at which Diff compares the
+	// schedules, so that comparing frequent schedules over long horizons
+	// ends.
+	maxDiffActivations = 1 << 20
+)
+
+// ScheduleDiff is the difference between the activations of two schedules
+// over a period, as Diff returns it.
+type ScheduleDiff struct {
+	// From and To bound the period compared.  To is before the end of the
+	// horizon if the comparison was truncated.
+	From, To time.Time
+
+	// Removed lists the first activations of the old schedule that the new
+	// one lacks, and Added the first of the new schedule that the old one
+	// lacks, in order.
+	Removed, Added []time.Time
+
+	// RemovedCount and AddedCount count all the removed and added
+	// activations, including those not listed, and Unchanged those of both.
+	RemovedCount, AddedCount, Unchanged int
+
+	// Truncated reports whether the comparison stopped before the end of
+	// the horizon, at To, as the schedules activate too often to compare
+	// further.
+	Truncated bool
+}
+
+// Diff compares the activations of the old schedule a and the new schedule
+// b after from, and within horizon of it, for showing reviewers of a
+// schedule change exactly how its firing behavior changes.  The activations
+// only one schedule has are listed, up to 20 of each kind, and all are
+// counted.  At most about a million activations of the schedules are
+// compared, see ScheduleDiff.Truncated.
+func Diff(a, b Schedule, from time.Time, horizon time.Duration) ScheduleDiff {
+	d := ScheduleDiff{From: from, To: from.Add(horizon)}
+	ta, tb := a.Next(from), b.Next(from)
+	for n := 0; ; n++ {
+		doneA := ta.IsZero() || ta.After(d.To)
+		doneB := tb.IsZero() || tb.After(d.To)
+		switch {
+		case doneA && doneB:
+			return d
+		case n == maxDiffActivations:
+			// Everything before the next activation was compared.
+			d.Truncated = true
+			if doneB || !doneA && ta.Before(tb) {
+				d.To = ta
+			} else {
+				d.To = tb
+			}
+			return d
+		case !doneA && !doneB && ta.Equal(tb):
+			d.Unchanged++
+			ta, tb = a.Next(ta), b.Next(tb)
+		case doneB || !doneA && ta.Before(tb):
+			d.RemovedCount++
+			if len(d.Removed) < maxDiffTimes {
+				d.Removed = append(d.Removed, ta)
+			}
+			ta = a.Next(ta)
+		default:
+			d.AddedCount++
+			if len(d.Added) < maxDiffTimes {
+				d.Added = append(d.Added, tb)
+			}
+			tb = b.Next(tb)
+		}
+	}
+}
+
+// Empty reports whether the schedules activate at the same times.
+func (d ScheduleDiff) Empty() bool {
+	return d.RemovedCount == 0 && d.AddedCount == 0
+}
+
+// String summarizes the difference, followed by the listed activations in
+// time order, those removed prefixed by "-" and those added by "+", as in:
+//
+//	-1 +2 activations, 3 unchanged, from 2024-01-01T00:00:00Z to 2024-01-02T00:00:00Z
+//	- 2024-01-01T09:00:00Z
+//	+ 2024-01-01T09:30:00Z
+//	+ 2024-01-01T10:30:00Z
+func (d ScheduleDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "-%d +%d activations, %d unchanged, from %s to %s", d.RemovedCount, d.AddedCount, d.Unchanged,
+		d.From.Format(time.RFC3339), d.To.Format(time.RFC3339))
+	if d.Truncated {
+		b.WriteString(" (truncated)")
+	}
+	for i, j := 0, 0; i < len(d.Removed) || j < len(d.Added); {
+		if j == len(d.Added) || i < len(d.Removed) && d.Removed[i].Before(d.Added[j]) {
+			fmt.Fprintf(&b, "\n- %s", d.Removed[i].Format(time.RFC3339Nano))
+			i++
+		} else {
+			fmt.Fprintf(&b, "\n+ %s", d.Added[j].Format(time.RFC3339Nano))
+			j++
+		}
+	}
+	if more := d.RemovedCount - len(d.Removed) + d.AddedCount - len(d.Added); more > 0 {
+		fmt.Fprintf(&b, "\n... and %d more", more)
+	}
+	return b.String()
+}
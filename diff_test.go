@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2024, time.January, day, hour, min, 0, 0, time.UTC)
+	}
+	d := Diff(mustParse("0 0 9 * * *"), mustParse("0 0 9 * * MON-FRI"), from, 7*24*time.Hour)
+	if d.RemovedCount != 2 || d.AddedCount != 0 || d.Unchanged != 5 || d.Empty() {
+		t.Errorf("expected the weekend activations to be removed, got %+v", d)
+	}
+	if len(d.Removed) != 2 || !d.Removed[0].Equal(at(6, 9, 0)) || !d.Removed[1].Equal(at(7, 9, 0)) {
+		t.Errorf("unexpected removed activations %v", d.Removed)
+	}
+
+	d = Diff(mustParse("0 0 9 * * *"), mustParse("0 30 9 * * *"), from, 24*time.Hour)
+	expected := "-1 +1 activations, 0 unchanged, from 2024-01-01T00:00:00Z to 2024-01-02T00:00:00Z\n" +
+		"- 2024-01-01T09:00:00Z\n" +
+		"+ 2024-01-01T09:30:00Z"
+	if d.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, d.String())
+	}
+
+	if d := Diff(mustParse("@hourly"), mustParse("0 0 * * * *"), from, 24*time.Hour); !d.Empty() || d.Unchanged != 24 {
+		t.Errorf("expected no difference, got %+v", d)
+	}
+}
+
+func TestDiffBounds(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	d := Diff(mustParse("* * * * * *"), mustParse("*/2 * * * * *"), from, time.Hour)
+	if d.RemovedCount != 1800 || len(d.Removed) != maxDiffTimes || d.Unchanged != 1800 {
+		t.Errorf("expected the removed activations to be counted and the first listed, got %d, %d",
+			d.RemovedCount, len(d.Removed))
+	}
+	if !strings.HasSuffix(d.String(), "... and 1780 more") {
+		t.Errorf("expected the unlisted activations to be summarized, got %q", d.String())
+	}
+
+	d = Diff(mustParse("* * * * * *"), mustParse("* * * * * *"), from, 365*24*time.Hour)
+	if !d.Truncated || d.Unchanged != maxDiffActivations || !d.To.Equal(from.Add(maxDiffActivations*time.Second+time.Second)) {
+		t.Errorf("expected the comparison to be truncated, got %+v", d)
+	}
+}
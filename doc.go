@@ -20,7 +20,12 @@ them in their own goroutines.
 	// Inspect the cron job entries' next and previous run times.
 	inspect(c.Entries())
 	..
-	c.Stop()  // Stop the scheduler (does not stop any jobs already running).
+	// Entries may be paused, resumed, triggered and removed by ID.
+	id, _ := c.AddFunc("@every 10m", func() { fmt.Println("Every ten minutes") })
+	c.Pause(id)
+	..
+	ctx := c.Stop()  // Stop the scheduler (does not stop any jobs already running).
+	<-ctx.Done()     // Wait for the running jobs to finish.
 
 CRON Expression Format
 
@@ -55,6 +60,10 @@ that is, an increment over the largest possible range of the field.  The form
 "N/..." is accepted as meaning "N-MAX/...", that is, starting at N, use the
 increment until the end of that specific range.  It does not wrap around.
 
+Names count as their numbers: "MON-FRI/2" means Mondays, Wednesdays and
+Fridays, "JAN/3" January, April, July and October.  In a list, each item
+takes its own increment, so "MON/2,SUN" adds Sundays to the first.
+
 Comma ( , )
 
 Commas are used to separate items of a list. For example, using "MON,WED,FRI" in
@@ -78,10 +87,14 @@ You may use one of several pre-defined schedules in place of a cron expression.
 	-----                  | -----------                                | -------------
 	@yearly (or @annually) | Run once a year, midnight, Jan. 1st        | 0 0 0 1 1 *
 	@monthly               | Run once a month, midnight, first of month | 0 0 0 1 * *
+	@monthend              | Run once a month, midnight, last of month  | (none)
 	@weekly                | Run once a week, midnight on Sunday        | 0 0 0 * * 0
 	@daily (or @midnight)  | Run once a day, midnight                   | 0 0 0 * * *
 	@hourly                | Run once an hour, beginning of hour        | 0 0 * * * *
 
+No spec can select the last day of each month, so @monthend has no
+equivalent; the WithMonthlyAtMonthEnd option reads @monthly as @monthend.
+
 Intervals
 
 You may also schedule a job to execute at fixed intervals.  This is supported by
@@ -107,6 +120,39 @@ provided by the Go time package (http://www.golang.org/pkg/time).
 Be aware that jobs scheduled during daylight-savings leap-ahead transitions will
 not be run!
 
+Run keys
+
+Every activation is identified by a RunKey derived from the entry's ID and the
+activation's scheduled time.  Jobs implementing ContextJob can read it with
+RunKeyFromContext to dedupe side effects downstream.  If the Cron is created
+with WithStore, each activation is claimed in the Store before it runs, and an
+activation the Store has already recorded is not dispatched again.
+
+Each invocation also gets a unique run ID, available to ContextJobs through
+RunIDFromContext and carried in the Run records, events and log messages
+about it, for correlating them with application logs and traces.
+
+Migrating from robfig/cron
+
+The Schedule interface of robfig/cron v3 has the same method set as this
+package's, so schedules convert both ways without adapters: a robfig Schedule
+may be passed to Cron.Schedule, and a Schedule from this package to robfig's
+Cron.Schedule.  Specs differ, as robfig's default parser takes five fields,
+minute first, where Parse takes six, seconds first.  ParseRobfig and the
+WithRobfigSpecs option accept robfig's specs unchanged, including their
+CRON_TZ= prefix, so that entries can move between the libraries one at a time.
+
+WebAssembly and TinyGo
+
+The package builds for js/wasm and wasip1/wasm, and with TinyGo, so that
+specs can be parsed, validated and described, and their activations
+computed, client-side, as in a schedule editor.  The build tags js, wasip1
+and tinygo leave out the features those platforms cannot support:
+RunUntilSignal, as there are no signals to wait for, and
+ExportTaskScheduler, whose encoding/xml leans on reflection TinyGo does not
+fully implement.  CommandJob remains, but returns an error when run, as there
+are no processes to run commands in.
+
 Thread safety
 
 Since the Cron service runs concurrently with the calling code, some amount of
@@ -115,15 +161,27 @@ care must be taken to ensure proper synchronization.
 All cron methods are designed to be correctly synchronized as long as the caller
 ensures that invocations have a clear happens-before ordering between them.
 
+In particular, AddFunc, AddJob, Schedule, Remove, Pause, Resume and
+UpdateSchedule may be called from any goroutine while the Cron is running,
+including from within jobs.  Each takes effect before it returns, and so
+before the scheduler's next activation; there is no need to stop and restart
+the Cron around them.
+
 Implementation
 
-Cron entries are stored in an array, sorted by their next activation time.  Cron
-sleeps until the next job is due to be run.
+Cron entries are stored in a min-heap, keyed by their next activation time, so
+that adding and removing entries costs O(log n) and finding the soonest entry
+O(1).  Cron sleeps until the next job is due to be run.
 
 Upon waking:
- - it runs each entry that is active on that second
+ - it pops each entry that is active on that second and runs them, in
+   registration order (see Entry.Before)
  - it calculates the next run times for the jobs that were run
- - it re-sorts the array of entries by next activation time.
+ - it pushes them back onto the heap
  - it goes to sleep until the soonest job.
+
+With the WithTimingWheel option, entries are instead kept in a hierarchical
+timing wheel of one-second slots, which makes adding and removing entries O(1)
+at the cost of ordering far-off entries only roughly until they draw near.
 */
 package cron
@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"context"
+	"sync"
+)
+
+// DrainProgress reports the runs a draining Cron is waiting for, see Drain.
+type DrainProgress struct {
+	// Running counts the runs that have started and not yet finished.
+	Running int
+
+	// Queued counts the runs that have been dispatched and not yet
+	// started, as they wait out a delay, such as their jitter or a rate
+	// limit, or wait for a worker.
+	Queued int
+}
+
+// Done returns true if no runs are left.
+func (p DrainProgress) Done() bool {
+	return p.Running == 0 && p.Queued == 0
+}
+
+// Drain stops the scheduler from activating entries, as for a deploy in
+// which another process has already taken over scheduling, while the runs
+// already dispatched, queued or running, carry on to completion.  Unlike
+// Stop, it leaves the scheduler serving Entries, Trigger and the other
+// methods, and unlike PauseAll, it is not undone by ResumeAll: a drained
+// Cron is meant to be stopped.
+//
+// If progress is not nil, it is called with the runs left when Drain is
+// called and each time that changes, until none are left.  It is called with
+// a lock held, so it should return quickly.  The returned context is done
+// once no runs are left.
+func (c *Cron) Drain(progress func(DrainProgress)) context.Context {
+	c.do(func() {
+		c.draining = true
+	})
+	c.inflight.watch(progress)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		c.jobs.Wait()
+		cancel()
+	}()
+	return ctx
+}
+
+// inflight counts the runs dispatched and not yet finished, for Drain.  The
+// zero value counts none.
+type inflight struct {
+	mu       sync.Mutex
+	progress DrainProgress
+	report   func(DrainProgress)
+}
+
+// update adds to the counts of queued and running runs.
+func (f *inflight) update(queued, running int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.progress.Queued += queued
+	f.progress.Running += running
+	if f.report != nil {
+		f.report(f.progress)
+		if f.progress.Done() {
+			f.report = nil
+		}
+	}
+}
+
+// watch reports the counts, and their changes, to report.
+func (f *inflight) watch(report func(DrainProgress)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if report == nil {
+		return
+	}
+	report(f.progress)
+	if !f.progress.Done() {
+		f.report = report
+	}
+}
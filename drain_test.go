@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDrain(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 10)
+	cron := New()
+	runs := 0
+	var mu sync.Mutex
+	cron.AddFunc("* * * * * ?", func() {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+		started <- struct{}{}
+		<-release
+	})
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-started:
+	case <-time.After(2 * ONE_SECOND):
+		t.Fatal("expected the job to run")
+	}
+
+	var progress []DrainProgress
+	ctx := cron.Drain(func(p DrainProgress) { progress = append(progress, p) })
+	select {
+	case <-ctx.Done():
+		t.Fatal("expected the drain to wait for the running job")
+	case <-time.After(1500 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-ctx.Done():
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the drain to finish")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if runs != 1 {
+		t.Errorf("expected no run after the drain, got %d runs", runs)
+	}
+	if len(progress) != 2 || progress[0] != (DrainProgress{Running: 1}) || !progress[1].Done() {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+	if len(cron.Entries()) != 1 {
+		t.Error("expected a drained Cron to keep serving its entries")
+	}
+}
+
+func TestDrainQueued(t *testing.T) {
+	cron := New()
+	ran := make(chan struct{}, 1)
+	id, _ := cron.AddFunc("@yearly", func() { ran <- struct{}{} })
+	cron.Start()
+	defer cron.Stop()
+
+	// Dispatch a run held back by a delay, as jitter or a rate limit would.
+	cron.do(func() {
+		cron.dispatch([]activation{{entry: cron.find(id), scheduled: cron.now(), delay: 200 * time.Millisecond}})
+	})
+	var progress []DrainProgress
+	ctx := cron.Drain(func(p DrainProgress) { progress = append(progress, p) })
+	<-ctx.Done()
+	select {
+	case <-ran:
+	default:
+		t.Error("expected the queued run to finish before the drain")
+	}
+	expected := []DrainProgress{{Queued: 1}, {Running: 1}, {}}
+	if len(progress) != len(expected) {
+		t.Fatalf("expected %+v, got %+v", expected, progress)
+	}
+	for i := range expected {
+		if progress[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected, progress)
+		}
+	}
+}
+
+func TestDrainIdle(t *testing.T) {
+	cron := New()
+	var progress []DrainProgress
+	ctx := cron.Drain(func(p DrainProgress) { progress = append(progress, p) })
+	select {
+	case <-ctx.Done():
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected an idle Cron to drain at once")
+	}
+	if len(progress) != 1 || !progress[0].Done() {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+	cron.Trigger(cron.Schedule(Every(time.Hour), FuncJob(func() {})))
+	<-cron.Stop().Done()
+	if len(progress) != 1 {
+		t.Errorf("expected no progress once drained, got %+v", progress)
+	}
+}
+
+func TestResumeAllDoesNotUndoDrain(t *testing.T) {
+	cron := New()
+	cron.Drain(nil)
+	cron.PauseAll()
+	cron.ResumeAll(SkipMissed)
+	if !cron.draining {
+		t.Error("expected the Cron to keep draining")
+	}
+}
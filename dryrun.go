@@ -0,0 +1,33 @@
+package cron
+
+// WithDryRun returns an Option that puts the Cron in dry-run mode: every
+// activation it would have dispatched is logged, handed to the batch hook and
+// recorded in the Store with OutcomeDryRun, but no job is run.  Activations
+// are not claimed, so a dry run may share a Store with a live Cron, for
+// instance to validate a new set of schedules in production.
+func WithDryRun() Option {
+	return func(c *Cron) {
+		c.dryRun = true
+	}
+}
+
+// dryDispatch logs and records the activations instead of running them.
+func (c *Cron) dryDispatch(batch []activation) {
+	for i := range batch {
+		batch[i].key = NewRunKey(batch[i].entry.ID, batch[i].scheduled)
+	}
+	c.batchStarted(batch)
+	for _, a := range batch {
+		run := Run{
+			Key:       a.key,
+			ID:        newRunID(),
+			EntryID:   a.entry.ID,
+			Scheduled: a.scheduled,
+			Outcome:   OutcomeDryRun,
+			Host:      c.host,
+		}
+		rlog := c.runLog(a.entry, run)
+		rlog.logf("cron: dry run: would run %s after %s", rlog.name, a.delay)
+		c.record(run)
+	}
+}
@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a bytes.Buffer safe for use as a Logger's output.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDryRun(t *testing.T) {
+	store := NewMemoryStore()
+	logs := &lockedBuffer{}
+	cron := New(WithStore(store), WithDryRun())
+	cron.ErrorLog = log.New(logs, "", 0)
+	id, _ := cron.AddFunc("* * * * * ?", func() { t.Error("expected the job not to run") })
+	cron.Start()
+	time.Sleep(ONE_SECOND)
+	<-cron.Stop().Done()
+
+	history, _ := cron.History(id, time.Time{}, 0)
+	if len(history) == 0 {
+		t.Fatal("expected the dry run to be recorded")
+	}
+	run := history[0]
+	if run.Outcome != OutcomeDryRun || !run.Start.IsZero() {
+		t.Errorf("expected a dry run, got %+v", run)
+	}
+	if !strings.Contains(logs.String(), "dry run: would run "+string(run.Key)) {
+		t.Errorf("expected the dry run to be logged, got %q", logs.String())
+	}
+	if ok, _ := store.Claim(run.Key); !ok {
+		t.Error("expected the dry run not to claim the activation")
+	}
+}
@@ -0,0 +1,169 @@
+// Package dsttest provides the daylight saving time transitions schedules
+// most often get wrong, and assertions for testing cron.Schedule
+// implementations across them, so that custom schedules get the same edge
+// cases covered as cron's own:
+//
+//	func TestBusinessHoursDST(t *testing.T) {
+//		dsttest.Run(t, func(t *testing.T, tr dsttest.Transition) {
+//			s := BusinessHours(tr.Location)
+//			dsttest.NextIsMonotonic(t, s, tr)
+//			dsttest.NextMatchesSpec(t, s, "0 0 9-17 * * MON-FRI", tr)
+//		})
+//	}
+//
+// Each assertion walks the activations of a schedule from a day before the
+// transition to a day after it, reporting the first problem it finds with
+// t.Errorf.
+package dsttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// Transition is a change of a location's offset from UTC.
+type Transition struct {
+	// Name describes the transition, as "America/New_York spring forward".
+	Name string
+
+	// Location is the location whose offset changes.
+	Location *time.Location
+
+	// At is the instant the new offset takes effect.
+	At time.Time
+
+	// Shift is the change in offset.  It is positive when clocks spring
+	// forward, skipping the wall-clock times in [At-Shift, At) in the old
+	// offset, and negative when they fall back, repeating the wall-clock
+	// times in [At+Shift, At) in the new offset.
+	Shift time.Duration
+}
+
+// Window returns the times the assertions walk activations between: a day
+// either side of the transition.
+func (tr Transition) Window() (from, to time.Time) {
+	return tr.At.Add(-24 * time.Hour).In(tr.Location), tr.At.Add(24 * time.Hour).In(tr.Location)
+}
+
+// transitions are the canonical transitions, by location and instant.
+var transitions = []struct {
+	location, name string
+	at             time.Time
+	shift          time.Duration
+}{
+	{"America/New_York", "spring forward", time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC), time.Hour},
+	{"America/New_York", "fall back", time.Date(2024, 11, 3, 6, 0, 0, 0, time.UTC), -time.Hour},
+	{"Europe/London", "spring forward", time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC), time.Hour},
+	{"Europe/London", "fall back", time.Date(2024, 10, 27, 1, 0, 0, 0, time.UTC), -time.Hour},
+	{"Australia/Lord_Howe", "spring forward", time.Date(2024, 10, 5, 15, 30, 0, 0, time.UTC), 30 * time.Minute},
+	{"Australia/Lord_Howe", "fall back", time.Date(2024, 4, 6, 15, 0, 0, 0, time.UTC), -30 * time.Minute},
+}
+
+// Transitions returns the canonical transitions: spring forward and fall
+// back in New York and London, and Lord Howe Island's half-hour shifts.
+// It skips the test if the time zone database lacks their locations, or
+// disagrees about when their offsets change.
+func Transitions(t testing.TB) []Transition {
+	t.Helper()
+	var trs []Transition
+	for _, tr := range transitions {
+		loc, err := time.LoadLocation(tr.location)
+		if err != nil {
+			t.Skipf("time zone database unavailable: %v", err)
+		}
+		_, before := tr.at.Add(-time.Second).In(loc).Zone()
+		_, after := tr.at.In(loc).Zone()
+		if time.Duration(after-before)*time.Second != tr.shift {
+			t.Skipf("time zone database has no %s %s at %v", tr.location, tr.name, tr.at)
+		}
+		trs = append(trs, Transition{
+			Name:     tr.location + " " + tr.name,
+			Location: loc,
+			At:       tr.at.In(loc),
+			Shift:    tr.shift,
+		})
+	}
+	return trs
+}
+
+// Run runs f as a subtest of t for each of the canonical transitions.
+func Run(t *testing.T, f func(t *testing.T, tr Transition)) {
+	t.Helper()
+	for _, tr := range Transitions(t) {
+		tr := tr
+		t.Run(tr.Name, func(t *testing.T) { f(t, tr) })
+	}
+}
+
+// NextIsMonotonic asserts that the activations of the schedule across the
+// transition each follow the time Next was called with, including when it
+// is called with a time within the shift, so that a schedule neither
+// stalls nor steps back when the wall clock does.
+func NextIsMonotonic(t testing.TB, schedule cron.Schedule, tr Transition) {
+	t.Helper()
+	from, to := tr.Window()
+	prev := from
+	for prev.Before(to) {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if !next.After(prev) {
+			t.Errorf("%s: expected the activation after %v to follow it, got %v", tr.Name, prev, next)
+			return
+		}
+		prev = next
+	}
+	shift := tr.Shift
+	if shift < 0 {
+		shift = -shift
+	}
+	for d := -shift; d <= shift; d += time.Minute {
+		at := tr.At.Add(d)
+		if next := schedule.Next(at); !next.IsZero() && !next.After(at) {
+			t.Errorf("%s: expected the activation after %v to follow it, got %v", tr.Name, at, next)
+			return
+		}
+	}
+}
+
+// NextMatchesSpec asserts that the activations of the schedule across the
+// transition are the activations of the spec, in the syntax of cron.Parse,
+// in the transition's location: those skipped when clocks spring forward
+// are skipped, and those repeated when they fall back are repeated.
+func NextMatchesSpec(t testing.TB, schedule cron.Schedule, spec string, tr Transition) {
+	t.Helper()
+	expected, err := cron.Parse(spec)
+	if err != nil {
+		t.Errorf("invalid spec %q: %v", spec, err)
+		return
+	}
+	if _, ok := expected.(cron.ConstantDelaySchedule); ok {
+		t.Errorf("spec %q has no fixed activations to match", spec)
+		return
+	}
+	Equivalent(t, schedule, expected, tr)
+}
+
+// Equivalent asserts that the schedules have the same activations across
+// the transition, as when checking a custom schedule against the
+// SpecSchedule it stands in for.
+func Equivalent(t testing.TB, a, b cron.Schedule, tr Transition) {
+	t.Helper()
+	from, to := tr.Window()
+	for prev := from; prev.Before(to); {
+		// Spec schedules activate in the zone of the time they are given.
+		prev = prev.In(tr.Location)
+		na, nb := a.Next(prev), b.Next(prev)
+		if !na.Equal(nb) {
+			t.Errorf("%s: expected the activations after %v to be the same, got %v and %v", tr.Name, prev, na, nb)
+			return
+		}
+		if na.IsZero() {
+			return
+		}
+		prev = na
+	}
+}
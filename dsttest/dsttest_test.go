@@ -0,0 +1,85 @@
+package dsttest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// recorder is a testing.TB recording the errors reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// scheduleFunc is a Schedule implemented by a function.
+type scheduleFunc func(time.Time) time.Time
+
+func (f scheduleFunc) Next(t time.Time) time.Time { return f(t) }
+
+func mustParse(spec string) cron.Schedule {
+	s, err := cron.Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func TestTransitions(t *testing.T) {
+	for _, tr := range Transitions(t) {
+		_, before := tr.At.Add(-time.Minute).Zone()
+		_, after := tr.At.Zone()
+		if tr.At.Location() != tr.Location || time.Duration(after-before)*time.Second != tr.Shift {
+			t.Errorf("%s: expected the offset to shift by %v at %v", tr.Name, tr.Shift, tr.At)
+		}
+	}
+}
+
+func TestSpecSchedules(t *testing.T) {
+	Run(t, func(t *testing.T, tr Transition) {
+		for _, spec := range []string{
+			"0 0 * * * *",
+			"0 0,30 * * * *",
+			"0 30 1 * * *",
+			"0 15 2 * * *",
+			"0 */5 1-3 * * *",
+		} {
+			s := mustParse(spec)
+			NextIsMonotonic(t, s, tr)
+			NextMatchesSpec(t, s, spec, tr)
+		}
+		NextIsMonotonic(t, cron.Every(20*time.Minute), tr)
+	})
+}
+
+func TestFailures(t *testing.T) {
+	tr := Transitions(t)[0]
+	// wallClock activates at the next whole hour of the wall clock, ignoring
+	// the transition, as a naive schedule might.
+	wallClock := scheduleFunc(func(t time.Time) time.Time {
+		t = t.In(tr.Location)
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC).Add(5 * time.Hour)
+	})
+	hourly := mustParse("@hourly")
+	for name, assert := range map[string]func(t testing.TB){
+		"monotonic":    func(t testing.TB) { NextIsMonotonic(t, scheduleFunc(func(t time.Time) time.Time { return t }), tr) },
+		"matches spec": func(t testing.TB) { NextMatchesSpec(t, wallClock, "@hourly", tr) },
+		"invalid spec": func(t testing.TB) { NextMatchesSpec(t, hourly, "x", tr) },
+		"every spec":   func(t testing.TB) { NextMatchesSpec(t, hourly, "@every 1h", tr) },
+		"equivalent":   func(t testing.TB) { Equivalent(t, hourly, mustParse("0 30 * * * *"), tr) },
+	} {
+		r := &recorder{TB: t}
+		assert(r)
+		if len(r.errors) != 1 {
+			t.Errorf("%s: expected one error, got %q", name, r.errors)
+		}
+	}
+}
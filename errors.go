@@ -0,0 +1,129 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The kinds of error the parsers and Validate return.  Each error they
+// return describes the problem with the spec in its message, and wraps one of
+// these, so that callers can tell the kinds apart with errors.Is rather than
+// by matching messages.
+var (
+	// ErrFieldCount is wrapped by errors for specs with the wrong number of
+	// fields, including empty specs.
+	ErrFieldCount = errors.New("cron: wrong number of fields")
+
+	// ErrSyntax is wrapped by errors for values, ranges, durations and time
+	// zones that cannot be parsed.
+	ErrSyntax = errors.New("cron: invalid syntax")
+
+	// ErrValueOutOfRange is wrapped by errors for values outside the bounds
+	// of their field, and ranges that end before they begin.
+	ErrValueOutOfRange = errors.New("cron: value out of range")
+
+	// ErrBadStep is wrapped by errors for steps that are not positive
+	// numbers.
+	ErrBadStep = errors.New("cron: invalid step")
+
+	// ErrUnknownDescriptor is wrapped by errors for descriptors other than
+	// those Parse accepts, such as "@daily" and "@every".
+	ErrUnknownDescriptor = errors.New("cron: unknown descriptor")
+
+	// ErrUnsatisfiableSpec is wrapped by the errors of Validate for
+	// schedules that never activate, such as "0 0 0 30 Feb *".
+	ErrUnsatisfiableSpec = errors.New("cron: spec never activates")
+)
+
+// specError is an error of one of the kinds above, with a message of its
+// own.
+type specError struct {
+	kind error
+	msg  string
+}
+
+func (e *specError) Error() string { return e.msg }
+
+func (e *specError) Unwrap() error { return e.kind }
+
+// specErrorf returns an error of the kind with a formatted message.
+func specErrorf(kind error, format string, args ...interface{}) error {
+	return &specError{kind, fmt.Sprintf(format, args...)}
+}
+
+// suggest returns the name closest to the misspelled token, in the case of
+// the token if that is all upper case, or "" if none is close.  A name is
+// close if the token begins with it, as "tues" does "tue", or is a typo of
+// it: at most one edit away, or two for names longer than three letters,
+// counting swapping adjacent letters as one edit.
+func suggest(token string, names []string) string {
+	lower := strings.ToLower(token)
+	best, bestDist := "", 0
+	for _, name := range names {
+		dist := editDistance(lower, name)
+		if strings.HasPrefix(lower, name) {
+			dist = 0
+		}
+		limit := 1
+		if len(name) > 3 {
+			limit = 2
+		}
+		if dist > limit || best != "" && (dist > bestDist || dist == bestDist && name > best) {
+			continue
+		}
+		best, bestDist = name, dist
+	}
+	if token == strings.ToUpper(token) {
+		best = strings.ToUpper(best)
+	}
+	return best
+}
+
+// withSuggestion returns err with a suggestion of the name closest to the
+// token appended to its message, or err itself if none is close.
+func withSuggestion(err error, token string, names []string) error {
+	s := suggest(token, names)
+	if s == "" {
+		return err
+	}
+	var kind error = ErrSyntax
+	if e, ok := err.(*specError); ok {
+		kind = e.kind
+	}
+	return specErrorf(kind, "%v (did you mean %s?)", err, s)
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the number of insertions, deletions, substitutions and swaps of
+// adjacent bytes that turn one into the other.
+func editDistance(a, b string) int {
+	// d[i][j] is the distance between a[:i] and b[:j].
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = d[i-1][j-1] + cost
+			if d[i-1][j]+1 < d[i][j] {
+				d[i][j] = d[i-1][j] + 1
+			}
+			if d[i][j-1]+1 < d[i][j] {
+				d[i][j] = d[i][j-1] + 1
+			}
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,133 @@
+package cron
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorKinds(t *testing.T) {
+	entries := []struct {
+		spec string
+		kind error
+		msg  string
+	}{
+		{"", ErrFieldCount, "found 0"},
+		{"* * * *", ErrFieldCount, "Expected 5 or 6 fields"},
+		{"x * * * *", ErrSyntax, "Failed to parse int"},
+		{"1-2-3 * * * *", ErrSyntax, "Too many hyphens"},
+		{"@every 5", ErrSyntax, "Failed to parse duration"},
+		{"60 * * * *", ErrValueOutOfRange, "above maximum"},
+		{"0 * * 0 * *", ErrValueOutOfRange, "below minimum"},
+		{"5-3 * * * *", ErrValueOutOfRange, "beyond end of range"},
+		{"*/0 * * * *", ErrBadStep, "should be a positive number"},
+		{"*/x * * * *", ErrBadStep, "Failed to parse int"},
+		{"1/2/3 * * * *", ErrBadStep, "Too many slashes"},
+		{"@fortnightly", ErrUnknownDescriptor, "Unrecognized descriptor"},
+	}
+	for _, c := range entries {
+		_, err := Parse(c.spec)
+		if !errors.Is(err, c.kind) {
+			t.Errorf("%q: expected an error wrapping %v, got %v", c.spec, c.kind, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), c.msg) {
+			t.Errorf("%q: expected %q in the error, got %v", c.spec, c.msg, err)
+		}
+	}
+}
+
+func TestRobfigErrorKinds(t *testing.T) {
+	entries := []struct {
+		spec string
+		kind error
+	}{
+		{"TZ=UTC", ErrFieldCount},
+		{"TZ=UTC  ", ErrFieldCount},
+		{"TZ=Nowhere/Else * * * * *", ErrSyntax},
+		{"60 * * * *", ErrValueOutOfRange},
+	}
+	for _, c := range entries {
+		if _, err := ParseRobfig(c.spec); !errors.Is(err, c.kind) {
+			t.Errorf("%q: expected an error wrapping %v, got %v", c.spec, c.kind, err)
+		}
+	}
+}
+
+func TestEventBridgeErrorKinds(t *testing.T) {
+	entries := []struct {
+		expr string
+		kind error
+	}{
+		{"cron(0 12 * * ?)", ErrFieldCount},
+		{"cron(0 12 * * * *)", ErrSyntax},
+		{"cron(0 12 32W * ? *)", ErrValueOutOfRange},
+		{"cron(0 12 ? * 2#6 *)", ErrValueOutOfRange},
+		{"cron(0 12 * * ? 2020/0)", ErrBadStep},
+		{"cron(0 12 * * ? 1900)", ErrValueOutOfRange},
+	}
+	for _, c := range entries {
+		if _, err := ParseEventBridge(c.expr); !errors.Is(err, c.kind) {
+			t.Errorf("%q: expected an error wrapping %v, got %v", c.expr, c.kind, err)
+		}
+	}
+}
+
+func TestParseErrorSuggestions(t *testing.T) {
+	entries := []struct {
+		spec       string
+		suggestion string
+	}{
+		{"0 0 * * TUES", "did you mean TUE?"},
+		{"0 0 * * thurs-fri", "did you mean thu?"},
+		{"0 0 * * mno", "did you mean mon?"},
+		{"0 0 1 Janaury *", "did you mean jan?"},
+		{"@dialy", "did you mean @daily?"},
+		{"@anually", "did you mean @annually?"},
+		{"0 0 * * x", ""},
+		{"0 0 * * 7x", ""},
+		{"@fortnightly", ""},
+	}
+	for _, c := range entries {
+		_, err := ParseStandard(c.spec)
+		if err == nil {
+			t.Errorf("%q: expected an error", c.spec)
+			continue
+		}
+		if c.suggestion == "" {
+			if strings.Contains(err.Error(), "did you mean") {
+				t.Errorf("%q: expected no suggestion, got %v", c.spec, err)
+			}
+		} else if !strings.Contains(err.Error(), c.suggestion) {
+			t.Errorf("%q: expected %q in the error, got %v", c.spec, c.suggestion, err)
+		}
+	}
+
+	// Suggestions keep the kind of the error.
+	if _, err := Parse("@dialy"); !errors.Is(err, ErrUnknownDescriptor) {
+		t.Errorf("expected an error wrapping %v, got %v", ErrUnknownDescriptor, err)
+	}
+	if _, err := ParseEventBridge("cron(0 0 ? * TUES#2 *)"); !errors.Is(err, ErrSyntax) || !strings.Contains(err.Error(), "did you mean TUE?") {
+		t.Errorf("expected a suggestion of TUE, got %v", err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	entries := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"tue", "tue", 0},
+		{"tues", "tue", 1},
+		{"teu", "tue", 1},
+		{"mno", "mon", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, c := range entries {
+		if actual := editDistance(c.a, c.b); actual != c.expected {
+			t.Errorf("editDistance(%q, %q): expected %d, got %d", c.a, c.b, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The bounds of the EventBridge fields that differ from this package's.
+var (
+	ebDow = bounds{1, 7, map[string]uint{
+		"sun": 1,
+		"mon": 2,
+		"tue": 3,
+		"wed": 4,
+		"thu": 5,
+		"fri": 6,
+		"sat": 7,
+	}}
+	ebYears = bounds{1970, 2199, nil}
+)
+
+// EventBridgeSchedule is a schedule in the cron() syntax of AWS EventBridge,
+// see ParseEventBridge.  It activates on whole minutes.
+type EventBridgeSchedule struct {
+	// Minute, Hour and Month are bit sets, as in SpecSchedule.
+	Minute, Hour, Month uint64
+
+	// Dom is the bit set of days of the month, used unless LastDom or
+	// NearestWeekday is set.  It is zero if the day of the week is given
+	// instead.
+	Dom uint64
+
+	// LastDom selects the last day of the month ("L").
+	LastDom bool
+
+	// NearestWeekday, if not zero, selects the weekday nearest to that day
+	// of the month, within the month ("15W").
+	NearestWeekday int
+
+	// Dow is the bit set of days of the week, Sunday being bit 0 as in
+	// SpecSchedule, used unless Nth or LastWeekday is set.  It is zero if
+	// the day of the month is given instead.
+	Dow uint64
+
+	// Weekday with Nth selects the Nth such weekday of the month ("6#3"),
+	// and with LastWeekday the last one ("6L").
+	Weekday     time.Weekday
+	Nth         int
+	LastWeekday bool
+
+	// Years lists the years the schedule activates in, in order, or is nil
+	// for every year.
+	Years []int
+}
+
+// ParseEventBridge returns the schedule for an AWS EventBridge cron
+// expression, such as "cron(0 12 ? * MON-FRI *)".  The cron( ) wrapper is
+// optional.  The expression has six fields: minutes, hours, day of month,
+// month, day of week and year.  Days of the week are numbered from 1 for
+// Sunday, and one of the day fields must be "?".  Beyond the syntax of
+// Parse, the day of month may be "L" or "<day>W", and the day of week
+// "<day>L" or "<day>#<n>".
+func ParseEventBridge(expr string) (*EventBridgeSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "cron(") && strings.HasSuffix(spec, ")") {
+		spec = spec[len("cron(") : len(spec)-1]
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 6 {
+		return nil, specErrorf(ErrFieldCount, "Expected exactly 6 fields, found %d: %s", len(fields), expr)
+	}
+	if (fields[2] == "?") == (fields[4] == "?") {
+		return nil, specErrorf(ErrSyntax, "Exactly one of day of month and day of week must be ?: %s", expr)
+	}
+
+	s := &EventBridgeSchedule{}
+	var err error
+	if s.Minute, err = getField(fields[0], minutes); err != nil {
+		return nil, err
+	}
+	if s.Hour, err = getField(fields[1], hours); err != nil {
+		return nil, err
+	}
+	if s.Month, err = getField(fields[3], months); err != nil {
+		return nil, err
+	}
+	if fields[2] != "?" {
+		if err = s.parseDom(fields[2]); err != nil {
+			return nil, err
+		}
+	} else if err = s.parseDow(fields[4]); err != nil {
+		return nil, err
+	}
+	if s.Years, err = parseYears(fields[5]); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// parseDom parses the day of month field.
+func (s *EventBridgeSchedule) parseDom(field string) error {
+	switch {
+	case field == "L":
+		s.LastDom = true
+	case strings.HasSuffix(field, "W"):
+		day, err := mustParseInt(strings.TrimSuffix(field, "W"))
+		if err != nil {
+			return err
+		}
+		if day < dom.min || day > dom.max {
+			return specErrorf(ErrValueOutOfRange, "Day of month (%d) out of range: %s", day, field)
+		}
+		s.NearestWeekday = int(day)
+	default:
+		var err error
+		s.Dom, err = getField(field, dom)
+		return err
+	}
+	return nil
+}
+
+// parseDow parses the day of week field, converting its days to Weekdays.
+func (s *EventBridgeSchedule) parseDow(field string) error {
+	weekday := func(expr string) error {
+		day, err := parseIntOrName(expr, ebDow.names)
+		if err != nil {
+			return err
+		}
+		if day < ebDow.min || day > ebDow.max {
+			return specErrorf(ErrValueOutOfRange, "Day of week (%d) out of range: %s", day, field)
+		}
+		s.Weekday = time.Weekday(day - 1)
+		return nil
+	}
+	if i := strings.Index(field, "#"); i >= 0 {
+		n, err := mustParseInt(field[i+1:])
+		if err != nil {
+			return err
+		}
+		if n < 1 || n > 5 {
+			return specErrorf(ErrValueOutOfRange, "Occurrence (%d) out of range: %s", n, field)
+		}
+		s.Nth = int(n)
+		return weekday(field[:i])
+	}
+	if strings.HasSuffix(field, "L") && len(field) > 1 {
+		s.LastWeekday = true
+		return weekday(strings.TrimSuffix(field, "L"))
+	}
+	bits, err := getField(field, ebDow)
+	if err != nil {
+		return err
+	}
+	s.Dow = (bits &^ starBit) >> 1
+	return nil
+}
+
+// parseYears parses the year field, returning nil for every year.
+func parseYears(field string) ([]int, error) {
+	if field == "*" {
+		return nil, nil
+	}
+	set := map[int]bool{}
+	for _, expr := range strings.Split(field, ",") {
+		start, end, step, _, err := parseRange(expr, ebYears)
+		if err != nil {
+			return nil, err
+		}
+		for y := start; y <= end; y += step {
+			set[int(y)] = true
+		}
+	}
+	years := make([]int, 0, len(set))
+	for y := range set {
+		years = append(years, y)
+	}
+	sort.Ints(years)
+	return years, nil
+}
+
+// Next returns the next time this schedule is activated, greater than the
+// given time, or the zero time if there is none.
+func (s *EventBridgeSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	// Without a year field, give up after five years, as SpecSchedule does.
+	yearLimit := t.Year() + 5
+	if len(s.Years) > 0 {
+		yearLimit = s.Years[len(s.Years)-1]
+	}
+	for day.Year() <= yearLimit {
+		if !s.yearMatches(day.Year()) {
+			day = time.Date(day.Year()+1, time.January, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if 1<<uint(day.Month())&s.Month == 0 {
+			day = time.Date(day.Year(), day.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.dayMatches(day) {
+			if next := s.timeIn(day, t); !next.IsZero() {
+				return next
+			}
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+	}
+	return time.Time{}
+}
+
+func (s *EventBridgeSchedule) yearMatches(year int) bool {
+	if s.Years == nil {
+		return true
+	}
+	i := sort.SearchInts(s.Years, year)
+	return i < len(s.Years) && s.Years[i] == year
+}
+
+func (s *EventBridgeSchedule) dayMatches(day time.Time) bool {
+	last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+	switch {
+	case s.LastDom:
+		return day.Day() == last
+	case s.NearestWeekday > 0:
+		return day.Day() == nearestWeekday(day, s.NearestWeekday, last)
+	case s.Nth > 0:
+		return day.Weekday() == s.Weekday && (day.Day()-1)/7+1 == s.Nth
+	case s.LastWeekday:
+		return day.Weekday() == s.Weekday && day.Day()+7 > last
+	case s.Dom != 0:
+		return 1<<uint(day.Day())&s.Dom > 0
+	}
+	return 1<<uint(day.Weekday())&s.Dow > 0
+}
+
+// nearestWeekday returns the weekday of the month of day nearest to the
+// given day of the month, in a month of last days.
+func nearestWeekday(day time.Time, target, last int) int {
+	if target > last {
+		target = last
+	}
+	switch time.Date(day.Year(), day.Month(), target, 0, 0, 0, 0, day.Location()).Weekday() {
+	case time.Saturday:
+		if target == 1 {
+			return 3
+		}
+		return target - 1
+	case time.Sunday:
+		if target == last {
+			return target - 2
+		}
+		return target + 1
+	}
+	return target
+}
+
+// timeIn returns the first activation on the given day not before t, or the
+// zero time if there is none.
+func (s *EventBridgeSchedule) timeIn(day, t time.Time) time.Time {
+	for h := 0; h < 24; h++ {
+		if 1<<uint(h)&s.Hour == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if 1<<uint(m)&s.Minute == 0 {
+				continue
+			}
+			next := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location())
+			if !next.Before(t) && next.Day() == day.Day() {
+				return next
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// ExportEventBridge renders the schedule as an AWS EventBridge cron
+// expression, or returns an error if EventBridge cannot represent it: it
+// must activate at second 0 only, and restrict at most one of the day of
+// month and day of week, as EventBridge cannot run on either.
+func ExportEventBridge(s *SpecSchedule) (string, error) {
+	if s.Second&^starBit != 1<<seconds.min {
+		return "", fmt.Errorf("cron: EventBridge cannot activate on seconds other than 0")
+	}
+	days, err := formatQuartzDays(s, "EventBridge")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("cron(%s %s %s *)",
+		formatField(s.Minute, minutes, 0), formatField(s.Hour, hours, 0), days), nil
+}
+
+// formatField renders a field's bit set as a comma-separated list of values
+// and ranges, adding offset to each value, or as "*" if it holds every value
+// within the bounds.
+func formatField(bits uint64, r bounds, offset int) string {
+	return formatList(bits, r, "-", func(v uint) string { return strconv.Itoa(int(v) + offset) })
+}
+
+// formatList renders a field's bit set as a comma-separated list of values
+// and ranges, naming each value with name and joining the ends of ranges with
+// to, or as "*" if it holds every value within the bounds.
+func formatList(bits uint64, r bounds, to string, name func(uint) string) string {
+	if isAll(bits, r) {
+		return "*"
+	}
+	var items []string
+	for v := r.min; v <= r.max; v++ {
+		if 1<<v&bits == 0 {
+			continue
+		}
+		end := v
+		for end < r.max && 1<<(end+1)&bits != 0 {
+			end++
+		}
+		item := name(v)
+		if end > v {
+			item += to + name(end)
+		}
+		items = append(items, item)
+		v = end
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEventBridge(t *testing.T) {
+	for _, test := range []struct {
+		expr, from, expected string
+	}{
+		// Weekdays at noon.
+		{"cron(0 12 ? * MON-FRI *)", "Fri Jan 5 12:00 2024", "Mon Jan 8 12:00 2024"},
+		{"0 12 ? * 2-6 *", "Sat Jan 6 08:00 2024", "Mon Jan 8 12:00 2024"},
+		// Every 15 minutes.
+		{"cron(0/15 * * * ? *)", "Mon Jan 1 12:07 2024", "Mon Jan 1 12:15 2024"},
+		// Last day of the month.
+		{"cron(30 23 L * ? *)", "Thu Feb 1 00:00 2024", "Thu Feb 29 23:30 2024"},
+		// Weekday nearest the 1st: Saturday 1st June moves to Monday 3rd.
+		{"cron(0 9 1W * ? *)", "Fri May 31 00:00 2024", "Mon Jun 3 09:00 2024"},
+		// Weekday nearest the 30th: Sunday 30th June moves to Friday 28th.
+		{"cron(0 9 30W * ? *)", "Mon Jun 10 00:00 2024", "Fri Jun 28 09:00 2024"},
+		// Third Friday.
+		{"cron(0 10 ? * 6#3 *)", "Mon Jan 1 00:00 2024", "Fri Jan 19 10:00 2024"},
+		// Last Friday.
+		{"cron(0 10 ? * FRIL *)", "Mon Jan 1 00:00 2024", "Fri Jan 26 10:00 2024"},
+		// Restricted years.
+		{"cron(0 0 1 1 ? 2026,2030)", "Mon Jan 1 00:00 2024", "Thu Jan 1 00:00 2026"},
+		{"cron(0 0 1 1 ? 2026,2030)", "Thu Jan 1 00:00 2026", "Tue Jan 1 00:00 2030"},
+		{"cron(0 0 1 1 ? 2020-2023)", "Mon Jan 1 00:00 2024", ""},
+	} {
+		s, err := ParseEventBridge(test.expr)
+		if err != nil {
+			t.Errorf("%s: %v", test.expr, err)
+			continue
+		}
+		next := s.Next(getTime(test.from))
+		if expected := getTime(test.expected); !next.Equal(expected) {
+			t.Errorf("%s from %s: expected %v, got %v", test.expr, test.from, expected, next)
+		}
+	}
+}
+
+func TestParseEventBridgeErrors(t *testing.T) {
+	for _, expr := range []string{
+		"cron(0 12 * * MON-FRI *)", // neither day field is ?
+		"cron(0 12 ? * ? *)",       // both are
+		"cron(0 12 * * ?)",         // no year
+		"cron(0 12 ? * 8 *)",       // day of week out of range
+		"cron(0 12 ? * 2#6 *)",     // no sixth Monday
+		"cron(0 12 32W * ? *)",
+		"cron(0 12 * * ? 1969)",
+	} {
+		if _, err := ParseEventBridge(expr); err == nil {
+			t.Errorf("%s: expected an error", expr)
+		}
+	}
+}
+
+func TestExportEventBridge(t *testing.T) {
+	for spec, expected := range map[string]string{
+		"0 0 12 * * MON-FRI": "cron(0 12 ? * 2-6 *)",
+		"0 */15 * * * ?":     "cron(0,15,30,45 * * * ? *)",
+		"0 30 9 1,15 * ?":    "cron(30 9 1,15 * ? *)",
+		"@monthly":           "cron(0 0 1 * ? *)",
+	} {
+		schedule, _ := Parse(spec)
+		expr, err := ExportEventBridge(schedule.(*SpecSchedule))
+		if err != nil || expr != expected {
+			t.Errorf("%s: expected %s, got %s, %v", spec, expected, expr, err)
+			continue
+		}
+		// The exported expression activates at the same times.
+		s, err := ParseEventBridge(expr)
+		if err != nil {
+			t.Errorf("%s: %v", expr, err)
+			continue
+		}
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 10; i++ {
+			next, ebNext := schedule.Next(from), s.Next(from)
+			if !next.Equal(ebNext) {
+				t.Errorf("%s: expected %v, got %v", expr, next, ebNext)
+				break
+			}
+			from = next
+		}
+	}
+
+	for _, spec := range []string{"30 0 12 * * ?", "0 0 12 1 * MON"} {
+		schedule, _ := Parse(spec)
+		if _, err := ExportEventBridge(schedule.(*SpecSchedule)); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of an Event.
+type EventType int
+
+const (
+	// EntryAdded is sent when an entry is added to the Cron.
+	EntryAdded EventType = iota
+
+	// EntryRemoved is sent when an entry is removed from the Cron, whether
+	// by Remove or because it expired.
+	EntryRemoved
+
+	// RunStarted is sent when a job starts running.
+	RunStarted
+
+	// RunFinished is sent when a job has finished, with the record of its
+	// run.
+	RunFinished
+
+	// RunSkipped is sent when an activation is skipped, with the record of
+	// the skipped run.
+	RunSkipped
+
+	// SchedulerStopped is sent when the scheduler is stopped.
+	SchedulerStopped
+
+	// BacklogFull is sent when a run is dispatched while the backlog of runs
+	// waiting for a worker is full, with the record of that run; see
+	// WithBackpressure.
+	BacklogFull
+
+	// EntryRescheduled is sent when the next activation of an entry changes
+	// because the Cron's time zone was changed, see SetLocation.
+	EntryRescheduled
+
+	// SLABreached is sent when a run breaches its entry's SLA, with the
+	// record of the run and the breach; see WithSLA.
+	SLABreached
+
+	// BudgetExhausted is sent when a run finishes having exhausted its
+	// entry's runtime budget for the window, with the record of the run;
+	// see WithBudget.
+	BudgetExhausted
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EntryAdded:
+		return "entry added"
+	case EntryRemoved:
+		return "entry removed"
+	case RunStarted:
+		return "run started"
+	case RunFinished:
+		return "run finished"
+	case RunSkipped:
+		return "run skipped"
+	case SchedulerStopped:
+		return "scheduler stopped"
+	case BacklogFull:
+		return "backlog full"
+	case EntryRescheduled:
+		return "entry rescheduled"
+	case SLABreached:
+		return "SLA breached"
+	case BudgetExhausted:
+		return "budget exhausted"
+	}
+	return "unknown"
+}
+
+// Event is a change in the state of a Cron, see Subscribe.
+type Event struct {
+	Type EventType
+
+	// Time is when the event happened.
+	Time time.Time
+
+	// EntryID is the entry the event is about, or 0 for SchedulerStopped.
+	EntryID EntryID
+
+	// Namespace is the namespace of the entry, see Cron.Namespace.
+	Namespace string
+
+	// Run is the run the event is about, for the run events.  For
+	// RunStarted, its End and Outcome are not yet set.
+	Run *Run
+
+	// Breach is the breach of the entry's SLA, for SLABreached.
+	Breach *SLABreach
+}
+
+// Subscribe returns a channel on which the Cron sends its events, buffered
+// to hold the given number of them, and a function that cancels the
+// subscription and closes the channel.  Events are sent without blocking:
+// a subscriber that falls more than the buffer behind misses events.
+func (c *Cron) Subscribe(buffer int) (<-chan Event, func()) {
+	return c.events.subscribe(buffer)
+}
+
+// eventBus fans events out to subscribers.  The zero value has none.
+type eventBus struct {
+	mu   sync.Mutex
+	subs map[chan Event]func(Event) bool
+}
+
+func (b *eventBus) subscribe(buffer int) (<-chan Event, func()) {
+	return b.subscribeFunc(buffer, nil)
+}
+
+// subscribeFunc subscribes to the events for which filter returns true, or
+// to all of them if filter is nil.
+func (b *eventBus) subscribeFunc(buffer int, filter func(Event) bool) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = make(map[chan Event]func(Event) bool)
+	}
+	b.subs[ch] = filter
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs, ch)
+			close(ch)
+		})
+	}
+}
+
+func (b *eventBus) send(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch, filter := range b.subs {
+		if filter != nil && !filter(event) {
+			continue
+		}
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// emit sends an event about the given entry, in the given namespace, and run
+// to the subscribers.
+func (c *Cron) emit(t EventType, namespace string, id EntryID, run *Run) {
+	c.events.send(Event{Type: t, Time: c.now(), EntryID: id, Namespace: namespace, Run: run})
+}
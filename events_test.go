@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// expectEvent waits for the next event of the given type, skipping others.
+func expectEvent(t *testing.T, events <-chan Event, typ EventType) Event {
+	timeout := time.After(2 * ONE_SECOND)
+	for {
+		select {
+		case event := <-events:
+			if event.Type == typ {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("expected a %s event", typ)
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	cron := New()
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+
+	id, _ := cron.AddFunc("* * * * * ?", func() {})
+	if event := expectEvent(t, events, EntryAdded); event.EntryID != id {
+		t.Errorf("expected entry %d added, got %d", id, event.EntryID)
+	}
+	cron.Start()
+
+	started := expectEvent(t, events, RunStarted)
+	if started.EntryID != id || started.Run == nil || started.Run.Start.IsZero() {
+		t.Errorf("unexpected run started event %+v", started)
+	}
+	finished := expectEvent(t, events, RunFinished)
+	if finished.Run.Key != started.Run.Key || finished.Run.Outcome != OutcomeSuccess {
+		t.Errorf("unexpected run finished event %+v", finished.Run)
+	}
+
+	cron.Remove(id)
+	if event := expectEvent(t, events, EntryRemoved); event.EntryID != id {
+		t.Errorf("expected entry %d removed, got %d", id, event.EntryID)
+	}
+	cron.Stop()
+	expectEvent(t, events, SchedulerStopped)
+}
+
+func TestRunSkippedEvent(t *testing.T) {
+	cron := New(WithRateLimit(RateLimit{Rate: 0.001, Burst: 1, Overflow: Skip}))
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+	cron.AddFunc("* * * * * ?", func() {})
+	cron.AddFunc("* * * * * ?", func() {})
+	cron.Start()
+	defer cron.Stop()
+
+	if event := expectEvent(t, events, RunSkipped); event.Run.Outcome != OutcomeSkipped {
+		t.Errorf("unexpected skipped run %+v", event.Run)
+	}
+}
+
+func TestCancelSubscription(t *testing.T) {
+	cron := New()
+	events, cancel := cron.Subscribe(0)
+	cancel()
+	cancel()
+	cron.AddFunc("@hourly", func() {})
+	if _, ok := <-events; ok {
+		t.Error("expected the channel closed")
+	}
+}
@@ -0,0 +1,45 @@
+package cron
+
+import "context"
+
+// Executor runs the jobs of dispatched activations.  Implementations may run
+// them in-process, as LocalExecutor does, or hand them to an external worker
+// fleet or a FaaS platform.
+//
+// Execute is called in the run's own goroutine, with a snapshot of the entry
+// taken when it was dispatched and the run's record, whose Start is set.  The
+// context carries the run's key and ID.  The run finishes when Execute
+// returns; a non-nil error marks it as failed, and a panic is recovered and
+// recorded as with an in-process job.
+type Executor interface {
+	Execute(ctx context.Context, entry *Entry, run Run) error
+}
+
+// ExecutorFunc is an adapter to use a func as an Executor.
+type ExecutorFunc func(ctx context.Context, entry *Entry, run Run) error
+
+// Execute calls f.
+func (f ExecutorFunc) Execute(ctx context.Context, entry *Entry, run Run) error {
+	return f(ctx, entry, run)
+}
+
+// LocalExecutor runs jobs in the calling goroutine.  It is the default
+// Executor.
+type LocalExecutor struct{}
+
+// Execute runs the entry's job, with the context if it is a ContextJob.
+func (LocalExecutor) Execute(ctx context.Context, entry *Entry, run Run) error {
+	if cj, ok := entry.Job.(ContextJob); ok {
+		return cj.RunContext(ctx)
+	}
+	entry.Job.Run()
+	return nil
+}
+
+// WithExecutor returns an Option that runs every job with the given
+// Executor instead of in-process.  Runs started with Trigger use it too.
+func WithExecutor(x Executor) Option {
+	return func(c *Cron) {
+		c.executor = x
+	}
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestExecutor(t *testing.T) {
+	type execution struct {
+		name  string
+		run   Run
+		runID string
+	}
+	executed := make(chan execution, 1)
+	cron := New(WithExecutor(ExecutorFunc(func(ctx context.Context, e *Entry, run Run) error {
+		id, _ := RunIDFromContext(ctx)
+		executed <- execution{e.Name, run, id}
+		return nil
+	})))
+	ran := false
+	id, _ := cron.AddFunc("@yearly", func() { ran = true }, WithName("remote"))
+
+	cron.Trigger(id)
+	x := <-executed
+	<-cron.Stop().Done()
+	if ran {
+		t.Error("expected the executor to run the job instead of the Cron")
+	}
+	if x.name != "remote" || x.run.EntryID != id || x.run.Start.IsZero() || x.runID != x.run.ID {
+		t.Errorf("unexpected execution %+v", x)
+	}
+}
+
+func TestExecutorOutcomes(t *testing.T) {
+	store := NewMemoryStore()
+	cron := New(WithStore(store), WithExecutor(ExecutorFunc(func(ctx context.Context, e *Entry, run Run) error {
+		switch e.Name {
+		case "failure":
+			return errors.New("unreachable")
+		case "panic":
+			panic("boom")
+		}
+		return nil
+	})))
+	cron.ErrorLog = log.New(ioutil.Discard, "", 0)
+
+	for name, outcome := range map[string]Outcome{
+		"success": OutcomeSuccess,
+		"failure": OutcomeFailure,
+		"panic":   OutcomePanic,
+	} {
+		id, _ := cron.AddFunc("@yearly", func() {}, WithName(name))
+		cron.Trigger(id)
+		<-cron.Stop().Done()
+		history, _ := cron.History(id, time.Time{}, 0)
+		if len(history) != 1 || history[0].Outcome != outcome {
+			t.Errorf("%s: expected outcome %s, got %+v", name, outcome, history)
+		}
+	}
+}
+
+func TestLocalExecutor(t *testing.T) {
+	var key RunKey
+	job := ContextFuncJob(func(ctx context.Context) error {
+		key, _ = RunKeyFromContext(ctx)
+		return errors.New("failed")
+	})
+	ctx := context.WithValue(context.Background(), runKeyContextKey, RunKey("1@0"))
+	if err := (LocalExecutor{}).Execute(ctx, &Entry{Job: job}, Run{}); err == nil || key != "1@0" {
+		t.Errorf("expected the context job to run and fail, got %v and key %q", err, key)
+	}
+}
@@ -0,0 +1,42 @@
+package cron
+
+import "time"
+
+// ValidFrom returns an EntryOption that prevents the entry from being
+// activated before t.
+func ValidFrom(t time.Time) EntryOption {
+	return func(e *Entry) {
+		e.ValidFrom = t
+	}
+}
+
+// ValidUntil returns an EntryOption that prevents the entry from being
+// activated after t.  Once the entry has no activation left, its Next time
+// is zero; if remove is true, it is also removed from the Cron.
+func ValidUntil(t time.Time, remove bool) EntryOption {
+	return func(e *Entry) {
+		e.ValidUntil = t
+		e.RemoveWhenExpired = remove
+	}
+}
+
+// MaxRuns returns an EntryOption that expires the entry after it has been
+// activated n times.  If remove is true, it is then removed from the Cron.
+func MaxRuns(n int, remove bool) EntryOption {
+	return func(e *Entry) {
+		e.MaxRuns = n
+		e.RemoveWhenExpired = remove
+	}
+}
+
+// RemainingRuns returns the number of activations the entry has left under
+// its MaxRuns, or -1 if it is unlimited.
+func (e *Entry) RemainingRuns() int {
+	if e.MaxRuns <= 0 {
+		return -1
+	}
+	if e.Runs >= e.MaxRuns {
+		return 0
+	}
+	return e.MaxRuns - e.Runs
+}
@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidFrom(t *testing.T) {
+	from := time.Now().Add(time.Hour).Truncate(time.Second)
+	cron := New()
+	id, _ := cron.AddFunc("* * * * * ?", func() {}, ValidFrom(from))
+	cron.Start()
+	defer cron.Stop()
+
+	if next := cron.Entry(id).Next; !next.Equal(from) {
+		t.Errorf("expected first activation at %v, got %v", from, next)
+	}
+}
+
+// An entry stops running once past ValidUntil.
+func TestValidUntil(t *testing.T) {
+	ran := make(chan struct{}, 10)
+
+	until := time.Now().Add(2 * time.Second).Truncate(time.Second)
+	cron := New()
+	id, _ := cron.AddFunc("* * * * * ?", func() { ran <- struct{}{} }, ValidUntil(until, false))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(ONE_SECOND):
+		t.FailNow()
+	case <-ran:
+	}
+
+	time.Sleep(2 * time.Second)
+	entry := cron.Entry(id)
+	if entry == nil || !entry.Next.IsZero() || entry.Prev.After(until) {
+		t.Errorf("expected entry to be expired: %+v", entry)
+	}
+}
+
+func TestValidUntilRemove(t *testing.T) {
+	cron := New()
+	cron.AddFunc("0 0 0 1 1 ?", func() {}, ValidUntil(time.Now().Add(time.Hour), true))
+	cron.AddFunc("* * * * * ?", func() {})
+	cron.Start()
+	defer cron.Stop()
+
+	if entries := cron.Entries(); len(entries) != 1 {
+		t.Errorf("expected the expired entry to be removed, got %v", entries)
+	}
+}
+
+// An entry with MaxRuns stops after that many activations.
+func TestMaxRuns(t *testing.T) {
+	ran := make(chan struct{}, 10)
+
+	cron := New()
+	id, _ := cron.AddFunc("* * * * * ?", func() { ran <- struct{}{} }, MaxRuns(2, false))
+	if remaining := cron.Entry(id).RemainingRuns(); remaining != 2 {
+		t.Errorf("expected 2 remaining runs, got %d", remaining)
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	time.Sleep(3 * ONE_SECOND)
+	if len(ran) != 2 {
+		t.Errorf("expected 2 runs, got %d", len(ran))
+	}
+	entry := cron.Entry(id)
+	if entry.RemainingRuns() != 0 || !entry.Next.IsZero() {
+		t.Errorf("expected entry to be exhausted: %+v", entry)
+	}
+}
+
+func TestMaxRunsRemove(t *testing.T) {
+	cron := New()
+	cron.AddFunc("* * * * * ?", func() {}, MaxRuns(1, true))
+	cron.Start()
+	defer cron.Stop()
+
+	time.Sleep(ONE_SECOND)
+	if entries := cron.Entries(); len(entries) != 0 {
+		t.Errorf("expected the exhausted entry to be removed, got %v", entries)
+	}
+}
+
+func TestRemainingRunsUnlimited(t *testing.T) {
+	if remaining := (&Entry{}).RemainingRuns(); remaining != -1 {
+		t.Errorf("expected -1, got %d", remaining)
+	}
+}
@@ -0,0 +1,256 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Field is an extra field of a spec, such as a "fortnight" or shift-number
+// field, that a FieldRegistry parses alongside the standard ones.
+type Field struct {
+	// Name identifies the field in the registry.
+	Name string
+
+	// Min and Max bound the field's values.  Max may be at most 62.
+	Min, Max uint
+
+	// Names maps lower-case names to values, as "mon" is mapped to 1 in
+	// the day of week field.  It may be nil.
+	Names map[string]uint
+
+	// Position is the index of the field in a spec, counting from 0 for the
+	// seconds field, so that an extra field at position 6 follows the day
+	// of week.  The standard fields after it move up by one.
+	Position int
+
+	// Value returns the field's value at a time, such as the number of the
+	// fortnight of the year.
+	Value func(t time.Time) uint
+}
+
+// FieldRegistry parses specs extended with extra fields and aliases, so that
+// applications can extend the spec format without forking the parser.  A
+// spec it parses has the six standard fields of Parse, seconds first, with
+// the extra fields among them at their positions, and activates at the
+// activations of the standard fields at which each extra field's value is
+// one of those it lists.  An extra field listing every value, as a star
+// does, matches any time.
+//
+// The extra fields are evaluated within the search of Next, see
+// PredicateSchedule.  Without extra fields, specs may leave out the day of
+// week, as for Parse.  Descriptors are parsed as Parse parses them.
+//
+// A FieldRegistry is safe for concurrent use, but fields and aliases should
+// be registered before specs are parsed with it, as a ParseCache keeps the
+// schedules it parsed before.
+type FieldRegistry struct {
+	mu      sync.RWMutex
+	fields  []Field                      // ordered by position
+	aliases map[string]map[string]string // by field, then lower-case name
+}
+
+// standardFields are the names and bounds of the standard fields, in order,
+// for registering aliases.
+var standardFields = [...]struct {
+	name string
+	r    bounds
+}{
+	{"second", seconds},
+	{"minute", minutes},
+	{"hour", hours},
+	{"dom", dom},
+	{"month", months},
+	{"dow", dow},
+}
+
+// NewFieldRegistry returns a FieldRegistry with no extra fields.
+func NewFieldRegistry() *FieldRegistry {
+	return &FieldRegistry{}
+}
+
+// Register adds the field to the registry.  It returns an error if the field
+// has no name or Value func, its bounds are invalid, or its name or position
+// is already taken.
+func (r *FieldRegistry) Register(f Field) error {
+	switch {
+	case f.Name == "":
+		return fmt.Errorf("cron: extra field has no name")
+	case f.Value == nil:
+		return fmt.Errorf("cron: extra field %s has no Value func", f.Name)
+	case f.Min > f.Max || f.Max > 62:
+		return fmt.Errorf("cron: extra field %s has invalid bounds %d-%d", f.Name, f.Min, f.Max)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f.Position < 0 || f.Position > 6+len(r.fields) {
+		return fmt.Errorf("cron: extra field %s has invalid position %d", f.Name, f.Position)
+	}
+	if _, ok := r.bounds(f.Name); ok {
+		return fmt.Errorf("cron: field %s already exists", f.Name)
+	}
+	for _, g := range r.fields {
+		if g.Position == f.Position {
+			return fmt.Errorf("cron: extra fields %s and %s have the same position %d", g.Name, f.Name, f.Position)
+		}
+	}
+	// Parse reads the fields without holding the lock, so they are
+	// replaced rather than changed.
+	fields := append(append([]Field(nil), r.fields...), f)
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Position < fields[j].Position })
+	r.fields = fields
+	return nil
+}
+
+// Fields returns the registered fields, ordered by position.
+func (r *FieldRegistry) Fields() []Field {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Field(nil), r.fields...)
+}
+
+// Alias registers a name for a value, range or list of values of the named
+// field, such as "noon" for 12 in the "hour" field, or "weekday" for "1-5"
+// in the "dow" field, so that an organization's vocabulary can appear in
+// stored specs.  The standard fields are named "second", "minute", "hour",
+// "dom", "month" and "dow"; extra fields by their Name.  An alias stands for
+// a whole element of a list, as in "weekday,sat", and is matched ignoring
+// case.
+//
+// It returns an error if there is no such field, the name is a number or
+// already names a value of the field, or the expression does not parse in
+// the field.
+func (r *FieldRegistry) Alias(field, name, expr string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.bounds(field)
+	if !ok {
+		return fmt.Errorf("cron: no field %s to alias %q in", field, name)
+	}
+	lower := strings.ToLower(name)
+	if _, err := strconv.Atoi(name); err == nil || name == "" || strings.ContainsAny(name, "*?,-/ \t") {
+		return fmt.Errorf("cron: invalid alias %q", name)
+	}
+	if _, ok := b.names[lower]; ok {
+		return fmt.Errorf("cron: alias %q names a value of the %s field", name, field)
+	}
+	if _, ok := r.aliases[field][lower]; ok {
+		return fmt.Errorf("cron: alias %q of the %s field is already registered", name, field)
+	}
+	if _, err := getField(expr, b); err != nil {
+		return fmt.Errorf("cron: alias %q: %v", name, err)
+	}
+	// As with the fields, the aliases are replaced rather than changed.
+	aliases := make(map[string]map[string]string, len(r.aliases)+1)
+	for f, names := range r.aliases {
+		aliases[f] = names
+	}
+	names := make(map[string]string, len(r.aliases[field])+1)
+	for n, e := range r.aliases[field] {
+		names[n] = e
+	}
+	names[lower] = expr
+	aliases[field] = names
+	r.aliases = aliases
+	return nil
+}
+
+// bounds returns the bounds of the named field.
+func (r *FieldRegistry) bounds(field string) (bounds, bool) {
+	for _, f := range standardFields {
+		if f.name == field {
+			return f.r, true
+		}
+	}
+	for _, f := range r.fields {
+		if f.Name == field {
+			return bounds{f.Min, f.Max, f.Names}, true
+		}
+	}
+	return bounds{}, false
+}
+
+// expand replaces the aliases among the elements of the field's list.
+func expand(field string, aliases map[string]string) string {
+	if len(aliases) == 0 {
+		return field
+	}
+	elems := strings.Split(field, ",")
+	for i, elem := range elems {
+		if expr, ok := aliases[strings.ToLower(elem)]; ok {
+			elems[i] = expr
+		}
+	}
+	return strings.Join(elems, ",")
+}
+
+// Parse returns the schedule for a spec with the registry's extra fields
+// and aliases.  It returns a descriptive error if the spec is not valid.
+func (r *FieldRegistry) Parse(spec string) (Schedule, error) {
+	r.mu.RLock()
+	extra, aliases := r.fields, r.aliases
+	r.mu.RUnlock()
+	if len(extra)+len(aliases) == 0 || strings.HasPrefix(spec, "@") {
+		return Parse(spec)
+	}
+	fields := strings.Fields(spec)
+	if len(extra) > 0 && len(fields) != 6+len(extra) {
+		return nil, specErrorf(ErrFieldCount, "Expected exactly %d fields, found %d: %s", 6+len(extra), len(fields), spec)
+	}
+
+	var standard []string
+	bits := make([]uint64, len(extra))
+	next := 0
+	for i, field := range fields {
+		if next == len(extra) || extra[next].Position != i {
+			if len(standard) < len(standardFields) {
+				field = expand(field, aliases[standardFields[len(standard)].name])
+			}
+			standard = append(standard, field)
+			continue
+		}
+		f := extra[next]
+		b, err := getField(expand(field, aliases[f.Name]), bounds{f.Min, f.Max, f.Names})
+		if err != nil {
+			return nil, err
+		}
+		bits[next] = b
+		next++
+	}
+	schedule, err := Parse(strings.Join(standard, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []Field
+	var matchedBits []uint64
+	for i, f := range extra {
+		if !isAll(bits[i], bounds{f.Min, f.Max, nil}) {
+			matched = append(matched, f)
+			matchedBits = append(matchedBits, bits[i])
+		}
+	}
+	if len(matched) == 0 {
+		return schedule, nil
+	}
+	return schedule.(*SpecSchedule).Where(func(t time.Time) bool {
+		for i, f := range matched {
+			if 1<<f.Value(t)&matchedBits[i] == 0 {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+// WithFields returns an Option that makes AddFunc, AddJob and Parse parse
+// specs with the registry's extra fields.  It takes precedence over
+// WithRobfigSpecs.
+func WithFields(r *FieldRegistry) Option {
+	return func(c *Cron) {
+		c.fields = r
+	}
+}
@@ -0,0 +1,191 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fortnight is the number of the fortnight of the year, from 1.
+var fortnight = Field{
+	Name:     "fortnight",
+	Min:      1,
+	Max:      27,
+	Position: 6,
+	Value:    func(t time.Time) uint { return uint(t.YearDay()-1)/14 + 1 },
+}
+
+// shift is the shift of the day, by its name.
+var shift = Field{
+	Name:     "shift",
+	Min:      0,
+	Max:      2,
+	Names:    map[string]uint{"night": 0, "day": 1, "late": 2},
+	Position: 3,
+	Value:    func(t time.Time) uint { return uint(t.Hour() / 8) },
+}
+
+func TestFieldRegistry(t *testing.T) {
+	r := NewFieldRegistry()
+	if err := r.Register(fortnight); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(shift); err != nil {
+		t.Fatal(err)
+	}
+	// The fortnight field follows the month now that the shift field
+	// precedes it.
+	if fields := r.Fields(); len(fields) != 2 || fields[0].Name != "shift" || fields[1].Name != "fortnight" {
+		t.Errorf("expected the fields ordered by position, got %v", fields)
+	}
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		spec     string
+		expected []time.Time
+	}{
+		// Every hour of the late shift, in the second fortnight.
+		{"0 0 * LATE * * 2 *", []time.Time{
+			time.Date(2025, 1, 15, 16, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 15, 17, 0, 0, 0, time.UTC),
+		}},
+		// Mondays of odd fortnights.
+		{"0 0 9 * * * */2 MON", []time.Time{
+			time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC),
+		}},
+		// Extra fields of stars match any time.
+		{"0 0 0 * 1 * * *", []time.Time{
+			time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0),
+		}},
+	}
+	for _, test := range tests {
+		s, err := r.Parse(test.spec)
+		if err != nil {
+			t.Errorf("%s: %v", test.spec, err)
+			continue
+		}
+		next := from
+		for _, expected := range test.expected {
+			next = s.Next(next)
+			if !next.Equal(expected) {
+				t.Errorf("%s: expected %v, got %v", test.spec, expected, next)
+				break
+			}
+		}
+	}
+	if s, err := r.Parse("@daily"); err != nil || !s.Next(from).Equal(from.AddDate(0, 0, 1)) {
+		t.Errorf("expected descriptors to parse as Parse parses them, got %v, %v", s, err)
+	}
+}
+
+func TestFieldRegistryErrors(t *testing.T) {
+	r := NewFieldRegistry()
+	if err := r.Register(fortnight); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []Field{
+		{Max: 1, Position: 0, Value: fortnight.Value},
+		{Name: "novalue", Max: 1, Position: 0},
+		{Name: "bounds", Min: 2, Max: 1, Position: 0, Value: fortnight.Value},
+		{Name: "wide", Max: 63, Position: 0, Value: fortnight.Value},
+		{Name: "far", Max: 1, Position: 8, Value: fortnight.Value},
+		{Name: "fortnight", Max: 1, Position: 0, Value: fortnight.Value},
+		{Name: "same", Max: 1, Position: 6, Value: fortnight.Value},
+	} {
+		if err := r.Register(f); err == nil {
+			t.Errorf("expected an error registering %+v", f)
+		}
+	}
+
+	for spec, kind := range map[string]error{
+		"0 0 9 * * *":     ErrFieldCount,
+		"0 0 9 * * * 28":  ErrValueOutOfRange,
+		"0 0 9 * * * odd": ErrSyntax,
+		"0 0 9 * * BOB 2": ErrSyntax,
+		"0 0 9 * * * 1 1": ErrFieldCount,
+	} {
+		if _, err := r.Parse(spec); !errors.Is(err, kind) {
+			t.Errorf("%s: expected %v, got %v", spec, kind, err)
+		}
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	r := NewFieldRegistry()
+	r.Register(shift)
+	cron := New(WithFields(r), WithParseCache(NewParseCache(10)))
+	if _, err := cron.AddFunc("0 0 9 DAY * * *", func() {}); err != nil {
+		t.Errorf("expected the Cron to accept extra fields, got %v", err)
+	}
+	if _, err := cron.AddFunc("0 0 9 * * *", func() {}); err == nil {
+		t.Error("expected the Cron to require extra fields")
+	}
+}
+
+func TestFieldRegistryAliases(t *testing.T) {
+	r := NewFieldRegistry()
+	for _, alias := range [][3]string{
+		{"hour", "noon", "12"},
+		{"hour", "business", "9-17"},
+		{"dow", "weekday", "1-5"},
+		{"dow", "weekend", "sat,sun"},
+	} {
+		if err := r.Alias(alias[0], alias[1], alias[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	from := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC) // A Friday.
+	for spec, expected := range map[string]time.Time{
+		"0 0 noon * * weekday":     time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC),
+		"0 0 NOON * * Weekend":     time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC),
+		"0 30 0,business * * *":    time.Date(2025, 1, 3, 0, 30, 0, 0, time.UTC),
+		"0 0 business * * weekend": time.Date(2025, 1, 4, 9, 0, 0, 0, time.UTC),
+		"0 0 noon * *":             time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC),
+	} {
+		s, err := r.Parse(spec)
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+			continue
+		}
+		if next := s.Next(from); !next.Equal(expected) {
+			t.Errorf("%s: expected %v, got %v", spec, expected, next)
+		}
+	}
+
+	// Extra fields take aliases too.
+	r.Register(shift)
+	if err := r.Alias("shift", "office", "day"); err != nil {
+		t.Fatal(err)
+	}
+	s, err := r.Parse("0 0 * office * * weekday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := s.Next(from); !next.Equal(time.Date(2025, 1, 3, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the first hour of the day shift, got %v", next)
+	}
+}
+
+func TestFieldRegistryAliasErrors(t *testing.T) {
+	r := NewFieldRegistry()
+	r.Alias("hour", "noon", "12")
+	for _, alias := range [][3]string{
+		{"year", "leap", "1"},
+		{"hour", "12", "13"},
+		{"hour", "", "12"},
+		{"hour", "a-b", "12"},
+		{"dow", "MON", "2"},
+		{"hour", "Noon", "13"},
+		{"hour", "late", "25"},
+	} {
+		if err := r.Alias(alias[0], alias[1], alias[2]); err == nil {
+			t.Errorf("expected an error registering %q", alias)
+		}
+	}
+	if err := r.Register(Field{Name: "hour", Max: 1, Position: 6, Value: shift.Value}); err == nil {
+		t.Error("expected an error registering an extra field named as a standard one")
+	}
+}
@@ -0,0 +1,64 @@
+package cron
+
+import "time"
+
+// ResumePolicy determines what ResumeAll does with the activations that fell
+// within the freeze.
+type ResumePolicy int
+
+const (
+	// SkipMissed drops the missed activations: each entry is scheduled from
+	// the time of the resume.
+	SkipMissed ResumePolicy = iota
+
+	// RunMissedOnce runs each entry that missed any activations once, for
+	// the earliest of them, then schedules it from the time of the resume.
+	RunMissedOnce
+
+	// RunAllMissed runs every missed activation of every entry, in order,
+	// before resuming the normal schedule.  Beware that a frequent entry may
+	// have missed a great many.
+	RunAllMissed
+)
+
+// PauseAll freezes the scheduler: no entry is activated until ResumeAll is
+// called.  Entries may still be added, changed and triggered while frozen.
+// Unlike Pause, it leaves the entries' own state alone, so entries paused
+// individually stay paused after ResumeAll.
+func (c *Cron) PauseAll() {
+	c.do(func() {
+		c.frozen = true
+	})
+}
+
+// ResumeAll thaws a scheduler frozen by PauseAll, handling the activations
+// missed meanwhile according to the policy.
+func (c *Cron) ResumeAll(policy ResumePolicy) {
+	c.do(func() {
+		if !c.frozen {
+			return
+		}
+		c.frozen = false
+		now := c.now()
+		switch policy {
+		case SkipMissed:
+			for _, e := range c.queue.list() {
+				if !e.Next.IsZero() && !e.Next.After(now) {
+					c.advance(e, now)
+				}
+			}
+		case RunAllMissed:
+			c.catchUp = now
+		}
+	})
+}
+
+// advanceFrom returns the time from which to compute the next activation of
+// an entry just activated at scheduled: scheduled itself while catching up on
+// activations missed during a freeze, so that each is run, otherwise now.
+func (c *Cron) advanceFrom(scheduled, now time.Time) time.Time {
+	if scheduled.Before(c.catchUp) {
+		return scheduled
+	}
+	return now
+}
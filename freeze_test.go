@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// freezeFor runs an every-second entry, freezes the Cron for a little over
+// two seconds, resumes it with the policy, and returns how many runs happened
+// during the freeze and shortly after the resume.
+func freezeFor(t *testing.T, policy ResumePolicy) (during, after int32) {
+	var runs int32
+	cron := New()
+	cron.Schedule(Every(time.Second), FuncJob(func() { atomic.AddInt32(&runs, 1) }))
+	cron.Start()
+	defer cron.Stop()
+
+	cron.PauseAll()
+	time.Sleep(2500 * time.Millisecond)
+	during = atomic.LoadInt32(&runs)
+	cron.ResumeAll(policy)
+	time.Sleep(200 * time.Millisecond)
+	return during, atomic.LoadInt32(&runs) - during
+}
+
+func TestResumeAllSkipMissed(t *testing.T) {
+	during, after := freezeFor(t, SkipMissed)
+	if during != 0 || after != 0 {
+		t.Errorf("expected no runs, got %d during and %d after the freeze", during, after)
+	}
+}
+
+func TestResumeAllRunMissedOnce(t *testing.T) {
+	during, after := freezeFor(t, RunMissedOnce)
+	if during != 0 || after != 1 {
+		t.Errorf("expected one run after the freeze, got %d during and %d after", during, after)
+	}
+}
+
+func TestResumeAllRunAllMissed(t *testing.T) {
+	during, after := freezeFor(t, RunAllMissed)
+	// Every(time.Second) rounds down to the second, so the freeze covers two or
+	// three activations.
+	if during != 0 || after < 2 || after > 3 {
+		t.Errorf("expected each missed run after the freeze, got %d during and %d after", during, after)
+	}
+}
+
+// Entries paused on their own stay paused.
+func TestResumeAllKeepsPausedEntries(t *testing.T) {
+	cron := New()
+	id := cron.Schedule(Every(time.Second), FuncJob(func() {}))
+	cron.Start()
+	defer cron.Stop()
+
+	cron.Pause(id)
+	cron.PauseAll()
+	cron.ResumeAll(SkipMissed)
+	if e := cron.Entry(id); !e.Paused {
+		t.Error("expected the entry to stay paused")
+	}
+}
@@ -0,0 +1,114 @@
+package cron
+
+import (
+	"math/bits"
+	"time"
+)
+
+// daysPerYear is the mean length of a year of the Gregorian calendar, which
+// has 97 leap years in 400.
+const daysPerYear = 365.2425
+
+// Frequency returns the mean number of times the schedule activates in a
+// window of the given length, such as 24 hours for a typical day, e.g. 86400
+// for "* * * * * *", which activates every second.  It is meant for catching
+// schedules that activate far more often than intended:
+//
+//	if cron.Frequency(schedule, time.Hour) > 60 {
+//		// warn that the job runs more than once a minute
+//	}
+//
+// The frequency of a SpecSchedule, possibly in a LocationSchedule or a
+// ScheduleTable, is computed from its fields, averaging its days over the
+// months and years of the calendar, and ignoring changes of offset, as a
+// clock on the wall would.  That of a ConstantDelaySchedule is the window
+// divided by its delay.  Other schedules are counted by calling Next over a
+// window starting at 2000-01-01 UTC, as their activations may not be
+// regular.
+func Frequency(schedule Schedule, window time.Duration) float64 {
+	switch s := schedule.(type) {
+	case ConstantDelaySchedule:
+		return float64(window) / float64(s.Delay)
+	case *SpecSchedule:
+		return specFrequency(s, window)
+	case PackedSchedule:
+		return specFrequency(s.Spec(), window)
+	case LocationSchedule:
+		return Frequency(s.Schedule, window)
+	}
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(window)
+	n := 0
+	for next := schedule.Next(start.Add(-time.Nanosecond)); !next.IsZero() && next.Before(end); next = schedule.Next(next) {
+		n++
+	}
+	return float64(n)
+}
+
+// specFrequency returns the mean number of activations of the schedule in
+// the window.
+func specFrequency(s *SpecSchedule, window time.Duration) float64 {
+	perDay := bits.OnesCount64(s.Second&^starBit) *
+		bits.OnesCount64(s.Minute&^starBit) *
+		bits.OnesCount64(s.Hour&^starBit)
+	days := 0.0 // the mean number of days a year the schedule activates on
+	for month := months.min; month <= months.max; month++ {
+		if 1<<month&s.Month == 0 {
+			continue
+		}
+		days += meanDays(s, daysIn(time.Month(month), 2001))
+		if time.Month(month) == time.February {
+			leap := meanDays(s, 29) - meanDays(s, 28)
+			days += leap * 97 / 400
+		}
+	}
+	return float64(perDay) * days / daysPerYear * window.Hours() / 24
+}
+
+// meanDays returns the mean number of days the schedule activates on in a
+// month of the given length, over the weekdays the month may start on.
+func meanDays(s *SpecSchedule, last int) float64 {
+	n := 0
+	for first := uint(0); first < 7; first++ {
+		n += bits.OnesCount64(s.dayMask(first) & (1<<uint(last+1) - 1))
+	}
+	return float64(n) / 7
+}
+
+// MinInterval returns the shortest time between consecutive activations of
+// the schedule after from, within horizon of it, or 0 if it activates fewer
+// than twice in that time.  It is meant for rejecting schedules that activate
+// more often than allowed, which Frequency, a mean, may not show.  Changes of
+// offset can bring activations closer than the schedule's fields suggest,
+// such as those at 01:00 and 03:00 on the day the clocks skip 02:00.
+//
+// That of a ConstantDelaySchedule is its delay.  Other schedules are stepped
+// through every activation, so the horizon of frequent ones should be short,
+// though a SpecSchedule found to activate in consecutive seconds, as often
+// as it can, is not stepped further.
+func MinInterval(schedule Schedule, from time.Time, horizon time.Duration) time.Duration {
+	if s, ok := schedule.(ConstantDelaySchedule); ok {
+		if horizon < 2*s.Delay {
+			return 0
+		}
+		return s.Delay
+	}
+	_, _, spec := specIn(schedule)
+	end := from.Add(horizon)
+	var min time.Duration
+	prev := schedule.Next(from)
+	for !prev.IsZero() && !prev.After(end) {
+		next := schedule.Next(prev)
+		if next.IsZero() || next.After(end) {
+			break
+		}
+		if gap := next.Sub(prev); min == 0 || gap < min {
+			min = gap
+			if spec && min <= time.Second {
+				break
+			}
+		}
+		prev = next
+	}
+	return min
+}
@@ -0,0 +1,119 @@
+package cron
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFrequency(t *testing.T) {
+	const (
+		day  = 24 * time.Hour
+		week = 7 * day
+		year = time.Duration(daysPerYear * float64(day))
+	)
+	entries := []struct {
+		spec     string
+		window   time.Duration
+		expected float64
+	}{
+		{"* * * * * *", day, 86400},
+		{"0 * * * * *", time.Hour, 60},
+		{"0 */15 9-17 * * *", day, 36},
+		{"0 0 9 * * MON-FRI", week, 5},
+		{"0 0 0 1 * *", year, 12},
+		{"0 0 0 31 * *", year, 7},
+		{"0 0 0 29 Feb *", 100 * year, 24.25},
+		{"TZ=UTC 0 0 * * *", week, 7},
+		{"@every 90s", time.Hour, 40},
+		{"@every 1h", 30 * time.Minute, 0.5},
+	}
+	for _, c := range entries {
+		sched, err := ParseRobfig(c.spec)
+		if err != nil {
+			sched, err = Parse(c.spec)
+		}
+		if err != nil {
+			t.Fatalf("%q: %v", c.spec, err)
+		}
+		if actual := Frequency(sched, c.window); math.Abs(actual-c.expected) > 1e-6 {
+			t.Errorf("%q over %v: expected %v, got %v", c.spec, c.window, c.expected, actual)
+		}
+	}
+}
+
+func TestFrequencyMatchesNext(t *testing.T) {
+	// Over two centuries, the computed frequency is within a small fraction
+	// of the activations Next finds, as months start on each weekday about
+	// equally often.
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(200, 0, 0)
+	for _, spec := range []string{"0 0 0 * * MON", "0 0 0 13 * FRI", "0 0 0 1,15 Feb-Jun SAT", "0 0 0 31 * *"} {
+		sched, err := Parse(spec)
+		if err != nil {
+			t.Fatalf("%q: %v", spec, err)
+		}
+		n := 0
+		for next := sched.Next(start.Add(-time.Second)); next.Before(end); next = sched.Next(next) {
+			n++
+		}
+		if actual := Frequency(sched, end.Sub(start)); math.Abs(actual-float64(n)) > float64(n)/500 {
+			t.Errorf("%q: expected about %d, got %v", spec, n, actual)
+		}
+	}
+}
+
+func TestFrequencyOtherSchedules(t *testing.T) {
+	if actual := Frequency(robfigSchedule{time.Hour}, 24*time.Hour); actual != 24 {
+		t.Errorf("expected 24, got %v", actual)
+	}
+
+	table := NewScheduleTable()
+	sched, _ := Parse("0 0 * * * *")
+	p, err := table.Add(sched.(*SpecSchedule))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := Frequency(p, 24*time.Hour); actual != 24 {
+		t.Errorf("expected 24, got %v", actual)
+	}
+}
+
+func TestMinInterval(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	const day = 24 * time.Hour
+	entries := []struct {
+		spec     string
+		horizon  time.Duration
+		expected time.Duration
+	}{
+		{"* * * * * *", day, time.Second},
+		{"*/2 * * * * *", time.Hour, 2 * time.Second},
+		{"0,59 * * * * *", time.Hour, time.Second},
+		{"0 0,50 * * * *", day, 10 * time.Minute},
+		{"0 0 9,17 * * MON-FRI", 14 * day, 8 * time.Hour},
+		{"0 0 0 1 * *", 100 * day, 29 * day},
+		{"0 0 0 1 * *", 30 * day, 0},
+		{"@every 90s", time.Hour, 90 * time.Second},
+		{"@every 1h", time.Hour, 0},
+	}
+	for _, c := range entries {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Fatalf("%q: %v", c.spec, err)
+		}
+		if actual := MinInterval(sched, from, c.horizon); actual != c.expected {
+			t.Errorf("%q over %v: expected %v, got %v", c.spec, c.horizon, c.expected, actual)
+		}
+	}
+
+	// The clocks skip 02:00 in New York on 10 March 2024.
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched, _ := Parse("0 0 1,3 * * *")
+	if actual := MinInterval(sched, from.In(ny), 90*day); actual != time.Hour {
+		t.Errorf("expected an hour on the day the clocks skip 02:00, got %v", actual)
+	}
+}
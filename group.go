@@ -0,0 +1,71 @@
+package cron
+
+import "sort"
+
+// WithTags returns an EntryOption that adds the entry to the groups named by
+// the given tags, so that it can be managed along with the other entries of
+// the group.
+func WithTags(tags ...string) EntryOption {
+	return func(e *Entry) {
+		e.Tags = append(e.Tags, tags...)
+	}
+}
+
+// HasTag returns true if the entry belongs to the group named by tag.
+func (e *Entry) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// EntriesByTag returns a snapshot of the entries in the group named by tag.
+func (c *Cron) EntriesByTag(tag string) []*Entry {
+	entries := []*Entry{}
+	c.do(func() {
+		for _, e := range c.queue.list() {
+			if e.HasTag(tag) {
+				entries = append(entries, e.snapshot())
+			}
+		}
+	})
+	sort.Sort(byTime(entries))
+	return entries
+}
+
+// StopGroup pauses every entry in the group named by tag, see Pause.
+func (c *Cron) StopGroup(tag string) {
+	c.do(func() {
+		for _, e := range c.queue.list() {
+			if e.HasTag(tag) {
+				c.pause(e)
+			}
+		}
+	})
+}
+
+// StartGroup resumes every paused entry in the group named by tag, see
+// Resume.
+func (c *Cron) StartGroup(tag string) {
+	c.do(func() {
+		now := c.now()
+		for _, e := range c.queue.list() {
+			if e.HasTag(tag) {
+				c.resume(e, now)
+			}
+		}
+	})
+}
+
+// RemoveGroup removes every entry in the group named by tag.
+func (c *Cron) RemoveGroup(tag string) {
+	c.do(func() {
+		for _, e := range c.queue.list() {
+			if e.HasTag(tag) {
+				c.removeEntry(e)
+			}
+		}
+	})
+}
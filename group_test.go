@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEntriesByTag(t *testing.T) {
+	cron := New()
+	a, _ := cron.AddFunc("@daily", func() {}, WithTags("billing", "reports"))
+	b := cron.Schedule(Every(time.Hour), FuncJob(func() {}), WithTags("billing"))
+	cron.AddFunc("@daily", func() {})
+
+	entries := cron.EntriesByTag("billing")
+	if len(entries) != 2 || entries[0].ID != a || entries[1].ID != b {
+		t.Errorf("unexpected entries %v", entries)
+	}
+	if len(cron.EntriesByTag("reports")) != 1 || len(cron.EntriesByTag("none")) != 0 {
+		t.Error("unexpected entries")
+	}
+
+	// Snapshots don't share their tags with the entry.
+	entries[0].Tags[0] = "changed"
+	if !cron.Entry(a).HasTag("billing") {
+		t.Error("expected the entry's tags to be unaffected")
+	}
+}
+
+// Stopping a group pauses its entries until the group is started.
+func TestStopAndStartGroup(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	cron := New()
+	cron.AddFunc("* * * * * ?", func() { wg.Done() }, WithTags("billing"))
+	other, _ := cron.AddFunc("* * * * * ?", func() {})
+	cron.StopGroup("billing")
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(ONE_SECOND):
+	case <-wait(wg):
+		t.FailNow()
+	}
+	if cron.Entry(other).Paused {
+		t.Error("expected entries outside the group to be unaffected")
+	}
+
+	cron.StartGroup("billing")
+	select {
+	case <-time.After(ONE_SECOND):
+		t.FailNow()
+	case <-wait(wg):
+	}
+}
+
+func TestRemoveGroup(t *testing.T) {
+	cron := New()
+	cron.AddFunc("@daily", func() {}, WithTags("billing"))
+	other, _ := cron.AddFunc("@daily", func() {})
+	cron.AddFunc("@daily", func() {}, WithTags("billing"))
+	cron.Start()
+	defer cron.Stop()
+
+	cron.RemoveGroup("billing")
+	entries := cron.Entries()
+	if len(entries) != 1 || entries[0].ID != other {
+		t.Errorf("unexpected entries %v", entries)
+	}
+}
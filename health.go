@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// EntryHealth reports how an entry's recent runs went, see Health.
+type EntryHealth struct {
+	EntryID EntryID
+	Name    string
+
+	// LastSuccess is the time the entry's last successful run finished, or
+	// the zero time if it has not succeeded since the Cron was created.
+	LastSuccess time.Time
+
+	// ConsecutiveFailures counts the runs that failed or panicked since the
+	// last successful one.
+	ConsecutiveFailures int
+
+	// LastScheduled is the entry's last activation time, as Entry.Prev.
+	LastScheduled time.Time
+
+	// Missed is true if the last activation was not dispatched, because it
+	// was skipped or could not be claimed in the Store.
+	Missed bool
+}
+
+// Healthy returns true if the entry's last run did not fail and its last
+// activation was not missed.
+func (h EntryHealth) Healthy() bool {
+	return h.ConsecutiveFailures == 0 && !h.Missed
+}
+
+// Health returns the health of every entry, ordered by ID.  It is meant for
+// readiness and liveness endpoints.
+func (c *Cron) Health() []EntryHealth {
+	entries := c.Entries()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	health := make([]EntryHealth, len(entries))
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+	for i, e := range entries {
+		h := EntryHealth{EntryID: e.ID, Name: e.Name, LastScheduled: e.Prev}
+		if s := c.health.entries[e.ID]; s != nil {
+			h.LastSuccess = s.lastSuccess
+			h.ConsecutiveFailures = s.failures
+			h.Missed = s.missed
+		}
+		health[i] = h
+	}
+	return health
+}
+
+// healthTracker keeps the state behind Health.  The zero value is empty.
+type healthTracker struct {
+	mu      sync.Mutex
+	entries map[EntryID]*entryHealth
+}
+
+type entryHealth struct {
+	lastSuccess time.Time
+	failures    int
+	missed      bool
+	stats       entryStats
+}
+
+// update applies fn to the state of the given entry.
+func (t *healthTracker) update(id EntryID, fn func(h *entryHealth)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.entries == nil {
+		t.entries = make(map[EntryID]*entryHealth)
+	}
+	h := t.entries[id]
+	if h == nil {
+		h = &entryHealth{}
+		t.entries[id] = h
+	}
+	fn(h)
+}
+
+// activated records whether the entry's latest activation was dispatched.
+func (t *healthTracker) activated(id EntryID, dispatched bool) {
+	t.update(id, func(h *entryHealth) { h.missed = !dispatched })
+}
+
+// finished records the outcome of a run.
+func (t *healthTracker) finished(run Run) {
+	t.update(run.EntryID, func(h *entryHealth) {
+		h.stats.add(run)
+		switch run.Outcome {
+		case OutcomeSuccess:
+			h.lastSuccess = run.End
+			h.failures = 0
+		case OutcomeFailure, OutcomePanic:
+			h.failures++
+		}
+	})
+}
+
+// forget drops the state of a removed entry.
+func (t *healthTracker) forget(id EntryID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, id)
+}
@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+	fail := true
+	cron := New()
+	id, _ := cron.AddContextFunc("0 0 0 1 1 ?", func(ctx context.Context) error {
+		if fail {
+			return errors.New("boom")
+		}
+		return nil
+	}, WithName("report"))
+
+	h := cron.Health()
+	if len(h) != 1 || h[0].EntryID != id || h[0].Name != "report" || !h[0].Healthy() {
+		t.Fatalf("expected a healthy entry before any run, got %+v", h)
+	}
+
+	cron.Trigger(id)
+	cron.Trigger(id)
+	<-cron.Stop().Done()
+	if h := cron.Health()[0]; h.ConsecutiveFailures != 2 || h.Healthy() {
+		t.Errorf("expected two failures, got %+v", h)
+	}
+
+	fail = false
+	cron.Trigger(id)
+	<-cron.Stop().Done()
+	if h := cron.Health()[0]; h.ConsecutiveFailures != 0 || h.LastSuccess.IsZero() || !h.Healthy() {
+		t.Errorf("expected a success to reset the failures, got %+v", h)
+	}
+}
+
+func TestHealthMissed(t *testing.T) {
+	cron := New(WithRateLimit(RateLimit{Rate: 0.001, Burst: 1, Overflow: Skip}))
+	a, _ := cron.AddFunc("* * * * * ?", func() {})
+	b, _ := cron.AddFunc("* * * * * ?", func() {})
+	cron.Start()
+	time.Sleep(ONE_SECOND)
+	<-cron.Stop().Done()
+
+	health := cron.Health()
+	if health[0].EntryID != a || health[0].Missed || health[0].LastScheduled.IsZero() {
+		t.Errorf("expected entry %d dispatched, got %+v", a, health[0])
+	}
+	if health[1].EntryID != b || !health[1].Missed || health[1].Healthy() {
+		t.Errorf("expected entry %d missed, got %+v", b, health[1])
+	}
+}
@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"container/heap"
+	"time"
+)
+
+// entryHeap is a min-heap of entries ordered by their next activation time,
+// with zero times last.  It implements heap.Interface and keeps each entry's
+// index up to date, so that an entry can be fixed or removed in O(log n).
+// It is the default entryQueue.
+type entryHeap []*Entry
+
+func (h entryHeap) Len() int           { return len(h) }
+func (h entryHeap) Less(i, j int) bool { return byTime(h).Less(i, j) }
+func (h entryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *entryHeap) Push(x interface{}) {
+	e := x.(*Entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *entryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*h = old[:n-1]
+	return e
+}
+
+func (h *entryHeap) push(e *Entry)   { heap.Push(h, e) }
+func (h *entryHeap) remove(e *Entry) { heap.Remove(h, e.index) }
+func (h *entryHeap) fix(e *Entry)    { heap.Fix(h, e.index) }
+
+func (h *entryHeap) wakeup() time.Time {
+	if len(*h) == 0 {
+		return time.Time{}
+	}
+	return (*h)[0].Next
+}
+
+func (h *entryHeap) due(now time.Time) []*Entry {
+	var due []*Entry
+	for len(*h) > 0 && !(*h)[0].Next.IsZero() && !(*h)[0].Next.After(now) {
+		due = append(due, heap.Pop(h).(*Entry))
+	}
+	return due
+}
+
+func (h *entryHeap) list() []*Entry {
+	return append([]*Entry(nil), *h...)
+}
@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// checkHeap verifies the heap property and the entries' indexes.
+func checkHeap(t *testing.T, c *Cron) {
+	h := *c.queue.(*entryHeap)
+	for i, e := range h {
+		if e.index != i {
+			t.Fatalf("entry %d has index %d, expected %d", e.ID, e.index, i)
+		}
+		if c.byID[e.ID] != e {
+			t.Fatalf("entry %d missing from byID", e.ID)
+		}
+		if parent := (i - 1) / 2; i > 0 && h.Less(i, parent) {
+			t.Fatalf("entry %d is before its parent", e.ID)
+		}
+	}
+	if len(c.byID) != len(h) {
+		t.Fatalf("byID has %d entries, heap has %d", len(c.byID), len(h))
+	}
+}
+
+func TestHeapOperations(t *testing.T) {
+	c := New()
+	now := time.Now()
+	var ids []EntryID
+	for i := 0; i < 500; i++ {
+		ids = append(ids, c.Schedule(Every(time.Duration(1+rand.Intn(3600))*time.Second), FuncJob(func() {})))
+	}
+	for _, e := range c.queue.list() {
+		c.advance(e, now)
+	}
+	checkHeap(t, c)
+
+	for i, id := range ids {
+		switch i % 3 {
+		case 0:
+			c.Remove(id)
+		case 1:
+			c.Pause(id)
+		}
+	}
+	checkHeap(t, c)
+
+	prev := time.Time{}
+	for _, e := range c.Entries() {
+		if !e.Next.IsZero() && e.Next.Before(prev) {
+			t.Fatal("expected entries in time order")
+		}
+		prev = e.Next
+	}
+}
+
+func BenchmarkScheduleRunning(b *testing.B) {
+	c := New()
+	c.Start()
+	defer c.Stop()
+	job := FuncJob(func() {})
+	for i := 0; i < b.N; i++ {
+		c.Schedule(Every(time.Duration(1+i%86400)*time.Hour), job)
+	}
+}
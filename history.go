@@ -0,0 +1,110 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoStore is returned by operations that require a Store when the Cron
+// was created without one.
+var ErrNoStore = errors.New("cron: no store configured")
+
+// Outcome describes how a run finished.
+type Outcome int
+
+const (
+	// OutcomeSuccess means the job returned without error.
+	OutcomeSuccess Outcome = iota
+
+	// OutcomeFailure means the job returned an error.
+	OutcomeFailure
+
+	// OutcomePanic means the job panicked.
+	OutcomePanic
+
+	// OutcomeSkipped means the job was not run, for the reason given by
+	// the run's Error.
+	OutcomeSkipped
+
+	// OutcomeDryRun means the job would have been run, but the Cron was in
+	// dry-run mode.
+	OutcomeDryRun
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeSuccess:
+		return "success"
+	case OutcomeFailure:
+		return "failure"
+	case OutcomePanic:
+		return "panic"
+	case OutcomeSkipped:
+		return "skipped"
+	case OutcomeDryRun:
+		return "dry run"
+	}
+	return "unknown"
+}
+
+// Run is the record of a single invocation of an entry's job.
+type Run struct {
+	// Key identifies the activation this run was dispatched for.
+	Key RunKey
+
+	// ID uniquely identifies this invocation.  Unlike Key, it differs
+	// between invocations for the same activation, such as a run and its
+	// retries, or the same activation dispatched by different processes.
+	ID string
+
+	// EntryID is the ID of the entry that was run.
+	EntryID EntryID
+
+	// Scheduled is the activation time the run was dispatched for.
+	Scheduled time.Time
+
+	// Start and End are the times the job started and finished.  They are
+	// zero if the run was skipped or a dry run.
+	Start, End time.Time
+
+	// Outcome describes how the job finished.
+	Outcome Outcome
+
+	// Error is the error returned by the job, or the value it panicked
+	// with.  It is empty if the job succeeded.
+	Error string
+
+	// Output is what the job wrote to the writer returned by
+	// OutputFromContext, truncated to 64KB.
+	Output string
+
+	// Host is the name of the host the job ran on.
+	Host string
+}
+
+// byScheduled is a wrapper for sorting runs by scheduled time.
+type byScheduled []Run
+
+func (s byScheduled) Len() int           { return len(s) }
+func (s byScheduled) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byScheduled) Less(i, j int) bool { return s[i].Scheduled.Before(s[j].Scheduled) }
+
+// History returns the recorded runs of the given entry scheduled at or after
+// since, most recent first.  If limit is positive, at most limit runs are
+// returned.  It returns ErrNoStore if the Cron has no Store.
+func (c *Cron) History(id EntryID, since time.Time, limit int) ([]Run, error) {
+	if c.store == nil {
+		return nil, ErrNoStore
+	}
+	return c.store.History(id, since, limit)
+}
+
+// record adds the finished run to the Store, if one is configured.
+func (c *Cron) record(run Run) {
+	if c.store == nil {
+		return
+	}
+	if err := c.store.Record(run); err != nil {
+		c.logf("cron: failed to record run %s: %v", run.Key, err)
+	}
+}
@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreHistory(t *testing.T) {
+	store := NewMemoryStore()
+	base := time.Date(2016, 9, 14, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		scheduled := base.Add(time.Duration(i) * time.Hour)
+		store.Record(Run{Key: NewRunKey(1, scheduled), EntryID: 1, Scheduled: scheduled})
+	}
+	store.Record(Run{EntryID: 2, Scheduled: base})
+
+	history, _ := store.History(1, base.Add(time.Hour), 0)
+	if len(history) != 4 {
+		t.Fatalf("expected 4 runs, got %d", len(history))
+	}
+	if !history[0].Scheduled.Equal(base.Add(4 * time.Hour)) {
+		t.Errorf("expected most recent run first, got %v", history[0].Scheduled)
+	}
+
+	history, _ = store.History(1, time.Time{}, 2)
+	if len(history) != 2 {
+		t.Errorf("expected 2 runs, got %d", len(history))
+	}
+}
+
+// Only the most recent runs of each entry are kept, and the history of a
+// removed entry is dropped.
+func TestMemoryStoreHistoryLimit(t *testing.T) {
+	store := NewMemoryStore(WithHistoryLimit(3))
+	base := time.Date(2016, 9, 14, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		store.Record(Run{EntryID: 1, Scheduled: base.Add(time.Duration(i) * time.Hour)})
+	}
+	history, _ := store.History(1, time.Time{}, 0)
+	if len(history) != 3 || !history[2].Scheduled.Equal(base.Add(7*time.Hour)) {
+		t.Errorf("expected the 3 most recent runs, got %v", history)
+	}
+	if n := len(store.runs[1]); n >= 6 {
+		t.Errorf("expected the runs beyond the limit to be trimmed, %d remain", n)
+	}
+
+	cron := New(WithStore(store))
+	id, _ := cron.AddFunc("@hourly", func() {})
+	store.Record(Run{EntryID: id, Scheduled: base})
+	cron.Remove(id)
+	if history, _ := store.History(id, time.Time{}, 0); len(history) != 0 {
+		t.Errorf("expected the removed entry's history to be dropped, got %v", history)
+	}
+}
+
+func TestHistoryWithoutStore(t *testing.T) {
+	if _, err := New().History(1, time.Time{}, 0); err != ErrNoStore {
+		t.Errorf("expected ErrNoStore, got %v", err)
+	}
+}
+
+// Each run's outcome is recorded in the store.
+func TestHistoryRecordsOutcomes(t *testing.T) {
+	store := NewMemoryStore()
+	cron := New(WithStore(store))
+	ok, _ := cron.AddFunc("* * * * * ?", func() {})
+	failed, _ := cron.AddContextFunc("* * * * * ?", func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+	panicked, _ := cron.AddFunc("* * * * * ?", func() { panic("YOLO") })
+	cron.Start()
+	time.Sleep(ONE_SECOND)
+	cron.Stop()
+	time.Sleep(10 * time.Millisecond)
+
+	expected := map[EntryID]Outcome{ok: OutcomeSuccess, failed: OutcomeFailure, panicked: OutcomePanic}
+	for id, outcome := range expected {
+		history, err := cron.History(id, time.Time{}, 1)
+		if err != nil || len(history) != 1 {
+			t.Fatalf("entry %d: expected a run, got %v (%v)", id, history, err)
+		}
+		run := history[0]
+		if run.Outcome != outcome {
+			t.Errorf("entry %d: expected %s, got %s", id, outcome, run.Outcome)
+		}
+		if outcome != OutcomeSuccess && run.Error == "" {
+			t.Errorf("entry %d: expected an error", id)
+		}
+		if run.End.Before(run.Start) || run.Start.Before(run.Scheduled) {
+			t.Errorf("entry %d: unexpected run times %v", id, run)
+		}
+	}
+}
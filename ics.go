@@ -0,0 +1,348 @@
+package cron
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxICSEvents bounds the number of events ExportICS lists for a schedule
+// that no RRULE can represent.
+const maxICSEvents = 1000
+
+// icsZoneYears is the number of years of offset changes the time zone of an
+// unbounded recurring event describes; calendars keep applying the last
+// observance beyond them.
+const icsZoneYears = 10
+
+// ExportRRULE renders the schedule as an RFC 5545 recurrence rule, such as
+// "FREQ=DAILY;BYHOUR=9;BYMINUTE=30;BYSECOND=0;BYDAY=MO,TU,WE,TH,FR".  The
+// rule holds in the time zone of the event it recurs, see ExportICS.  It
+// returns an error if no rule can represent the schedule: it must be a
+// SpecSchedule or a ConstantDelaySchedule, and a SpecSchedule restricting
+// both the day of month and the day of week must run on the days both allow,
+// as a rule cannot run on either.
+func ExportRRULE(schedule Schedule) (string, error) {
+	if l, ok := schedule.(LocationSchedule); ok {
+		schedule = l.Schedule
+	}
+	switch s := schedule.(type) {
+	case ConstantDelaySchedule:
+		return exportDelayRRULE(s.Delay), nil
+	case *SpecSchedule:
+		return exportSpecRRULE(s)
+	case MonthEndSchedule:
+		return "FREQ=MONTHLY;BYMONTHDAY=-1;BYHOUR=0;BYMINUTE=0;BYSECOND=0", nil
+	}
+	return "", fmt.Errorf("cron: RRULE cannot represent %s", Describe(schedule))
+}
+
+// exportDelayRRULE renders a constant delay as a rule in the coarsest unit
+// that divides it.
+func exportDelayRRULE(delay time.Duration) string {
+	freq, unit := "SECONDLY", time.Second
+	switch {
+	case delay%(24*time.Hour) == 0:
+		freq, unit = "DAILY", 24*time.Hour
+	case delay%time.Hour == 0:
+		freq, unit = "HOURLY", time.Hour
+	case delay%time.Minute == 0:
+		freq, unit = "MINUTELY", time.Minute
+	}
+	return fmt.Sprintf("FREQ=%s;INTERVAL=%d", freq, delay/unit)
+}
+
+// exportSpecRRULE renders a spec as a rule recurring at the coarsest of
+// DAILY, HOURLY, MINUTELY and SECONDLY that keeps the rule exact.  The time
+// fields finer than the frequency are always listed, as a rule takes those
+// it leaves out from the event's start.  The date fields only limit the
+// recurrence, so they are left out when unrestricted; a rule runs on the
+// days both of them allow.
+func exportSpecRRULE(s *SpecSchedule) (string, error) {
+	byDom, byDow, both := s.restrictsDays()
+	if byDom && byDow && !both {
+		return "", fmt.Errorf("cron: RRULE cannot run on either the day of month or the day of week")
+	}
+
+	freq := "DAILY"
+	parts := []string{
+		"BYHOUR=" + formatRRULEField(s.Hour, hours),
+		"BYMINUTE=" + formatRRULEField(s.Minute, minutes),
+		"BYSECOND=" + formatRRULEField(s.Second, seconds),
+	}
+	switch {
+	case isAll(s.Hour, hours) && isAll(s.Minute, minutes) && isAll(s.Second, seconds):
+		freq, parts = "SECONDLY", nil
+	case isAll(s.Hour, hours) && isAll(s.Minute, minutes):
+		freq, parts = "MINUTELY", parts[2:]
+	case isAll(s.Hour, hours):
+		freq, parts = "HOURLY", parts[1:]
+	}
+	if !isAll(s.Month, months) {
+		parts = append(parts, "BYMONTH="+formatRRULEField(s.Month, months))
+	}
+	if byDom {
+		parts = append(parts, "BYMONTHDAY="+formatRRULEField(s.Dom, dom))
+	}
+	if byDow {
+		var days []string
+		for _, v := range values(s.Dow, dow) {
+			days = append(days, strings.ToUpper(time.Weekday(v).String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	return strings.Join(append([]string{"FREQ=" + freq}, parts...), ";"), nil
+}
+
+// formatRRULEField renders a field's bit set as a comma-separated list of
+// its values, as rules have no ranges.
+func formatRRULEField(bits uint64, r bounds) string {
+	var items []string
+	for _, v := range values(bits, r) {
+		items = append(items, strconv.Itoa(int(v)))
+	}
+	return strings.Join(items, ",")
+}
+
+// ExportICS renders the schedule as an RFC 5545 calendar, which calendar
+// applications can subscribe to, with an event titled summary for each
+// activation from now until horizon has passed.  Schedules ExportRRULE can
+// represent become a single recurring event, others a list of up to 1000
+// events.  A zero horizon leaves a recurring event unbounded, and is an
+// error for a schedule that needs listing.
+//
+// A LocationSchedule's events are in its own location, described by a
+// VTIMEZONE component, and other schedules' in the system's time zone,
+// which calendars render in the viewer's.
+func ExportICS(schedule Schedule, summary string, horizon time.Duration) (string, error) {
+	return exportICS(schedule, summary, time.Now(), horizon)
+}
+
+func exportICS(schedule Schedule, summary string, now time.Time, horizon time.Duration) (string, error) {
+	location := time.Local
+	if l, ok := schedule.(LocationSchedule); ok && l.Location != nil {
+		location = l.Location
+	}
+	now = now.In(location)
+	var until time.Time
+	if horizon > 0 {
+		until = now.Add(horizon)
+	}
+
+	var events [][]string
+	if rule, err := ExportRRULE(schedule); err == nil {
+		start := schedule.Next(now)
+		if start.IsZero() || (!until.IsZero() && start.After(until)) {
+			return icsCalendar(nil), nil
+		}
+		if !until.IsZero() {
+			rule += ";UNTIL=" + until.UTC().Format(icsUTC)
+		}
+		events = append(events, icsEvent(summary, now, start, "RRULE:"+rule))
+		if until.IsZero() {
+			until = now.AddDate(icsZoneYears, 0, 0)
+		}
+	} else if until.IsZero() {
+		return "", err
+	} else {
+		for t := schedule.Next(now); !t.IsZero() && !t.After(until); t = schedule.Next(t) {
+			if len(events) == maxICSEvents {
+				return "", fmt.Errorf("cron: more than %d activations within %s", maxICSEvents, horizon)
+			}
+			events = append(events, icsEvent(summary, now, t))
+		}
+	}
+	if icsZoned(location) {
+		events = append([][]string{icsTimezone(location, now, until)}, events...)
+	}
+	return icsCalendar(events), nil
+}
+
+// ExportFeed renders the next n activations of the entries with the given
+// IDs, or of every entry if none is given, as Upcoming returns them, as an
+// RFC 5545 calendar, so that the jobs can be followed in a calendar
+// application.  Each activation is an event titled with the entry's name, or
+// its spec if it has none.  Paused and expired entries and IDs of no entry
+// are left out.
+//
+// The events are in the Cron's location, as a LocationSchedule's are in
+// ExportICS.
+func (c *Cron) ExportFeed(n int, ids ...EntryID) string {
+	var entries []*Entry
+	if len(ids) == 0 {
+		entries = c.Entries()
+	}
+	for _, id := range ids {
+		if e := c.Entry(id); e != nil {
+			entries = append(entries, e)
+		}
+	}
+	return c.exportFeed(entries, n, c.now())
+}
+
+func (c *Cron) exportFeed(entries []*Entry, n int, now time.Time) string {
+	var (
+		events    [][]string
+		locations []*time.Location
+		last      = make(map[*time.Location]time.Time)
+	)
+	for _, e := range entries {
+		summary := e.Name
+		if summary == "" {
+			summary = e.Spec
+		}
+		if summary == "" {
+			summary = Describe(e.Schedule)
+		}
+		for _, t := range c.upcoming(e, n, now) {
+			events = append(events, icsEvent(summary, now, t))
+			if loc := t.Location(); icsZoned(loc) {
+				if _, ok := last[loc]; !ok {
+					locations = append(locations, loc)
+				}
+				if t.After(last[loc]) {
+					last[loc] = t
+				}
+			}
+		}
+	}
+	var zones [][]string
+	for _, loc := range locations {
+		zones = append(zones, icsTimezone(loc, now, last[loc]))
+	}
+	return icsCalendar(append(zones, events...))
+}
+
+// The formats of RFC 5545 date-times in UTC, and in local or floating time.
+const (
+	icsUTC   = "20060102T150405Z"
+	icsLocal = "20060102T150405"
+)
+
+// icsEvent returns the lines of an event starting at start, with the given
+// extra properties.  Its UID is derived from its contents, so that exporting
+// the same schedule again updates the events subscribers have seen.
+func icsEvent(summary string, now, start time.Time, props ...string) []string {
+	dtstart := "DTSTART:" + start.UTC().Format(icsUTC)
+	switch loc := start.Location(); {
+	case loc == time.Local:
+		dtstart = "DTSTART:" + start.Format(icsLocal)
+	case loc != time.UTC:
+		dtstart = "DTSTART;TZID=" + loc.String() + ":" + start.Format(icsLocal)
+	}
+	// A recurring event keeps its identity as its start moves on, and each
+	// listed one has its own.
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s\x00%s", summary, strings.Join(props, "\x00"))
+	if len(props) == 0 {
+		fmt.Fprintf(h, "\x00%s", start.UTC().Format(icsUTC))
+	}
+	lines := []string{
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:%016x@cron", h.Sum64()),
+		"DTSTAMP:" + now.UTC().Format(icsUTC),
+		dtstart,
+		"SUMMARY:" + icsEscape(summary),
+	}
+	return append(append(lines, props...), "END:VEVENT")
+}
+
+// icsZoned reports whether events in the location refer to it by TZID, so
+// that the calendar must describe it with a VTIMEZONE component.
+func icsZoned(loc *time.Location) bool {
+	return loc != time.UTC && loc != time.Local
+}
+
+// icsTimezone returns the lines of a VTIMEZONE component describing the
+// offsets of the location from from until to: an observance of the offset
+// in effect at from, followed by one for each change of offset.
+func icsTimezone(loc *time.Location, from, to time.Time) []string {
+	from, to = from.In(loc), to.In(loc)
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + loc.String()}
+	_, offset := from.Zone()
+	lines = append(lines, icsObservance(from, offset)...)
+	for t := from.Truncate(time.Second); t.Before(to); {
+		next := t.Add(24 * time.Hour)
+		if _, o := next.Zone(); o == offset {
+			t = next
+			continue
+		}
+		// Narrow the change down to the second it takes effect.
+		for next.Sub(t) > time.Second {
+			mid := t.Add(next.Sub(t) / 2 / time.Second * time.Second)
+			if _, o := mid.Zone(); o == offset {
+				t = mid
+			} else {
+				next = mid
+			}
+		}
+		lines = append(lines, icsObservance(next, offset)...)
+		t = next
+		_, offset = t.Zone()
+	}
+	return append(lines, "END:VTIMEZONE")
+}
+
+// icsObservance returns the lines of the observance taking effect at t,
+// changing from the given offset.  Its start is the local time of the
+// change in the offset it changes from.
+func icsObservance(t time.Time, from int) []string {
+	kind := "STANDARD"
+	if t.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, offset := t.Zone()
+	return []string{
+		"BEGIN:" + kind,
+		"DTSTART:" + t.In(time.FixedZone("", from)).Format(icsLocal),
+		"TZOFFSETFROM:" + icsOffset(from),
+		"TZOFFSETTO:" + icsOffset(offset),
+		"TZNAME:" + icsEscape(name),
+		"END:" + kind,
+	}
+}
+
+// icsOffset formats an offset in seconds east of UTC as a UTC-OFFSET value.
+func icsOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
+
+// icsCalendar wraps the components, time zones first and then events, in a
+// calendar, folding its lines to 75 octets and ending them with CRLF.
+func icsCalendar(components [][]string) string {
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//webconnex//cron//EN"}
+	for _, component := range components {
+		lines = append(lines, component...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		// Continuation lines start with a space, which counts against them.
+		for max := 75; len(line) > max; max = 74 {
+			cut := max
+			for line[cut]&0xC0 == 0x80 {
+				cut--
+			}
+			b.WriteString(line[:cut] + "\r\n ")
+			line = line[cut:]
+		}
+		b.WriteString(line + "\r\n")
+	}
+	return b.String()
+}
+
+// icsEscape escapes text for a TEXT property value.
+func icsEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
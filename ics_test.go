@@ -0,0 +1,179 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportRRULE(t *testing.T) {
+	for _, test := range []struct {
+		schedule Schedule
+		expected string
+	}{
+		{mustParse("0 30 9 * * MON-FRI"), "FREQ=DAILY;BYHOUR=9;BYMINUTE=30;BYSECOND=0;BYDAY=MO,TU,WE,TH,FR"},
+		{mustParse("0 15,45 * * * *"), "FREQ=HOURLY;BYMINUTE=15,45;BYSECOND=0"},
+		{mustParse("*/20 * * * * *"), "FREQ=MINUTELY;BYSECOND=0,20,40"},
+		{mustParse("* * * * * *"), "FREQ=SECONDLY"},
+		{mustParse("@yearly"), "FREQ=DAILY;BYHOUR=0;BYMINUTE=0;BYSECOND=0;BYMONTH=1;BYMONTHDAY=1"},
+		{mustParse("0 0 9 1-31 * MON"), "FREQ=DAILY;BYHOUR=9;BYMINUTE=0;BYSECOND=0"},
+		{mustParse("0 0 0 */2 * MON"), "FREQ=DAILY;BYHOUR=0;BYMINUTE=0;BYSECOND=0;BYMONTHDAY=1,3,5,7,9,11,13,15,17,19,21,23,25,27,29,31;BYDAY=MO"},
+		{Every(90 * time.Minute), "FREQ=MINUTELY;INTERVAL=90"},
+		{Every(48 * time.Hour), "FREQ=DAILY;INTERVAL=2"},
+		{Every(61 * time.Second), "FREQ=SECONDLY;INTERVAL=61"},
+	} {
+		rule, err := ExportRRULE(test.schedule)
+		if err != nil || rule != test.expected {
+			t.Errorf("%s: expected %q, got %q, %v", Describe(test.schedule), test.expected, rule, err)
+		}
+	}
+
+	if _, err := ExportRRULE(mustParse("0 0 12 1 * MON")); err == nil {
+		t.Error("expected an error for a schedule restricting both day fields")
+	}
+}
+
+func TestExportICSRecurring(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	now := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	schedule := LocationSchedule{mustParse("0 30 9 * * MON-FRI"), loc}
+	ics, err := exportICS(schedule, "Daily report; all teams", now, 7*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ics = strings.Replace(ics, "\r\n ", "", -1)
+	for _, line := range []string{
+		"BEGIN:VCALENDAR",
+		"DTSTAMP:20240304T100000Z",
+		"UNTIL=20240311T100000Z\r\n",
+		"DTSTART;TZID=America/New_York:20240304T093000",
+		`SUMMARY:Daily report\; all teams`,
+		"RRULE:FREQ=DAILY;BYHOUR=9;BYMINUTE=30;BYSECOND=0;BYDAY=MO,TU,WE,TH,FR;UNTIL=",
+		"END:VCALENDAR",
+	} {
+		if !strings.Contains(ics, line) {
+			t.Errorf("expected %q in the calendar:\n%s", line, ics)
+		}
+	}
+	if strings.Count(ics, "BEGIN:VEVENT") != 1 {
+		t.Errorf("expected a single recurring event:\n%s", ics)
+	}
+}
+
+// An event in a named time zone comes with a VTIMEZONE describing the
+// offsets it may fall in.
+func TestExportICSTimezone(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	now := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	schedule := LocationSchedule{mustParse("0 30 9 * * MON-FRI"), loc}
+	ics, err := exportICS(schedule, "report", now, 30*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"BEGIN:VTIMEZONE",
+		"TZID:America/New_York",
+		"BEGIN:STANDARD",
+		"DTSTART:20240304T050000",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0500",
+		"TZNAME:EST",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20240310T020000",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0400",
+		"TZNAME:EDT",
+		"END:DAYLIGHT",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+	}, "\r\n")
+	if !strings.Contains(ics, want) {
+		t.Errorf("expected the time zone before the event:\n%s", ics)
+	}
+
+	// An unbounded event's time zone describes the coming years.
+	ics, _ = exportICS(schedule, "report", now, 0)
+	if n := strings.Count(ics, "BEGIN:DAYLIGHT"); n != icsZoneYears {
+		t.Errorf("expected %d daylight observances, got %d:\n%s", icsZoneYears, n, ics)
+	}
+
+	ics, _ = exportICS(mustParse("0 30 9 * * MON-FRI"), "report", now, 0)
+	if strings.Contains(ics, "VTIMEZONE") {
+		t.Errorf("expected no time zone for floating times:\n%s", ics)
+	}
+}
+
+func TestExportICSListed(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	schedule := LocationSchedule{mustParse("0 0 12 1 * MON"), time.UTC}
+	ics, err := exportICS(schedule, "Audit", now, 14*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, start := range []string{"20240304T120000Z", "20240311T120000Z"} {
+		if !strings.Contains(ics, "DTSTART:"+start+"\r\n") {
+			t.Errorf("expected an event at %s:\n%s", start, ics)
+		}
+	}
+	if n := strings.Count(ics, "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("expected 2 events, got %d", n)
+	}
+
+	if _, err := exportICS(schedule, "Audit", now, 0); err == nil {
+		t.Error("expected an error listing events without a horizon")
+	}
+}
+
+func TestICSFolding(t *testing.T) {
+	ics := icsCalendar([][]string{{"SUMMARY:" + strings.Repeat("é", 60)}})
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("expected lines of at most 75 octets, got %d", len(line))
+		}
+	}
+	if unfolded := strings.Replace(ics, "\r\n ", "", -1); !strings.Contains(unfolded, strings.Repeat("é", 60)) {
+		t.Errorf("expected the line to unfold intact:\n%s", ics)
+	}
+}
+
+func TestExportFeed(t *testing.T) {
+	c := NewWithLocation(time.UTC)
+	report, _ := c.AddFunc("0 30 9 * * MON-FRI", func() {}, WithName("report"))
+	audit, _ := c.AddFunc("0 0 12 1 * *", func() {})
+	paused, _ := c.AddFunc("@hourly", func() {})
+	c.Pause(paused)
+
+	now := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	ics := c.exportFeed(c.Entries(), 3, now)
+	for _, event := range []string{
+		"DTSTART:20240304T093000Z\r\nSUMMARY:report",
+		"DTSTART:20240305T093000Z\r\nSUMMARY:report",
+		"DTSTART:20240306T093000Z\r\nSUMMARY:report",
+		"DTSTART:20240301T120000Z\r\nSUMMARY:0 0 12 1 * *",
+		"DTSTART:20240501T120000Z\r\nSUMMARY:0 0 12 1 * *",
+	} {
+		if !strings.Contains(ics, event) {
+			t.Errorf("expected an event %q:\n%s", event, ics)
+		}
+	}
+	if n := strings.Count(ics, "BEGIN:VEVENT"); n != 6 {
+		t.Errorf("expected 6 events, leaving out the paused entry, got %d", n)
+	}
+
+	ics = c.exportFeed([]*Entry{c.Entry(audit)}, 1, now)
+	if n := strings.Count(ics, "BEGIN:VEVENT"); n != 1 || strings.Contains(ics, "report") {
+		t.Errorf("expected a single event of the audit:\n%s", ics)
+	}
+
+	ics = c.ExportFeed(2, report, 999)
+	if n := strings.Count(ics, "SUMMARY:report"); n != 2 || strings.Count(ics, "BEGIN:VEVENT") != 2 {
+		t.Errorf("expected 2 events of the report:\n%s", ics)
+	}
+
+	loc, _ := time.LoadLocation("Europe/Paris")
+	ics = c.exportFeed([]*Entry{c.Entry(report), c.Entry(audit)}, 1, now.In(loc))
+	if n := strings.Count(ics, "TZID:Europe/Paris"); n != 1 || !strings.Contains(ics, "DTSTART;TZID=Europe/Paris:20240301T120000") {
+		t.Errorf("expected a single time zone for the zoned events:\n%s", ics)
+	}
+}
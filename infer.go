@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+// Infer returns the simplest spec, in the syntax of Parse, whose activations
+// from the first of the times to the last are exactly the times, for
+// migrating legacy jobs whose schedules are only known from the logs of
+// their runs.  Each field of the spec is either "*" or the values the times
+// take in it, and the spec leaving the most fields "*", and then the
+// shortest, is returned, simplified as Simplify simplifies it.  The times
+// are read in the location of the first.
+//
+// It returns false if there are fewer than two distinct times, any is not
+// a whole second, or no such spec matches them all, as when a run was made
+// by hand at another time of day.  A missed run is taken to be no run: the
+// spec found then lists the days, or other values, that had runs.
+func Infer(times []time.Time) (string, bool) {
+	if len(times) == 0 {
+		return "", false
+	}
+	loc := times[0].Location()
+	sorted := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		if t.Nanosecond() != 0 {
+			return "", false
+		}
+		sorted = append(sorted, t.In(loc))
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	distinct := sorted[:1]
+	for _, t := range sorted[1:] {
+		if !t.Equal(distinct[len(distinct)-1]) {
+			distinct = append(distinct, t)
+		}
+	}
+	if len(distinct) < 2 {
+		return "", false
+	}
+
+	// The values the times take in each field.
+	var observed SpecSchedule
+	for _, t := range distinct {
+		observed.Second |= 1 << uint(t.Second())
+		observed.Minute |= 1 << uint(t.Minute())
+		observed.Hour |= 1 << uint(t.Hour())
+		observed.Dom |= 1 << uint(t.Day())
+		observed.Month |= 1 << uint(t.Month())
+		observed.Dow |= 1 << uint(t.Weekday())
+	}
+
+	var best string
+	bestRestricted := 0
+	for mask := 0; mask < 1<<4; mask++ {
+		// Restrict the day of month, the day of week, or neither, as the
+		// two cannot both restrict the day.
+		for days := 0; days < 3; days++ {
+			s := SpecSchedule{
+				Second: all(seconds),
+				Minute: all(minutes),
+				Hour:   all(hours),
+				Dom:    all(dom),
+				Month:  all(months),
+				Dow:    all(dow),
+			}
+			restricted := 0
+			for i, f := range []struct {
+				field    *uint64
+				observed uint64
+			}{
+				{&s.Second, observed.Second},
+				{&s.Minute, observed.Minute},
+				{&s.Hour, observed.Hour},
+				{&s.Month, observed.Month},
+			} {
+				if mask&(1<<uint(i)) != 0 {
+					*f.field = f.observed
+					restricted++
+				}
+			}
+			switch days {
+			case 1:
+				s.Dom = observed.Dom
+				restricted++
+			case 2:
+				s.Dow = observed.Dow
+				restricted++
+			}
+			if best != "" && restricted > bestRestricted || !activatesAt(&s, distinct) {
+				continue
+			}
+			spec, err := Simplify(s.String())
+			if err != nil {
+				continue
+			}
+			if best == "" || restricted < bestRestricted || len(spec) < len(best) {
+				best, bestRestricted = spec, restricted
+			}
+		}
+	}
+	return best, best != ""
+}
+
+// activatesAt reports whether the activations of the schedule from the
+// first of the times to the last are exactly the times, in order.
+func activatesAt(s *SpecSchedule, times []time.Time) bool {
+	next := times[0].Add(-time.Nanosecond)
+	for _, t := range times {
+		if next = s.Next(next); !next.Equal(t) {
+			return false
+		}
+	}
+	return true
+}
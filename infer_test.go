@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInfer(t *testing.T) {
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2024, time.January, day, hour, min, 0, 0, time.UTC)
+	}
+	// every returns the times from start, d apart, for n times.
+	every := func(start time.Time, d time.Duration, n int) []time.Time {
+		var times []time.Time
+		for i := 0; i < n; i++ {
+			times = append(times, start.Add(time.Duration(i)*d))
+		}
+		return times
+	}
+	var weekdays []time.Time
+	for day := 1; day <= 31; day++ {
+		if t := at(day, 9, 30); t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			weekdays = append(weekdays, t)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		times    []time.Time
+		expected string
+	}{
+		{"quarter hours", every(at(1, 10, 0), 15*time.Minute, 20), "0 */15 * * * *"},
+		{"daily", every(at(1, 9, 0), 24*time.Hour, 10), "0 0 9 * * *"},
+		{"weekdays", weekdays, "0 30 9 * * 1-5"},
+		{"first of the month", []time.Time{at(1, 0, 0), at(1, 0, 0).AddDate(0, 1, 0), at(1, 0, 0).AddDate(0, 2, 0)}, "0 0 0 1 * *"},
+		{"twice a day", []time.Time{at(1, 8, 0), at(1, 20, 0), at(2, 8, 0), at(2, 20, 0), at(3, 8, 0)}, "0 0 8,20 * * *"},
+		// A missed run is taken to be no run.
+		{"missed run", []time.Time{at(1, 9, 0), at(2, 9, 0), at(4, 9, 0)}, "0 0 9 1-2,4 * *"},
+		// Duplicates and order do not matter.
+		{"unordered", []time.Time{at(2, 9, 0), at(1, 9, 0), at(2, 9, 0), at(3, 9, 0)}, "0 0 9 * * *"},
+	}
+	for _, test := range tests {
+		spec, ok := Infer(test.times)
+		if !ok || spec != test.expected {
+			t.Errorf("%s: expected %q, got %q, %v", test.name, test.expected, spec, ok)
+		}
+	}
+
+	for name, times := range map[string][]time.Time{
+		"none":         nil,
+		"one":          {at(1, 9, 0)},
+		"fractional":   {at(1, 9, 0), at(2, 9, 0).Add(time.Millisecond)},
+		"inconsistent": {at(1, 9, 0), at(2, 17, 0)},
+	} {
+		if spec, ok := Infer(times); ok {
+			t.Errorf("%s: expected no spec, got %q", name, spec)
+		}
+	}
+}
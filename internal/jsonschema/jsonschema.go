@@ -0,0 +1,106 @@
+// Package jsonschema derives JSON Schemas from Go types, for the schemas
+// published by package schema and the OpenAPI document of package admin.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema is a JSON Schema.
+type Schema map[string]interface{}
+
+// Of returns the JSON Schema of the JSON encoding of v's type.  Struct fields
+// are required unless they are tagged omitempty.  It panics on types
+// encoding/json cannot encode to a fixed shape, such as interfaces and
+// channels.
+func Of(v interface{}) Schema {
+	return of(reflect.TypeOf(v))
+}
+
+// Marshal returns the indented JSON of the schema.
+func (s Schema) Marshal() ([]byte, error) {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// of returns the schema of the JSON encoding of t.
+func of(t reflect.Type) Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case t.Implements(marshalerType):
+		panic(fmt.Sprintf("jsonschema: %s has a custom JSON encoding", t))
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Schema{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": of(t.Elem())}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			break
+		}
+		return Schema{"type": "object", "additionalProperties": of(t.Elem())}
+	case reflect.Struct:
+		return ofStruct(t)
+	}
+	panic(fmt.Sprintf("jsonschema: cannot describe the JSON encoding of %s", t))
+}
+
+// ofStruct returns the schema of the JSON encoding of a struct type.
+// Embedded structs are not flattened, as the serialized types have none.
+func ofStruct(t reflect.Type) Schema {
+	properties := Schema{}
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, opts := f.Name, ""
+		if tag, ok := f.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+			if i := strings.Index(tag, ","); i >= 0 {
+				tag, opts = tag[:i], tag[i:]
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		properties[name] = of(f.Type)
+		if !strings.Contains(opts, ",omitempty") {
+			required = append(required, name)
+		}
+	}
+	return Schema{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,46 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOf(t *testing.T) {
+	type run struct {
+		ID      uint64            `json:"id"`
+		Started *time.Time        `json:"started,omitempty"`
+		Tags    []string          `json:"tags,omitempty"`
+		Labels  map[string]string `json:"labels"`
+		Ratio   float64
+		Ignored string `json:"-"`
+		hidden  string
+	}
+	s := Of(run{})
+	if s["type"] != "object" || s["additionalProperties"] != false {
+		t.Errorf("expected a closed object, got %v", s)
+	}
+	properties := s["properties"].(Schema)
+	expected := Schema{
+		"id":      Schema{"type": "integer", "minimum": 0},
+		"started": Schema{"type": "string", "format": "date-time"},
+		"tags":    Schema{"type": "array", "items": Schema{"type": "string"}},
+		"labels":  Schema{"type": "object", "additionalProperties": Schema{"type": "string"}},
+		"Ratio":   Schema{"type": "number"},
+	}
+	if !reflect.DeepEqual(properties, expected) {
+		t.Errorf("expected properties %v, got %v", expected, properties)
+	}
+	if required := s["required"].([]string); !reflect.DeepEqual(required, []string{"id", "labels", "Ratio"}) {
+		t.Errorf("expected id, labels and Ratio to be required, got %v", required)
+	}
+}
+
+func TestOfUnsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an interface field")
+		}
+	}()
+	Of(struct{ Value interface{} }{})
+}
@@ -0,0 +1,27 @@
+package cron
+
+import (
+	"math/rand"
+	"time"
+)
+
+// WithJitter returns an EntryOption that delays each scheduled run of the
+// entry by a random duration in [0, max), so that entries sharing a spec
+// don't all hit shared downstream services at the same instant.
+//
+// The jitter is applied when the run is dispatched: the entry's Next and
+// Prev times, and the run's scheduled time and RunKey, are those of the
+// schedule.  Runs started with Trigger are not delayed.
+func WithJitter(max time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.Jitter = max
+	}
+}
+
+// jitter returns a random delay for the entry's next run.
+func (e *Entry) jitter() time.Duration {
+	if e.Jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(e.Jitter)))
+}
@@ -0,0 +1,38 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitterBounds(t *testing.T) {
+	e := &Entry{Jitter: 10 * time.Millisecond}
+	for i := 0; i < 100; i++ {
+		if d := e.jitter(); d < 0 || d >= e.Jitter {
+			t.Fatalf("jitter %v out of range", d)
+		}
+	}
+	if d := (&Entry{}).jitter(); d != 0 {
+		t.Errorf("expected no jitter, got %v", d)
+	}
+}
+
+// A jittered run starts after its scheduled time, but keeps it as its
+// scheduled time.
+func TestJitterDelaysRun(t *testing.T) {
+	store := NewMemoryStore()
+	cron := New(WithStore(store))
+	id, _ := cron.AddFunc("* * * * * ?", func() {}, WithJitter(200*time.Millisecond))
+	cron.Start()
+	defer cron.Stop()
+
+	time.Sleep(ONE_SECOND + 200*time.Millisecond)
+	history, _ := cron.History(id, time.Time{}, 0)
+	if len(history) == 0 {
+		t.Fatal("expected a run")
+	}
+	run := history[len(history)-1]
+	if run.Scheduled.Nanosecond() != 0 || run.Start.Sub(run.Scheduled) > 250*time.Millisecond {
+		t.Errorf("unexpected run times: scheduled %v, started %v", run.Scheduled, run.Start)
+	}
+}
@@ -0,0 +1,353 @@
+// Package jobfile registers cron entries from a YAML file of job definitions:
+//
+//	jobs:
+//	  - name: report
+//	    spec: "0 30 9 * * MON-FRI"
+//	    timezone: America/New_York
+//	    tags: [reports, daily]
+//	    overlap: skip
+//	    timeout: 10m
+//	    command: /usr/local/bin/report --daily
+//	  - name: cleanup
+//	    spec: "@every 1h"
+//	    handler: cleanup
+//
+// The document is either a sequence of definitions or a mapping holding one
+// under "jobs".  Each definition has a unique name, a spec in the syntax of
+// the Cron it is registered with, and either a command, run with the shell as
+// cron.CommandJob does, or a handler, naming a job supplied by the caller.
+// The other fields are optional:
+//
+//	timezone   an IANA time zone name the spec is interpreted in
+//	tags       a list of tags, see cron.WithTags
+//	overlap    allow (the default), skip or wait, see Overlap
+//	timeout    a duration, such as "90s", after which the run's context is
+//	           cancelled
+//
+// Files are read with a YAML parser supporting the subset of YAML such
+// definitions need: block mappings and sequences, flow sequences such as
+// the tags above, and plain and quoted scalars on a single line.  Errors,
+// from the syntax to an unknown handler, are reported with the file and line
+// they concern.
+package jobfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// Overlap selects what happens when a job is activated while its previous
+// run is still in progress.
+type Overlap int
+
+const (
+	// OverlapAllow runs the job again alongside the previous run.
+	OverlapAllow Overlap = iota
+
+	// OverlapSkip skips the activation, which completes without running
+	// the job.
+	OverlapSkip
+
+	// OverlapWait runs the job once the previous run has finished.
+	OverlapWait
+)
+
+var overlapNames = map[string]Overlap{
+	"allow": OverlapAllow,
+	"skip":  OverlapSkip,
+	"wait":  OverlapWait,
+}
+
+// Definition is a job defined in a file.
+type Definition struct {
+	// File is the name of the file the job was read from.
+	File string
+
+	// Line is the 1-based line number the definition starts on.
+	Line int
+
+	Name     string
+	Spec     string
+	TimeZone string
+	Tags     []string
+	Overlap  Overlap
+
+	// Timeout is zero for runs without a timeout.
+	Timeout time.Duration
+
+	// Exactly one of Command and Handler is set.
+	Command string
+	Handler string
+
+	// lines holds the line of each field, for errors found on
+	// registration.
+	lines map[string]int
+}
+
+// ParseError describes a problem with a definition, or with the syntax of a
+// file.
+type ParseError struct {
+	File string
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+}
+
+// Parse reads the definitions in a file.  The name is used to identify the
+// file in the returned definitions and errors.
+func Parse(name string, r io.Reader) ([]Definition, error) {
+	defs, err := parse(r)
+	if e, ok := err.(*yamlError); ok {
+		return nil, &ParseError{File: name, Line: e.line, Err: e.err}
+	}
+	for i := range defs {
+		defs[i].File = name
+	}
+	return defs, err
+}
+
+// parse reads the definitions in a file, returning yamlErrors for problems
+// with its contents.
+func parse(r io.Reader) ([]Definition, error) {
+	root, err := parseYAML(r)
+	if err != nil || root == nil {
+		return nil, err
+	}
+	if root.kind == mappingNode {
+		if len(root.keys) != 1 || root.keys[0].value != "jobs" {
+			return nil, errorf(root.line, "expected a sequence of jobs, or a mapping holding one under \"jobs\"")
+		}
+		root = root.vals[0]
+	}
+	if root.kind != sequenceNode {
+		return nil, errorf(root.line, "expected a sequence of jobs")
+	}
+
+	var defs []Definition
+	names := make(map[string]int)
+	for _, item := range root.items {
+		def, err := parseDefinition(item)
+		if err != nil {
+			return nil, err
+		}
+		line := def.lines["name"]
+		if prev, ok := names[def.Name]; ok {
+			return nil, errorf(line, "job %q is already defined on line %d", def.Name, prev)
+		}
+		names[def.Name] = line
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// parseDefinition parses and validates a definition.
+func parseDefinition(n *node) (Definition, error) {
+	def := Definition{Line: n.line, lines: make(map[string]int)}
+	if n.kind != mappingNode {
+		return def, errorf(n.line, "expected a job definition")
+	}
+	for i, k := range n.keys {
+		v := n.vals[i]
+		def.lines[k.value] = k.line
+		if k.value == "tags" {
+			if v.kind != sequenceNode {
+				return def, errorf(v.line, "expected a list of tags")
+			}
+			for _, tag := range v.items {
+				if tag.kind != scalarNode {
+					return def, errorf(tag.line, "expected a tag")
+				}
+				def.Tags = append(def.Tags, tag.value)
+			}
+			continue
+		}
+		if v.kind != scalarNode {
+			return def, errorf(v.line, "expected a single value for %s", k.value)
+		}
+		switch k.value {
+		case "name":
+			def.Name = v.value
+		case "spec":
+			def.Spec = v.value
+		case "timezone":
+			def.TimeZone = v.value
+		case "overlap":
+			overlap, ok := overlapNames[v.value]
+			if !ok {
+				return def, errorf(v.line, "overlap must be allow, skip or wait, not %q", v.value)
+			}
+			def.Overlap = overlap
+		case "timeout":
+			timeout, err := time.ParseDuration(v.value)
+			if err != nil || timeout <= 0 {
+				return def, errorf(v.line, "invalid timeout %q", v.value)
+			}
+			def.Timeout = timeout
+		case "command":
+			def.Command = v.value
+		case "handler":
+			def.Handler = v.value
+		default:
+			return def, errorf(k.line, "unknown field %q", k.value)
+		}
+	}
+	switch {
+	case def.Name == "":
+		return def, errorf(n.line, "job has no name")
+	case def.Spec == "":
+		return def, errorf(n.line, "job %q has no spec", def.Name)
+	case (def.Command == "") == (def.Handler == ""):
+		return def, errorf(n.line, "job %q must have either a command or a handler", def.Name)
+	}
+	return def, nil
+}
+
+// Handlers maps the names definitions may give as their handler to the jobs
+// they run.
+type Handlers map[string]cron.Job
+
+// Load reads the definitions in the file at path and registers them with c,
+// see Register.
+func Load(c *cron.Cron, path string, handlers Handlers) ([]cron.EntryID, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	defs, err := Parse(path, f)
+	if err != nil {
+		return nil, err
+	}
+	return Register(c, defs, handlers)
+}
+
+// Register adds an entry to c for each definition, returning their IDs in
+// order.  If a definition's spec is invalid for c or its handler is unknown,
+// no entry is added and the error is returned.
+func Register(c *cron.Cron, defs []Definition, handlers Handlers) ([]cron.EntryID, error) {
+	type pending struct {
+		schedule cron.Schedule
+		job      cron.Job
+		opts     []cron.EntryOption
+	}
+	var entries []pending
+	for _, def := range defs {
+		schedule, err := c.Parse(def.Spec)
+		if err != nil {
+			return nil, def.errorf("spec", "%v", err)
+		}
+		if def.TimeZone != "" {
+			location, err := time.LoadLocation(def.TimeZone)
+			if err != nil {
+				return nil, def.errorf("timezone", "unknown time zone %q", def.TimeZone)
+			}
+			schedule = cron.LocationSchedule{Schedule: schedule, Location: location}
+		}
+		var job cron.Job = cron.CommandJob{Command: def.Command}
+		if def.Handler != "" {
+			var ok bool
+			if job, ok = handlers[def.Handler]; !ok {
+				return nil, def.errorf("handler", "unknown handler %q", def.Handler)
+			}
+		}
+		spec := def.Spec
+		entries = append(entries, pending{schedule, wrap(job, def), []cron.EntryOption{
+			cron.WithName(def.Name),
+			cron.WithTags(def.Tags...),
+			func(e *cron.Entry) { e.Spec = spec },
+		}})
+	}
+
+	ids := make([]cron.EntryID, len(entries))
+	for i, e := range entries {
+		ids[i] = c.Schedule(e.schedule, e.job, e.opts...)
+	}
+	return ids, nil
+}
+
+// errorf returns a ParseError at the line of the given field.
+func (def Definition) errorf(field, format string, args ...interface{}) error {
+	line, ok := def.lines[field]
+	if !ok {
+		line = def.Line
+	}
+	return &ParseError{File: def.File, Line: line, Err: fmt.Errorf(format, args...)}
+}
+
+// wrap applies the definition's overlap policy and timeout to the job.
+func wrap(job cron.Job, def Definition) cron.Job {
+	if def.Timeout > 0 {
+		job = timeoutJob{job, def.Timeout}
+	}
+	switch def.Overlap {
+	case OverlapSkip:
+		job = &skipJob{job: job}
+	case OverlapWait:
+		job = &waitJob{job: job}
+	}
+	return job
+}
+
+// runContext runs the job with the context if it accepts one.
+func runContext(ctx context.Context, job cron.Job) error {
+	if cj, ok := job.(cron.ContextJob); ok {
+		return cj.RunContext(ctx)
+	}
+	job.Run()
+	return nil
+}
+
+// timeoutJob cancels the context of a job's runs after a timeout.  Only
+// jobs implementing cron.ContextJob can be stopped.
+type timeoutJob struct {
+	job     cron.Job
+	timeout time.Duration
+}
+
+func (j timeoutJob) Run() { j.RunContext(context.Background()) }
+
+func (j timeoutJob) RunContext(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+	return runContext(ctx, j.job)
+}
+
+// skipJob skips runs while a previous one is in progress.
+type skipJob struct {
+	job     cron.Job
+	running int32
+}
+
+func (j *skipJob) Run() { j.RunContext(context.Background()) }
+
+func (j *skipJob) RunContext(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&j.running, 0, 1) {
+		return nil
+	}
+	defer atomic.StoreInt32(&j.running, 0)
+	return runContext(ctx, j.job)
+}
+
+// waitJob runs a job's runs one at a time.
+type waitJob struct {
+	job cron.Job
+	mu  sync.Mutex
+}
+
+func (j *waitJob) Run() { j.RunContext(context.Background()) }
+
+func (j *waitJob) RunContext(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return runContext(ctx, j.job)
+}
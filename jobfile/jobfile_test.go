@@ -0,0 +1,192 @@
+package jobfile
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+const jobs = `
+jobs:
+  - name: report
+    spec: "0 30 9 * * MON-FRI"
+    timezone: America/New_York
+    tags: [reports, daily]
+    overlap: skip
+    timeout: 10m
+    command: /usr/local/bin/report --daily
+
+  - name: cleanup
+    spec: "@every 1h"
+    handler: cleanup
+`
+
+func TestParse(t *testing.T) {
+	defs, err := Parse("jobs.yaml", strings.NewReader(jobs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Definition{{
+		File:     "jobs.yaml",
+		Line:     3,
+		Name:     "report",
+		Spec:     "0 30 9 * * MON-FRI",
+		TimeZone: "America/New_York",
+		Tags:     []string{"reports", "daily"},
+		Overlap:  OverlapSkip,
+		Timeout:  10 * time.Minute,
+		Command:  "/usr/local/bin/report --daily",
+	}, {
+		File:    "jobs.yaml",
+		Line:    11,
+		Name:    "cleanup",
+		Spec:    "@every 1h",
+		Handler: "cleanup",
+	}}
+	if len(defs) != len(expected) {
+		t.Fatalf("expected %d definitions, got %d", len(expected), len(defs))
+	}
+	for i, def := range defs {
+		def.lines = nil
+		if !reflect.DeepEqual(def, expected[i]) {
+			t.Errorf("(expected) %+v != %+v (actual)", expected[i], def)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, test := range []struct {
+		doc, err string
+	}{
+		{"- name: a\n  spec: '@daily'\n", "f:1: job \"a\" must have either a command or a handler"},
+		{"- name: a\n  spec: '@daily'\n  command: x\n  handler: y\n", "f:1: job \"a\" must have either a command or a handler"},
+		{"- spec: '@daily'\n  command: x\n", "f:1: job has no name"},
+		{"- name: a\n  command: x\n", "f:1: job \"a\" has no spec"},
+		{"- name: a\n  spec: '@daily'\n  command: x\n  retries: 3\n", "f:4: unknown field \"retries\""},
+		{"- name: a\n  spec: '@daily'\n  command: x\n  overlap: queue\n", "f:4: overlap must be allow, skip or wait, not \"queue\""},
+		{"- name: a\n  spec: '@daily'\n  command: x\n  timeout: soon\n", "f:4: invalid timeout \"soon\""},
+		{"- name: a\n  spec: '@daily'\n  command: x\n  tags: urgent\n", "f:4: expected a list of tags"},
+		{"- name: a\n  spec: '@daily'\n  command: x\n- name: a\n  spec: '@daily'\n  command: x\n", "f:4: job \"a\" is already defined on line 1"},
+		{"cron:\n  - name: a\n", "f:1: expected a sequence of jobs, or a mapping holding one under \"jobs\""},
+		{"jobs:\n  - name: a\n   spec: x\n", "f:3: unexpected indentation"},
+	} {
+		_, err := Parse("f", strings.NewReader(test.doc))
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%q: expected %q, got %v", test.doc, test.err, err)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jobfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "jobs.yaml")
+	if err := ioutil.WriteFile(path, []byte(jobs), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := cron.New()
+	if _, err := Load(c, path, Handlers{}); err == nil || err.Error() != path+":13: unknown handler \"cleanup\"" {
+		t.Errorf("expected an unknown handler error, got %v", err)
+	}
+	if len(c.Entries()) != 0 {
+		t.Errorf("expected no entries after an error, got %d", len(c.Entries()))
+	}
+
+	ids, err := Load(c, path, Handlers{"cleanup": cron.FuncJob(func() {})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := c.Entry(ids[0])
+	if report.Name != "report" || report.Spec != "0 30 9 * * MON-FRI" || !reflect.DeepEqual(report.Tags, []string{"reports", "daily"}) {
+		t.Errorf("unexpected entry: %+v", report)
+	}
+	if s, ok := report.Schedule.(cron.LocationSchedule); !ok || s.Location.String() != "America/New_York" {
+		t.Errorf("expected the schedule in America/New_York, got %v", report.Schedule)
+	}
+	if cleanup := c.Entry(ids[1]); cleanup.Name != "cleanup" {
+		t.Errorf("unexpected entry: %+v", cleanup)
+	}
+}
+
+func TestRegisterInvalidSpec(t *testing.T) {
+	defs, err := Parse("f", strings.NewReader("- name: a\n  command: x\n  spec: 'not a spec'\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Register(cron.New(), defs, nil); err == nil || !strings.HasPrefix(err.Error(), "f:3: ") {
+		t.Errorf("expected an error on line 3, got %v", err)
+	}
+}
+
+func TestOverlapSkip(t *testing.T) {
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	job := wrap(cron.FuncJob(func() {
+		started <- struct{}{}
+		<-release
+	}), Definition{Overlap: OverlapSkip}).(cron.ContextJob)
+
+	done := make(chan struct{})
+	go func() {
+		job.RunContext(context.Background())
+		close(done)
+	}()
+	<-started
+	job.RunContext(context.Background())
+	close(release)
+	<-done
+	if len(started) != 0 {
+		t.Error("expected the overlapping run to be skipped")
+	}
+}
+
+func TestOverlapWait(t *testing.T) {
+	var mu sync.Mutex
+	running, max := 0, 0
+	job := wrap(cron.FuncJob(func() {
+		mu.Lock()
+		running++
+		if running > max {
+			max = running
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}), Definition{Overlap: OverlapWait})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job.Run()
+		}()
+	}
+	wg.Wait()
+	if max != 1 {
+		t.Errorf("expected runs one at a time, %d ran at once", max)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	job := wrap(cron.ContextFuncJob(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), Definition{Timeout: 10 * time.Millisecond}).(cron.ContextJob)
+	if err := job.RunContext(context.Background()); err != context.DeadlineExceeded {
+		t.Errorf("expected the run to time out, got %v", err)
+	}
+}
@@ -0,0 +1,343 @@
+package jobfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The kinds of nodes of a YAML document.
+const (
+	scalarNode = iota
+	sequenceNode
+	mappingNode
+)
+
+// node is a value of a YAML document, with the line it starts on.
+type node struct {
+	kind  int
+	line  int
+	value string  // of a scalar
+	items []*node // of a sequence
+	keys  []*node // of a mapping, in order, as scalars
+	vals  []*node // of a mapping, matching keys
+}
+
+// yamlError is an error at a line of a YAML document.
+type yamlError struct {
+	line int
+	err  error
+}
+
+func (e *yamlError) Error() string {
+	return e.err.Error()
+}
+
+func errorf(line int, format string, args ...interface{}) error {
+	return &yamlError{line, fmt.Errorf(format, args...)}
+}
+
+// yamlLine is a line of a YAML document, stripped of its comment and its
+// indentation.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// parseYAML parses a document in the subset of YAML job files use: block
+// mappings and sequences, flow sequences of scalars, and plain, single- and
+// double-quoted scalars on a single line.  Other constructs, such as
+// anchors, flow mappings and multi-line scalars, are reported as errors.  An
+// empty document is a nil node.
+func parseYAML(r io.Reader) (*node, error) {
+	var lines []yamlLine
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		raw := scanner.Text()
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, errorf(n, "tabs cannot indent YAML")
+		}
+		indent := len(raw) - len(text)
+		text = strings.TrimSpace(stripComment(text))
+		if text == "" || (indent == 0 && (text == "---" || strings.HasPrefix(text, "%"))) {
+			continue
+		}
+		if indent == 0 && text == "..." {
+			break
+		}
+		lines = append(lines, yamlLine{n, indent, text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	p := &yamlParser{lines: lines}
+	root, err := p.block(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.i < len(lines) {
+		if lines[p.i].indent == lines[0].indent {
+			return nil, errorf(lines[p.i].number, "expected a single value: %s", lines[p.i].text)
+		}
+		return nil, errorf(lines[p.i].number, "unexpected indentation")
+	}
+	return root, nil
+}
+
+// stripComment removes a comment from the end of a line.
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		}
+	}
+	return text
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	i     int
+}
+
+// block parses the mapping or sequence whose lines start at the given
+// indentation, or a scalar on a line of its own.
+func (p *yamlParser) block(indent int) (*node, error) {
+	switch line := p.lines[p.i]; {
+	case isItem(line.text):
+		return p.sequence(indent)
+	case !isKey(line.text):
+		p.i++
+		return scalar(line.text, line.number)
+	}
+	return p.mapping(indent)
+}
+
+func isItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// sequence parses the items of a block sequence.  An item may start on the
+// line of its dash, in which case that line is parsed as if the dash were
+// indentation.
+func (p *yamlParser) sequence(indent int) (*node, error) {
+	seq := &node{kind: sequenceNode, line: p.lines[p.i].number}
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent && isItem(p.lines[p.i].text) {
+		line := p.lines[p.i]
+		rest := strings.TrimLeft(line.text[1:], " ")
+		var item *node
+		var err error
+		switch {
+		case rest == "":
+			p.i++
+			item, err = p.nested(indent, line.number)
+		case isItem(rest) || isKey(rest):
+			p.lines[p.i] = yamlLine{line.number, indent + len(line.text) - len(rest), rest}
+			item, err = p.block(p.lines[p.i].indent)
+		default:
+			p.i++
+			item, err = scalar(rest, line.number)
+		}
+		if err != nil {
+			return nil, err
+		}
+		seq.items = append(seq.items, item)
+	}
+	return seq, nil
+}
+
+// mapping parses the keys and values of a block mapping.
+func (p *yamlParser) mapping(indent int) (*node, error) {
+	m := &node{kind: mappingNode, line: p.lines[p.i].number}
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent {
+		line := p.lines[p.i]
+		if isItem(line.text) {
+			return nil, errorf(line.number, "expected a key, found a sequence item")
+		}
+		k, rest, err := splitKey(line.text, line.number)
+		if err != nil {
+			return nil, err
+		}
+		for _, prev := range m.keys {
+			if prev.value == k.value {
+				return nil, errorf(line.number, "duplicate key %q", k.value)
+			}
+		}
+		p.i++
+		var val *node
+		switch {
+		case rest != "":
+			val, err = scalar(rest, line.number)
+		case p.i < len(p.lines) && p.lines[p.i].indent == indent && isItem(p.lines[p.i].text):
+			// A sequence may be indented as much as its key.
+			val, err = p.sequence(indent)
+		default:
+			val, err = p.nested(indent, line.number)
+		}
+		if err != nil {
+			return nil, err
+		}
+		m.keys = append(m.keys, k)
+		m.vals = append(m.vals, val)
+	}
+	return m, nil
+}
+
+// nested parses the block following a line at the given indentation, or
+// returns an empty scalar if the next line is not indented further.
+func (p *yamlParser) nested(indent, number int) (*node, error) {
+	if p.i < len(p.lines) && p.lines[p.i].indent > indent {
+		return p.block(p.lines[p.i].indent)
+	}
+	return &node{kind: scalarNode, line: number}, nil
+}
+
+// isKey reports whether text starts with a mapping key.
+func isKey(text string) bool {
+	_, _, err := splitKey(text, 0)
+	return err == nil
+}
+
+// splitKey splits a line of a mapping into its key and the text of its
+// value.
+func splitKey(text string, number int) (*node, string, error) {
+	end := 0
+	if text[0] == '"' || text[0] == '\'' {
+		end = closingQuote(text)
+		if end < 0 {
+			return nil, "", errorf(number, "unterminated string: %s", text)
+		}
+		end++
+	}
+	i := strings.Index(text[end:], ": ")
+	if i < 0 && strings.HasSuffix(text[end:], ":") {
+		i = len(text) - end - 1
+	}
+	if i < 0 {
+		return nil, "", errorf(number, "expected \"key: value\": %s", text)
+	}
+	k, err := scalar(strings.TrimSpace(text[:end+i]), number)
+	if err != nil {
+		return nil, "", err
+	}
+	return k, strings.TrimSpace(text[end+i+1:]), nil
+}
+
+// closingQuote returns the index of the quote closing the string text
+// starts with, or -1.
+func closingQuote(text string) int {
+	for i := 1; i < len(text); i++ {
+		switch {
+		case text[0] == '"' && text[i] == '\\':
+			i++
+		case text[i] == text[0]:
+			if text[0] == '\'' && i+1 < len(text) && text[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// scalar parses a value given on a single line: a scalar, or a flow
+// sequence of scalars.
+func scalar(text string, number int) (*node, error) {
+	n := &node{kind: scalarNode, line: number}
+	if text == "" {
+		return n, nil
+	}
+	switch text[0] {
+	case '[':
+		if !strings.HasSuffix(text, "]") {
+			return nil, errorf(number, "unterminated sequence: %s", text)
+		}
+		n.kind = sequenceNode
+		inner := strings.TrimSpace(text[1 : len(text)-1])
+		for inner != "" {
+			item := inner
+			if inner[0] == '"' || inner[0] == '\'' {
+				end := closingQuote(inner)
+				if end < 0 {
+					return nil, errorf(number, "unterminated string: %s", inner)
+				}
+				item = inner[:end+1]
+			} else if i := strings.Index(inner, ","); i >= 0 {
+				item = inner[:i]
+			}
+			rest := strings.TrimSpace(inner[len(item):])
+			if rest != "" && rest[0] != ',' {
+				return nil, errorf(number, "expected a comma: %s", inner)
+			}
+			v, err := scalar(strings.TrimSpace(item), number)
+			if err != nil {
+				return nil, err
+			}
+			if v.kind != scalarNode {
+				return nil, errorf(number, "nested flow sequences are not supported: %s", text)
+			}
+			n.items = append(n.items, v)
+			inner = strings.TrimSpace(strings.TrimPrefix(rest, ","))
+		}
+	case '"':
+		if closingQuote(text) != len(text)-1 {
+			return nil, errorf(number, "unterminated string: %s", text)
+		}
+		value, err := unescape(text[1:len(text)-1], number)
+		if err != nil {
+			return nil, err
+		}
+		n.value = value
+	case '\'':
+		if closingQuote(text) != len(text)-1 {
+			return nil, errorf(number, "unterminated string: %s", text)
+		}
+		n.value = strings.Replace(text[1:len(text)-1], "''", "'", -1)
+	case '{', '&', '*', '!', '|', '>':
+		return nil, errorf(number, "unsupported YAML: %s", text)
+	default:
+		n.value = text
+	}
+	return n, nil
+}
+
+// unescape resolves the escape sequences of a double-quoted string.
+func unescape(text string, number int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' {
+			b.WriteByte(text[i])
+			continue
+		}
+		if i++; i == len(text) {
+			return "", errorf(number, "unterminated escape sequence")
+		}
+		switch text[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case '\\', '"', '/':
+			b.WriteByte(text[i])
+		default:
+			return "", errorf(number, "unsupported escape sequence \\%c", text[i])
+		}
+	}
+	return b.String(), nil
+}
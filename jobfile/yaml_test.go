@@ -0,0 +1,100 @@
+package jobfile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// simplify converts a node to plain values, for comparison.
+func simplify(n *node) interface{} {
+	switch n.kind {
+	case sequenceNode:
+		items := []interface{}{}
+		for _, item := range n.items {
+			items = append(items, simplify(item))
+		}
+		return items
+	case mappingNode:
+		m := map[string]interface{}{}
+		for i, k := range n.keys {
+			m[k.value] = simplify(n.vals[i])
+		}
+		return m
+	}
+	return n.value
+}
+
+func TestParseYAML(t *testing.T) {
+	const doc = `---
+# Jobs
+jobs:
+- name: "quoted: \"value\"" # comment
+  tags: [a, 'b, c', "d"]
+  list:
+    - x
+    -
+      y
+  nested:
+    - - one
+      - two
+- 'it''s': plain # text
+  url: http://example.com/#anchor
+  empty:
+`
+	root, err := parseYAML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"jobs": []interface{}{
+			map[string]interface{}{
+				"name":   `quoted: "value"`,
+				"tags":   []interface{}{"a", "b, c", "d"},
+				"list":   []interface{}{"x", "y"},
+				"nested": []interface{}{[]interface{}{"one", "two"}},
+			},
+			map[string]interface{}{
+				"it's":  "plain",
+				"url":   "http://example.com/#anchor",
+				"empty": "",
+			},
+		},
+	}
+	if actual := simplify(root); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+
+	jobs := root.vals[0]
+	if line := jobs.items[1].line; line != 13 {
+		t.Errorf("expected the second job on line 13, got %d", line)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for _, test := range []struct {
+		doc  string
+		line int
+	}{
+		{"a: 1\n  b: 2\n", 2},
+		{"a: 1\na: 2\n", 2},
+		{"a: 1\n\t b: 2\n", 2},
+		{"a: [1, 2\n", 1},
+		{"- a\nb: 1\n", 2},
+		{"a: \"open\n", 1},
+		{"a: {b: 1}\n", 1},
+		{"a: &anchor 1\n", 1},
+		{"a: |\n  text\n", 1},
+		{"text\nmore text\n", 2},
+	} {
+		_, err := parseYAML(strings.NewReader(test.doc))
+		e, ok := err.(*yamlError)
+		if !ok {
+			t.Errorf("%q: expected an error, got %v", test.doc, err)
+			continue
+		}
+		if e.line != test.line {
+			t.Errorf("%q: expected an error on line %d, got %d: %v", test.doc, test.line, e.line, e)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KubernetesSchedule holds the scheduling fields of a Kubernetes CronJob's
+// spec, so that a schedule defined once can be run in-process or deployed
+// as a CronJob.
+type KubernetesSchedule struct {
+	// Schedule is the CronJob's .spec.schedule: a standard five-field spec
+	// or one of the @yearly, @monthly, @weekly, @daily or @hourly macros.
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the CronJob's .spec.timeZone, an IANA time zone name, or
+	// empty for the time zone of the kube-controller-manager.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Validate returns an error if Kubernetes would reject the schedule.
+func (k KubernetesSchedule) Validate() error {
+	_, err := ParseKubernetes(k)
+	return err
+}
+
+// ParseKubernetes returns the schedule of a Kubernetes CronJob, interpreted
+// in its time zone if it has one, see LocationSchedule.  It returns an error
+// if Kubernetes would reject the schedule: besides an invalid spec, that is
+// an @every interval, a TZ= or CRON_TZ= prefix in the spec rather than the
+// TimeZone field, or an unknown or Local time zone.
+func ParseKubernetes(k KubernetesSchedule) (Schedule, error) {
+	spec := strings.TrimSpace(k.Schedule)
+	switch {
+	case spec == "":
+		return nil, specErrorf(ErrFieldCount, "cron: empty Kubernetes schedule")
+	case strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ="):
+		return nil, specErrorf(ErrSyntax, "cron: Kubernetes schedules set their time zone with timeZone, not in the spec: %s", spec)
+	case strings.HasPrefix(spec, "@every"):
+		return nil, specErrorf(ErrUnknownDescriptor, "cron: Kubernetes does not accept intervals: %s", spec)
+	}
+	schedule, err := ParseStandard(spec)
+	if err != nil {
+		return nil, err
+	}
+	if k.TimeZone == "" {
+		return schedule, nil
+	}
+	if k.TimeZone == "Local" {
+		return nil, specErrorf(ErrSyntax, "cron: Kubernetes does not accept the Local time zone")
+	}
+	location, err := time.LoadLocation(k.TimeZone)
+	if err != nil {
+		return nil, specErrorf(ErrSyntax, "cron: unknown time zone %q: %v", k.TimeZone, err)
+	}
+	return LocationSchedule{Schedule: schedule, Location: location}, nil
+}
+
+// ExportKubernetes renders the schedule, interpreted in the given location,
+// as the scheduling fields of a Kubernetes CronJob.  A LocationSchedule is
+// exported in its own location.  A nil or Local location leaves TimeZone
+// empty.  It returns an error if a CronJob cannot represent the schedule:
+// it must be a SpecSchedule activating at second 0 only.
+func ExportKubernetes(schedule Schedule, location *time.Location) (KubernetesSchedule, error) {
+	if l, ok := schedule.(LocationSchedule); ok {
+		schedule, location = l.Schedule, l.Location
+	}
+	s, ok := schedule.(*SpecSchedule)
+	if !ok {
+		return KubernetesSchedule{}, fmt.Errorf("cron: Kubernetes cannot represent %s", Describe(schedule))
+	}
+	if s.Second&^starBit != 1<<seconds.min {
+		return KubernetesSchedule{}, fmt.Errorf("cron: Kubernetes cannot activate on seconds other than 0")
+	}
+	days, err := formatDays(s)
+	if err != nil {
+		return KubernetesSchedule{}, err
+	}
+	k := KubernetesSchedule{Schedule: fmt.Sprintf("%s %s %s",
+		formatField(s.Minute, minutes, 0), formatField(s.Hour, hours, 0), days)}
+	if location != nil && location != time.Local {
+		k.TimeZone = location.String()
+	}
+	return k, nil
+}
+
+// formatDays renders the day of month, month and day of week fields of the
+// schedule in standard cron syntax, where the days match if either does
+// unless one of them is a star.
+func formatDays(s *SpecSchedule) (string, error) {
+	domStar, dowStar := s.Dom&starBit > 0, s.Dow&starBit > 0
+	if domStar && !isAll(s.Dom, dom) && !dowStar || dowStar && !isAll(s.Dow, dow) && !domStar {
+		return "", fmt.Errorf("cron: standard cron cannot represent a stepped star in one day field with the other restricted")
+	}
+	day := func(bits uint64, r bounds) string {
+		if bits&starBit == 0 && isAll(bits, r) {
+			return fmt.Sprintf("%d-%d", r.min, r.max)
+		}
+		return formatField(bits, r, 0)
+	}
+	return day(s.Dom, dom) + " " + formatField(s.Month, months, 0) + " " + day(s.Dow, dow), nil
+}
@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseKubernetes(t *testing.T) {
+	for _, k := range []KubernetesSchedule{
+		{Schedule: "*/5 * * * *"},
+		{Schedule: "30 9 * * MON-FRI", TimeZone: "Etc/UTC"},
+		{Schedule: "@hourly"},
+	} {
+		if err := k.Validate(); err != nil {
+			t.Errorf("%+v: %v", k, err)
+		}
+	}
+
+	for _, k := range []KubernetesSchedule{
+		{Schedule: ""},
+		{Schedule: "0 30 9 * * *"},
+		{Schedule: "@every 5m"},
+		{Schedule: "CRON_TZ=UTC 0 9 * * *"},
+		{Schedule: "0 9 * * *", TimeZone: "Local"},
+		{Schedule: "0 9 * * *", TimeZone: "Nowhere/Special"},
+	} {
+		if err := k.Validate(); err == nil {
+			t.Errorf("%+v: expected an error", k)
+		}
+	}
+}
+
+func TestParseKubernetesTimeZone(t *testing.T) {
+	schedule, err := ParseKubernetes(KubernetesSchedule{Schedule: "0 9 * * *", TimeZone: "Etc/GMT-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if next := schedule.Next(from); !next.Equal(time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 09:00 in the time zone, got %v", next)
+	}
+}
+
+func TestExportKubernetes(t *testing.T) {
+	zone := time.FixedZone("Etc/GMT-2", 2*60*60)
+	for _, test := range []struct {
+		spec     string
+		location *time.Location
+		expected KubernetesSchedule
+	}{
+		{"0 30 9 * * MON-FRI", nil, KubernetesSchedule{Schedule: "30 9 * * 1-5"}},
+		{"0 0 0 1 * ?", zone, KubernetesSchedule{Schedule: "0 0 1 * *", TimeZone: "Etc/GMT-2"}},
+		{"0 0 12 1-31 * MON", time.Local, KubernetesSchedule{Schedule: "0 12 1-31 * 1"}},
+		{"@hourly", time.UTC, KubernetesSchedule{Schedule: "0 * * * *", TimeZone: "UTC"}},
+	} {
+		schedule, _ := Parse(test.spec)
+		k, err := ExportKubernetes(schedule, test.location)
+		if err != nil || k != test.expected {
+			t.Errorf("%s: expected %+v, got %+v, %v", test.spec, test.expected, k, err)
+			continue
+		}
+		if err := k.Validate(); err != nil {
+			t.Errorf("%+v: %v", k, err)
+		}
+	}
+
+	for _, schedule := range []Schedule{Every(time.Minute), mustParse("30 0 12 * * ?")} {
+		if _, err := ExportKubernetes(schedule, nil); err == nil {
+			t.Errorf("%s: expected an error", Describe(schedule))
+		}
+	}
+}
+
+func mustParse(spec string) Schedule {
+	schedule, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return schedule
+}
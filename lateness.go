@@ -0,0 +1,27 @@
+package cron
+
+import "time"
+
+// WithLatenessHook returns an Option that calls hook whenever a job starts
+// more than threshold after it was due: its scheduled time plus any
+// intended delay, such as its jitter.  Late starts mean the scheduler or the
+// machine is overloaded, and are reported whether or not the job then
+// succeeds.  The hook is called in the job's goroutine, before the job runs,
+// with the run and how late it started.
+func WithLatenessHook(threshold time.Duration, hook func(run Run, late time.Duration)) Option {
+	return func(c *Cron) {
+		c.lateness = threshold
+		c.latenessHook = hook
+	}
+}
+
+// checkLateness calls the lateness hook if the run started more than the
+// threshold after it was due.
+func (c *Cron) checkLateness(run Run, due time.Time) {
+	if c.latenessHook == nil {
+		return
+	}
+	if late := run.Start.Sub(due); late > c.lateness {
+		c.latenessHook(run, late)
+	}
+}
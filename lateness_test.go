@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatenessHook(t *testing.T) {
+	late := make(chan time.Duration, 10)
+	cron := New(WithLatenessHook(time.Second, func(run Run, d time.Duration) { late <- d }))
+
+	run := Run{EntryID: 1, Scheduled: time.Now().Add(-3 * time.Second)}
+	run.Start = time.Now()
+	cron.checkLateness(run, run.Scheduled)
+	select {
+	case d := <-late:
+		if d < 3*time.Second {
+			t.Errorf("expected at least 3s late, got %v", d)
+		}
+	default:
+		t.Fatal("expected the hook to be called")
+	}
+
+	// Intended delays do not count.
+	cron.checkLateness(run, run.Scheduled.Add(2500*time.Millisecond))
+	if len(late) != 0 {
+		t.Error("expected no call within the threshold")
+	}
+}
+
+// Runs dispatched on time do not trip the hook.
+func TestLatenessHookOnTime(t *testing.T) {
+	late := make(chan time.Duration, 10)
+	cron := New(WithLatenessHook(500*time.Millisecond, func(run Run, d time.Duration) { late <- d }))
+	cron.AddFunc("* * * * * ?", func() {}, WithJitter(800*time.Millisecond))
+	cron.Start()
+	time.Sleep(2 * ONE_SECOND)
+	<-cron.Stop().Done()
+
+	if len(late) != 0 {
+		t.Errorf("expected no late runs, got %v", <-late)
+	}
+}
@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithLogField returns an EntryOption that adds the key/value pair to every
+// message the Cron logs about the entry, such as its failures and panics, so
+// that log-based alerting can route them by service, team or severity.
+func WithLogField(key, value string) EntryOption {
+	return func(e *Entry) {
+		if e.LogFields == nil {
+			e.LogFields = make(map[string]string)
+		}
+		e.LogFields[key] = value
+	}
+}
+
+// Logger receives the messages logged by a Cron along with their fields:
+// for messages about a run, the LogFields of its entry and its run_id.
+type Logger interface {
+	Log(msg string, fields map[string]string)
+}
+
+// WithLogger returns an Option that sends the Cron's log messages to the
+// given Logger.  Without one, messages go to ErrorLog, with their fields
+// appended to their first line in key=value form.
+func WithLogger(logger Logger) Option {
+	return func(c *Cron) {
+		c.logger = logger
+	}
+}
+
+// runLog logs messages about the runs of an entry.
+type runLog struct {
+	c *Cron
+
+	// name refers to the run in messages, see Entry.logName.
+	name   string
+	fields map[string]string
+}
+
+// runLog returns the runLog for the given run of the entry.  Its fields are
+// the entry's LogFields and the run's ID, as run_id.
+func (c *Cron) runLog(e *Entry, run Run) runLog {
+	fields := make(map[string]string, len(e.LogFields)+1)
+	for k, v := range e.LogFields {
+		fields[k] = v
+	}
+	fields["run_id"] = run.ID
+	return runLog{c: c, name: e.logName(run.Key), fields: fields}
+}
+
+func (l runLog) logf(format string, args ...interface{}) {
+	l.c.log(fmt.Sprintf(format, args...), l.fields)
+}
+
+// log logs the message with the given fields.
+func (c *Cron) log(msg string, fields map[string]string) {
+	if c.logger != nil {
+		c.logger.Log(msg, fields)
+		return
+	}
+	if len(fields) > 0 {
+		pairs := make([]string, 0, len(fields))
+		for k, v := range fields {
+			if strings.ContainsAny(v, " \t\n\"=") || v == "" {
+				v = strconv.Quote(v)
+			}
+			pairs = append(pairs, k+"="+v)
+		}
+		sort.Strings(pairs)
+		// Keep the fields on the first line, ahead of any stack trace.
+		first, rest := msg, ""
+		if i := strings.IndexByte(msg, '\n'); i >= 0 {
+			first, rest = msg[:i], msg[i:]
+		}
+		msg = first + " " + strings.Join(pairs, " ") + rest
+	}
+	if c.ErrorLog != nil {
+		c.ErrorLog.Print(msg)
+	} else {
+		log.Print(msg)
+	}
+}
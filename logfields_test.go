@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testLogger struct {
+	mu     sync.Mutex
+	msgs   []string
+	fields []map[string]string
+}
+
+func (l *testLogger) Log(msg string, fields map[string]string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, msg)
+	l.fields = append(l.fields, fields)
+}
+
+func TestLogFieldsWithLogger(t *testing.T) {
+	logger := &testLogger{}
+	cron := New(WithLogger(logger))
+	id, _ := cron.AddContextFunc("@hourly", func(ctx context.Context) error {
+		return errors.New("boom")
+	}, WithLogField("team", "billing"), WithLogField("severity", "page"))
+	cron.Trigger(id)
+	<-cron.Stop().Done()
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if len(logger.msgs) != 1 || !strings.Contains(logger.msgs[0], "failed: boom") {
+		t.Fatalf("expected the failure logged, got %v", logger.msgs)
+	}
+	if f := logger.fields[0]; f["team"] != "billing" || f["severity"] != "page" {
+		t.Errorf("unexpected fields %v", f)
+	}
+	if e := cron.Entry(id); e.LogFields["team"] != "billing" {
+		t.Errorf("expected the fields in snapshots, got %v", e.LogFields)
+	}
+}
+
+func TestLogFieldsWithErrorLog(t *testing.T) {
+	logs := &lockedBuffer{}
+	cron := New()
+	cron.ErrorLog = log.New(logs, "", 0)
+	id, _ := cron.AddFunc("@hourly", func() { panic("YOLO") },
+		WithLogField("service", "api"), WithLogField("note", "two words"))
+	cron.Trigger(id)
+	<-cron.Stop().Done()
+
+	first := strings.SplitN(logs.String(), "\n", 2)[0]
+	if !strings.Contains(first, `note="two words" run_id=`) || !strings.HasSuffix(first, " service=api") {
+		t.Errorf("expected the fields appended, got %q", first)
+	}
+}
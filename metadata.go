@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithMetadata returns an EntryOption that attaches the key/value pair to the
+// entry.  Metadata is not interpreted by the Cron; it is carried along in
+// snapshots, handed to hooks, included in log lines and served by the admin
+// API, so that callers need not keep a parallel map keyed by EntryID.
+func WithMetadata(key, value string) EntryOption {
+	return func(e *Entry) {
+		if e.Metadata == nil {
+			e.Metadata = make(map[string]string)
+		}
+		e.Metadata[key] = value
+	}
+}
+
+// SetMetadata sets the key/value pair on the given entry, replacing any
+// previous value of the key.
+func (c *Cron) SetMetadata(id EntryID, key, value string) {
+	c.do(func() {
+		if e := c.find(id); e != nil {
+			WithMetadata(key, value)(e)
+		}
+	})
+}
+
+// DeleteMetadata removes the key from the given entry's metadata.
+func (c *Cron) DeleteMetadata(id EntryID, key string) {
+	c.do(func() {
+		if e := c.find(id); e != nil {
+			delete(e.Metadata, key)
+		}
+	})
+}
+
+// copyMetadata returns a copy of the metadata, or nil if it is empty.
+func copyMetadata(md map[string]string) map[string]string {
+	if len(md) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(md))
+	for k, v := range md {
+		copied[k] = v
+	}
+	return copied
+}
+
+// logName describes the run of the entry with the given key in log lines,
+// along with the entry's name and metadata, if any.
+func (e *Entry) logName(key RunKey) string {
+	s := string(key)
+	if e.Name != "" {
+		s += " " + strconv.Quote(e.Name)
+	}
+	if len(e.Metadata) > 0 {
+		pairs := make([]string, 0, len(e.Metadata))
+		for k, v := range e.Metadata {
+			pairs = append(pairs, k+"="+v)
+		}
+		sort.Strings(pairs)
+		s += " [" + strings.Join(pairs, " ") + "]"
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetadata(t *testing.T) {
+	cron := New()
+	id := cron.Schedule(Every(time.Hour), FuncJob(func() {}),
+		WithMetadata("owner", "billing"), WithMetadata("ticket", "OPS-1"))
+	cron.Start()
+	defer cron.Stop()
+
+	e := cron.Entry(id)
+	if e.Metadata["owner"] != "billing" || e.Metadata["ticket"] != "OPS-1" {
+		t.Fatalf("unexpected metadata %v", e.Metadata)
+	}
+	e.Metadata["owner"] = "changed"
+	if owner := cron.Entry(id).Metadata["owner"]; owner != "billing" {
+		t.Errorf("expected snapshots to have their own metadata, got owner %s", owner)
+	}
+
+	cron.SetMetadata(id, "owner", "payments")
+	cron.DeleteMetadata(id, "ticket")
+	md := cron.Entries()[0].Metadata
+	if len(md) != 1 || md["owner"] != "payments" {
+		t.Errorf("unexpected metadata after changes %v", md)
+	}
+}
+
+func TestMetadataInHooks(t *testing.T) {
+	batches := make(chan []*Entry, 10)
+	cron := New(WithBatchHook(func(_ time.Time, batch []*Entry) { batches <- batch }))
+	cron.AddFunc("* * * * * ?", func() {}, WithMetadata("owner", "billing"))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case batch := <-batches:
+		if batch[0].Metadata["owner"] != "billing" {
+			t.Errorf("expected metadata in the hook, got %v", batch[0].Metadata)
+		}
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected a batch")
+	}
+}
+
+func TestLogName(t *testing.T) {
+	e := &Entry{ID: 1, Name: "backup"}
+	WithMetadata("team", "db")(e)
+	WithMetadata("owner", "ops")(e)
+	if name := e.logName("1@x"); name != `1@x "backup" [owner=ops team=db]` {
+		t.Errorf("unexpected log name %s", name)
+	}
+	if name := (&Entry{ID: 2}).logName("2@x"); name != "2@x" {
+		t.Errorf("unexpected log name %s", name)
+	}
+}
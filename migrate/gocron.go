@@ -0,0 +1,326 @@
+// Package migrate eases moving jobs to this module from other scheduling
+// libraries, with thin adapters mapping their common calls onto cron
+// schedules and a cron.Cron, so that call sites change little:
+//
+//	s := migrate.NewScheduler(c)
+//	s.Every(1).Monday().At("09:30").Tag("reports").Do(report)
+//
+//	trigger, err := migrate.NewCronTrigger("0 30 9 ? * 2-6")
+//	migrate.ScheduleJob(c, job, trigger)
+//
+// Scheduler follows gocron's builder and NewCronTrigger and ScheduleJob
+// go-quartz's triggers and jobs.  Only the parts of those APIs with an
+// equivalent here are provided; calls describing schedules this module cannot
+// represent return an error rather than run at different times.
+package migrate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// Scheduler adds entries to a Cron with calls in the style of gocron's
+// Scheduler.
+type Scheduler struct {
+	c *cron.Cron
+}
+
+// NewScheduler returns a Scheduler adding entries to c.
+func NewScheduler(c *cron.Cron) *Scheduler {
+	return &Scheduler{c: c}
+}
+
+// StartAsync starts the Cron.
+func (s *Scheduler) StartAsync() { s.c.Start() }
+
+// Stop stops the Cron, without waiting for running jobs.
+func (s *Scheduler) Stop() { s.c.Stop() }
+
+// RemoveByTag removes the entries with the tag.
+func (s *Scheduler) RemoveByTag(tag string) { s.c.RemoveGroup(tag) }
+
+// Every starts a job running at an interval, given as a number of the units
+// selected next, such as Every(5).Minutes(), or as a time.Duration or a
+// string time.ParseDuration accepts.
+func (s *Scheduler) Every(interval interface{}) *Job {
+	j := &Job{s: s}
+	switch v := interval.(type) {
+	case int:
+		if v <= 0 {
+			j.err = fmt.Errorf("migrate: interval must be positive, not %d", v)
+		}
+		j.interval = v
+	case time.Duration:
+		j.duration = v
+	case string:
+		j.duration, j.err = time.ParseDuration(v)
+	default:
+		j.err = fmt.Errorf("migrate: unsupported interval %v", interval)
+	}
+	if j.err == nil && j.interval == 0 && j.duration <= 0 {
+		j.err = fmt.Errorf("migrate: interval must be positive, not %v", interval)
+	}
+	return j
+}
+
+// Cron starts a job running on a standard five-field spec.
+func (s *Scheduler) Cron(spec string) *Job {
+	j := &Job{s: s}
+	j.schedule, j.err = cron.ParseStandard(spec)
+	return j
+}
+
+// CronWithSeconds starts a job running on a spec whose first field is the
+// second, see cron.Parse.
+func (s *Scheduler) CronWithSeconds(spec string) *Job {
+	j := &Job{s: s}
+	j.schedule, j.err = cron.Parse(spec)
+	return j
+}
+
+// The units of a Job's interval.
+const (
+	noUnit = iota
+	secondUnit
+	minuteUnit
+	hourUnit
+	dayUnit
+	weekUnit
+	monthUnit
+)
+
+var unitDurations = map[int]time.Duration{
+	secondUnit: time.Second,
+	minuteUnit: time.Minute,
+	hourUnit:   time.Hour,
+	dayUnit:    24 * time.Hour,
+	weekUnit:   7 * 24 * time.Hour,
+}
+
+// Job is a job being described by a Scheduler's builder calls.  Errors in
+// the calls are reported by Do.
+type Job struct {
+	s        *Scheduler
+	err      error
+	schedule cron.Schedule // of Cron and CronWithSeconds
+
+	interval int
+	duration time.Duration
+	unit     int
+	weekdays []time.Weekday
+	days     []int
+	at       []string
+	name     string
+	tags     []string
+}
+
+func (j *Job) setUnit(unit int) *Job {
+	if j.unit != noUnit && j.unit != unit {
+		j.err = fmt.Errorf("migrate: a job cannot run every %s and every %s", unitName(j.unit), unitName(unit))
+	}
+	if j.duration > 0 {
+		j.err = fmt.Errorf("migrate: a job given a duration cannot have a unit")
+	}
+	j.unit = unit
+	return j
+}
+
+func unitName(unit int) string {
+	return [...]string{"", "second", "minute", "hour", "day", "week", "month"}[unit]
+}
+
+// Second and Seconds select seconds as the unit of the interval.
+func (j *Job) Second() *Job  { return j.setUnit(secondUnit) }
+func (j *Job) Seconds() *Job { return j.setUnit(secondUnit) }
+
+// Minute and Minutes select minutes as the unit of the interval.
+func (j *Job) Minute() *Job  { return j.setUnit(minuteUnit) }
+func (j *Job) Minutes() *Job { return j.setUnit(minuteUnit) }
+
+// Hour and Hours select hours as the unit of the interval.
+func (j *Job) Hour() *Job  { return j.setUnit(hourUnit) }
+func (j *Job) Hours() *Job { return j.setUnit(hourUnit) }
+
+// Day and Days select days as the unit of the interval.
+func (j *Job) Day() *Job  { return j.setUnit(dayUnit) }
+func (j *Job) Days() *Job { return j.setUnit(dayUnit) }
+
+// Week and Weeks select weeks as the unit of the interval.
+func (j *Job) Week() *Job  { return j.setUnit(weekUnit) }
+func (j *Job) Weeks() *Job { return j.setUnit(weekUnit) }
+
+// Month selects months as the unit of the interval, running on the given
+// days of the month, or the first if none are given.
+func (j *Job) Month(daysOfMonth ...int) *Job {
+	for _, d := range daysOfMonth {
+		if d < 1 || d > 31 {
+			j.err = fmt.Errorf("migrate: invalid day of month %d", d)
+		}
+	}
+	j.days = append(j.days, daysOfMonth...)
+	return j.setUnit(monthUnit)
+}
+
+// Months is Month.
+func (j *Job) Months(daysOfMonth ...int) *Job { return j.Month(daysOfMonth...) }
+
+// Weekday runs the job weekly on the day, in addition to any others given.
+func (j *Job) Weekday(day time.Weekday) *Job {
+	j.weekdays = append(j.weekdays, day)
+	return j.setUnit(weekUnit)
+}
+
+func (j *Job) Monday() *Job    { return j.Weekday(time.Monday) }
+func (j *Job) Tuesday() *Job   { return j.Weekday(time.Tuesday) }
+func (j *Job) Wednesday() *Job { return j.Weekday(time.Wednesday) }
+func (j *Job) Thursday() *Job  { return j.Weekday(time.Thursday) }
+func (j *Job) Friday() *Job    { return j.Weekday(time.Friday) }
+func (j *Job) Saturday() *Job  { return j.Weekday(time.Saturday) }
+func (j *Job) Sunday() *Job    { return j.Weekday(time.Sunday) }
+
+// At sets the times of day a daily, weekly or monthly job runs at, as
+// "HH:MM" or "HH:MM:SS", several of which may be separated by semicolons.
+func (j *Job) At(times string) *Job {
+	j.at = append(j.at, strings.Split(times, ";")...)
+	return j
+}
+
+// Name names the entry.
+func (j *Job) Name(name string) *Job {
+	j.name = name
+	return j
+}
+
+// Tag tags the entry.
+func (j *Job) Tag(tags ...string) *Job {
+	j.tags = append(j.tags, tags...)
+	return j
+}
+
+// Do adds an entry running fn to the Scheduler's Cron, returning its ID, or
+// the first error in the calls describing the job.
+func (j *Job) Do(fn func()) (cron.EntryID, error) {
+	schedule, err := j.Schedule()
+	if err != nil {
+		return 0, err
+	}
+	opts := []cron.EntryOption{cron.WithTags(j.tags...)}
+	if j.name != "" {
+		opts = append(opts, cron.WithName(j.name))
+	}
+	return j.s.c.Schedule(schedule, cron.FuncJob(fn), opts...), nil
+}
+
+// Schedule returns the schedule the calls describe.
+//
+// A job running every interval of seconds, minutes or hours, or of days or
+// weeks without a time of day or a weekday, runs at that constant delay, see
+// cron.Every.  Otherwise it runs at the given times of day, or at midnight,
+// every day, on the given weekdays, or on the given days of the month, which
+// is only possible for an interval of 1.
+func (j *Job) Schedule() (cron.Schedule, error) {
+	switch {
+	case j.err != nil:
+		return nil, j.err
+	case j.schedule != nil:
+		return j.schedule, nil
+	case j.duration > 0:
+		if len(j.at) > 0 {
+			return nil, fmt.Errorf("migrate: a job given a duration cannot run at a time of day")
+		}
+		return cron.Every(j.duration), nil
+	case j.unit == noUnit:
+		return nil, fmt.Errorf("migrate: a job's interval has no unit")
+	}
+
+	calendar := len(j.at) > 0 || len(j.weekdays) > 0 || j.unit == monthUnit
+	if !calendar {
+		return cron.Every(time.Duration(j.interval) * unitDurations[j.unit]), nil
+	}
+	if j.unit < dayUnit {
+		return nil, fmt.Errorf("migrate: a job running every %s cannot run at a time of day", unitName(j.unit))
+	}
+	if j.interval != 1 {
+		return nil, fmt.Errorf("migrate: a job running every %d %ss at a time of day is not supported", j.interval, unitName(j.unit))
+	}
+
+	s := &cron.SpecSchedule{Dom: 1<<63 | allBits(1, 31), Month: 1<<63 | allBits(1, 12), Dow: 1<<63 | allBits(0, 6)}
+	if err := setTimes(s, j.at); err != nil {
+		return nil, err
+	}
+	if len(j.weekdays) > 0 {
+		s.Dow = 0
+		for _, d := range j.weekdays {
+			s.Dow |= 1 << uint(d)
+		}
+	}
+	if j.unit == monthUnit {
+		s.Dom = 0
+		for _, d := range j.days {
+			s.Dom |= 1 << uint(d)
+		}
+		if s.Dom == 0 {
+			s.Dom = 1 << 1
+		}
+	}
+	return s, nil
+}
+
+// setTimes sets the second, minute and hour fields of s to the times of day,
+// which must be every combination of their seconds, minutes and hours for a
+// SpecSchedule to represent them.
+func setTimes(s *cron.SpecSchedule, at []string) error {
+	if len(at) == 0 {
+		at = []string{"00:00"}
+	}
+	distinct := make(map[[3]int]bool)
+	for _, t := range at {
+		hms, err := parseTime(t)
+		if err != nil {
+			return err
+		}
+		distinct[hms] = true
+		s.Hour |= 1 << uint(hms[0])
+		s.Minute |= 1 << uint(hms[1])
+		s.Second |= 1 << uint(hms[2])
+	}
+	if count(s.Hour)*count(s.Minute)*count(s.Second) != len(distinct) {
+		return fmt.Errorf("migrate: cannot run at %s, as the times do not share their minutes and seconds", strings.Join(at, ";"))
+	}
+	return nil
+}
+
+// parseTime parses a time of day as "HH:MM" or "HH:MM:SS".
+func parseTime(t string) ([3]int, error) {
+	var hms [3]int
+	parts := strings.Split(strings.TrimSpace(t), ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return hms, fmt.Errorf("migrate: invalid time of day %q", t)
+	}
+	for i, max := range []int{23, 59, 59}[:len(parts)] {
+		v, err := strconv.Atoi(parts[i])
+		if err != nil || v < 0 || v > max {
+			return hms, fmt.Errorf("migrate: invalid time of day %q", t)
+		}
+		hms[i] = v
+	}
+	return hms, nil
+}
+
+// allBits returns the bits from min to max.
+func allBits(min, max uint) uint64 {
+	return ^(^uint64(0) << (max + 1)) &^ (1<<min - 1)
+}
+
+// count returns the number of bits set.
+func count(bits uint64) int {
+	n := 0
+	for ; bits > 0; bits &= bits - 1 {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,91 @@
+package migrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+var start = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// sameNext reports whether the schedules activate at the same times in the
+// days following start.
+func sameNext(a, b cron.Schedule) bool {
+	ta, tb := start, start
+	for i := 0; i < 50; i++ {
+		ta, tb = a.Next(ta), b.Next(tb)
+		if !ta.Equal(tb) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSchedulerSchedule(t *testing.T) {
+	s := NewScheduler(cron.New())
+	for _, test := range []struct {
+		job      *Job
+		expected string
+	}{
+		{s.Every(5).Minutes(), "@every 5m"},
+		{s.Every(90 * time.Second), "@every 90s"},
+		{s.Every("2h"), "@every 2h"},
+		{s.Every(2).Days(), "@every 48h"},
+		{s.Every(1).Day().At("10:30"), "0 30 10 * * *"},
+		{s.Every(1).Day().At("08:15;20:15"), "0 15 8,20 * * *"},
+		{s.Every(1).Monday().Friday().At("09:00:30"), "30 0 9 * * MON,FRI"},
+		{s.Every(1).Week().Sunday(), "0 0 0 * * SUN"},
+		{s.Every(1).Month(1, 15).At("06:00"), "0 0 6 1,15 * *"},
+		{s.Every(1).Month(), "0 0 0 1 * *"},
+		{s.Cron("30 9 * * MON-FRI"), "0 30 9 * * MON-FRI"},
+		{s.CronWithSeconds("15 30 9 * * *"), "15 30 9 * * *"},
+	} {
+		schedule, err := test.job.Schedule()
+		if err != nil {
+			t.Errorf("%s: %v", test.expected, err)
+			continue
+		}
+		expected, _ := cron.Parse(test.expected)
+		if !sameNext(schedule, expected) {
+			t.Errorf("expected a schedule like %s, got %s", test.expected, cron.Describe(schedule))
+		}
+	}
+}
+
+func TestSchedulerErrors(t *testing.T) {
+	s := NewScheduler(cron.New())
+	for name, job := range map[string]*Job{
+		"no unit":          s.Every(5),
+		"zero interval":    s.Every(0).Seconds(),
+		"bad duration":     s.Every("soon"),
+		"two units":        s.Every(1).Hours().Days(),
+		"hourly at":        s.Every(1).Hour().At("10:30"),
+		"interval at":      s.Every(2).Days().At("10:30"),
+		"bad time":         s.Every(1).Day().At("25:00"),
+		"unrelated times":  s.Every(1).Day().At("08:00;10:30"),
+		"bad day of month": s.Every(1).Month(32),
+		"bad spec":         s.Cron("* * *"),
+	} {
+		if _, err := job.Do(func() {}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSchedulerDo(t *testing.T) {
+	c := cron.New()
+	s := NewScheduler(c)
+	id, err := s.Every(1).Day().At("10:30").Name("report").Tag("reports", "daily").Do(func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := c.Entry(id)
+	if e == nil || e.Name != "report" || !e.HasTag("daily") {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+	s.RemoveByTag("reports")
+	if c.Entry(id) != nil {
+		t.Error("expected the entry to be removed by its tag")
+	}
+}
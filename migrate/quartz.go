@@ -0,0 +1,87 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// NewCronTrigger parses a Quartz cron expression, as go-quartz's trigger of
+// the same name does: seconds, minutes, hours, day of month, month and day
+// of week, with days of the week numbered from 1 for Sunday, followed by an
+// optional year that must be "*".  Quartz's L, W and # are not supported.
+func NewCronTrigger(expression string) (cron.Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) == 7 {
+		if fields[6] != "*" {
+			return nil, fmt.Errorf("migrate: years are not supported: %s", expression)
+		}
+		fields = fields[:6]
+	}
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("migrate: expected 6 or 7 fields, found %d: %s", len(fields), expression)
+	}
+	dow, err := quartzWeekdays(fields[5])
+	if err != nil {
+		return nil, err
+	}
+	fields[5] = dow
+	return cron.Parse(strings.Join(fields, " "))
+}
+
+// NewCronTriggerWithLoc parses a Quartz cron expression, see NewCronTrigger,
+// interpreting it in the location.
+func NewCronTriggerWithLoc(expression string, location *time.Location) (cron.Schedule, error) {
+	schedule, err := NewCronTrigger(expression)
+	if err != nil {
+		return nil, err
+	}
+	return cron.LocationSchedule{Schedule: schedule, Location: location}, nil
+}
+
+// NewSimpleTrigger returns a schedule activating every interval, as
+// go-quartz's trigger of the same name does.
+func NewSimpleTrigger(interval time.Duration) cron.Schedule {
+	return cron.Every(interval)
+}
+
+// quartzWeekdays renumbers the days of the week of a Quartz day of week
+// field, from 1 for Sunday to 0, leaving names and steps alone.
+func quartzWeekdays(field string) (string, error) {
+	ranges := strings.Split(field, ",")
+	for i, r := range ranges {
+		step := ""
+		if j := strings.Index(r, "/"); j >= 0 {
+			r, step = r[:j], r[j:]
+		}
+		bounds := strings.Split(r, "-")
+		for k, b := range bounds {
+			n, err := strconv.Atoi(b)
+			if err != nil {
+				continue
+			}
+			if n < 1 || n > 7 {
+				return "", fmt.Errorf("migrate: day of week %d is out of range 1-7", n)
+			}
+			bounds[k] = strconv.Itoa(n - 1)
+		}
+		ranges[i] = strings.Join(bounds, "-") + step
+	}
+	return strings.Join(ranges, ","), nil
+}
+
+// QuartzJob is the interface of go-quartz's jobs.
+type QuartzJob interface {
+	Execute(ctx context.Context) error
+	Description() string
+}
+
+// ScheduleJob adds an entry to c running the job on the trigger, named by the
+// job's description, as go-quartz's Scheduler.ScheduleJob does.
+func ScheduleJob(c *cron.Cron, job QuartzJob, trigger cron.Schedule) cron.EntryID {
+	return c.Schedule(trigger, cron.ContextFuncJob(job.Execute), cron.WithName(job.Description()))
+}
@@ -0,0 +1,85 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+func TestNewCronTrigger(t *testing.T) {
+	for expression, expected := range map[string]string{
+		"0 30 9 ? * 2-6":      "0 30 9 * * MON-FRI",
+		"0 0 12 1,15 * ?":     "0 0 12 1,15 * *",
+		"0 0 0 ? JAN,JUL 1 *": "0 0 0 * JAN,JUL SUN",
+		"0 0 0 ? * 1/2":       "0 0 0 * * SUN,TUE,THU,SAT",
+		"0 0 0 ? * MON,7":     "0 0 0 * * MON,SAT",
+		"0 0 0 ? * MON-FRI/2": "0 0 0 * * MON,WED,FRI",
+		"0 0 0 ? * 2-6/2":     "0 0 0 * * MON,WED,FRI",
+		"0 0 0 ? * MON/2":     "0 0 0 * * MON,WED,FRI",
+		"0 0 0 1 JAN-DEC/3 ?": "0 0 0 1 JAN,APR,JUL,OCT *",
+		"0/20 * * * * ?":      "*/20 * * * * *",
+	} {
+		schedule, err := NewCronTrigger(expression)
+		if err != nil {
+			t.Errorf("%s: %v", expression, err)
+			continue
+		}
+		e, _ := cron.Parse(expected)
+		if !sameNext(schedule, e) {
+			t.Errorf("%s: expected a schedule like %s, got %s", expression, expected, cron.Describe(schedule))
+		}
+	}
+
+	for _, expression := range []string{
+		"0 0 0 ? * 8",
+		"0 0 0 1 * ? 2025",
+		"0 0 0 * *",
+		"0 0 0 L * ?",
+	} {
+		if _, err := NewCronTrigger(expression); err == nil {
+			t.Errorf("%s: expected an error", expression)
+		}
+	}
+}
+
+func TestNewCronTriggerWithLoc(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	schedule, err := NewCronTriggerWithLoc("0 0 9 * * ?", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := schedule.Next(start)
+	if next.In(ny).Hour() != 9 {
+		t.Errorf("expected 09:00 in New York, got %v", next.In(ny))
+	}
+}
+
+type quartzJob struct{ ran chan struct{} }
+
+func (j quartzJob) Execute(ctx context.Context) error {
+	j.ran <- struct{}{}
+	return nil
+}
+
+func (j quartzJob) Description() string { return "report" }
+
+func TestScheduleJob(t *testing.T) {
+	c := cron.New()
+	job := quartzJob{make(chan struct{}, 1)}
+	id := ScheduleJob(c, job, NewSimpleTrigger(time.Second))
+	if e := c.Entry(id); e == nil || e.Name != "report" {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+	c.Start()
+	defer c.Stop()
+	select {
+	case <-job.ran:
+	case <-time.After(3 * time.Second):
+		t.Error("expected the job to run")
+	}
+}
@@ -0,0 +1,140 @@
+package cron
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// millis are the bounds of the milliseconds field.
+var millis = bounds{0, 999, nil}
+
+// MillisSchedule activates at the given milliseconds of each second at which
+// its spec activates, for sampling jobs that need sub-second activations.
+// It is returned by ParseMillis.
+type MillisSchedule struct {
+	// Millis is the set of milliseconds, as a bit set of 1024 bits, bit i
+	// of word i/64 standing for millisecond i.
+	Millis [16]uint64
+
+	// Spec gives the seconds at which the schedule activates.
+	Spec *SpecSchedule
+}
+
+// ParseMillis returns the schedule for a spec with a leading milliseconds
+// field, from 0 to 999, followed by the fields of Parse, as in
+// "0,500 * * * * * *" for twice a second.  The milliseconds field accepts
+// the lists, ranges and steps of the others.  A spec whose milliseconds
+// field is "0" activates at whole seconds, and its schedule is returned as
+// Parse returns it, as are descriptors.
+func ParseMillis(spec string) (Schedule, error) {
+	if strings.HasPrefix(spec, "@") {
+		return Parse(spec)
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, specErrorf(ErrFieldCount, "Expected 6 or 7 fields, found %d: %s", len(fields), spec)
+	}
+	ms, err := getMillisField(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := Parse(strings.Join(fields[1:], " "))
+	if err != nil {
+		return nil, err
+	}
+	if ms == [16]uint64{1} {
+		return schedule, nil
+	}
+	return &MillisSchedule{Millis: ms, Spec: schedule.(*SpecSchedule)}, nil
+}
+
+// getMillisField returns the bit set of the milliseconds a field lists.
+func getMillisField(field string) ([16]uint64, error) {
+	var set [16]uint64
+	for _, expr := range strings.Split(field, ",") {
+		if expr == "" {
+			continue
+		}
+		start, end, step, _, err := parseRange(expr, millis)
+		if err != nil {
+			return set, err
+		}
+		for v := start; v <= end; v += step {
+			set[v/64] |= 1 << (v % 64)
+		}
+	}
+	return set, nil
+}
+
+// Next returns the next activation of the schedule after the given time,
+// or the zero time if there is none.
+func (s *MillisSchedule) Next(t time.Time) time.Time {
+	// The remaining milliseconds of t's second, if it is an activation of
+	// the spec.
+	second := t.Add(-time.Duration(t.Nanosecond()))
+	if s.Spec.Next(second.Add(-time.Nanosecond)).Equal(second) {
+		if ms, ok := s.next(uint(t.Nanosecond()/int(time.Millisecond)) + 1); ok {
+			return second.Add(time.Duration(ms) * time.Millisecond)
+		}
+	}
+	next := s.Spec.Next(t)
+	if next.IsZero() {
+		return next
+	}
+	ms, ok := s.next(0)
+	if !ok {
+		return time.Time{}
+	}
+	return next.Add(time.Duration(ms) * time.Millisecond)
+}
+
+// next returns the first millisecond from v in the set.
+func (s *MillisSchedule) next(v uint) (uint, bool) {
+	for w := v / 64; w < uint(len(s.Millis)); w++ {
+		word := s.Millis[w]
+		if w == v/64 {
+			word &^= 1<<(v%64) - 1
+		}
+		if word != 0 {
+			return w*64 + uint(bits.TrailingZeros64(word)), true
+		}
+	}
+	return 0, false
+}
+
+// String returns the schedule as a seven-field spec, which ParseMillis
+// parses to an equivalent schedule.
+func (s *MillisSchedule) String() string {
+	var ranges []string
+	for v, ok := s.next(0); ok; v, ok = s.next(v + 1) {
+		end := v
+		for {
+			n, ok := s.next(end + 1)
+			if !ok || n != end+1 {
+				break
+			}
+			end = n
+		}
+		switch {
+		case v == millis.min && end == millis.max:
+			ranges = append(ranges, "*")
+		case end == v:
+			ranges = append(ranges, fmt.Sprint(v))
+		default:
+			ranges = append(ranges, fmt.Sprintf("%d-%d", v, end))
+		}
+		v = end
+	}
+	return strings.Join(ranges, ",") + " " + s.Spec.String()
+}
+
+// WithMillisSpecs returns an Option that makes AddFunc and AddJob parse specs
+// with ParseMillis, so that they may activate at milliseconds within a
+// second.  WithFields and WithRobfigSpecs take precedence over it.
+func WithMillisSpecs() Option {
+	return func(c *Cron) {
+		c.millisSpecs = true
+	}
+}
@@ -0,0 +1,103 @@
+package cron
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseMillis(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		spec     string
+		from     time.Time
+		expected []time.Time
+	}{
+		{"0,500 * * * * * *", from, []time.Time{
+			from.Add(500 * time.Millisecond),
+			from.Add(time.Second),
+			from.Add(1500 * time.Millisecond),
+		}},
+		{"*/250 * * * * *", from.Add(600 * time.Millisecond), []time.Time{
+			from.Add(750 * time.Millisecond),
+			from.Add(time.Second),
+			from.Add(1250 * time.Millisecond),
+		}},
+		// Milliseconds of a second the spec does not activate at wait for
+		// the next that it does.
+		{"100-102 30 * * * * *", from.Add(30*time.Second + 101*time.Millisecond + 500*time.Microsecond), []time.Time{
+			from.Add(30*time.Second + 102*time.Millisecond),
+			from.Add(90*time.Second + 100*time.Millisecond),
+		}},
+		{"999 0 0 0 1 1 *", from, []time.Time{
+			from.Add(999 * time.Millisecond),
+			from.AddDate(1, 0, 0).Add(999 * time.Millisecond),
+		}},
+	}
+	for _, test := range tests {
+		s, err := ParseMillis(test.spec)
+		if err != nil {
+			t.Errorf("%s: %v", test.spec, err)
+			continue
+		}
+		next := test.from
+		for _, expected := range test.expected {
+			next = s.Next(next)
+			if !next.Equal(expected) {
+				t.Errorf("%s: expected %v, got %v", test.spec, expected, next)
+				break
+			}
+		}
+	}
+
+	if s, err := ParseMillis("0 0 0 9 * * *"); err != nil || s.(*SpecSchedule).Hour != 1<<9 {
+		t.Errorf("expected a whole-second spec to parse as Parse parses it, got %v, %v", s, err)
+	}
+	for spec, kind := range map[string]error{
+		"* * * * *":          ErrFieldCount,
+		"1000 * * * * * *":   ErrValueOutOfRange,
+		"*/0 * * * * * *":    ErrBadStep,
+		"1-2-3 * * * * * *":  ErrSyntax,
+		"0,500 60 * * * * *": ErrValueOutOfRange,
+	} {
+		if _, err := ParseMillis(spec); !errors.Is(err, kind) {
+			t.Errorf("%s: expected %v, got %v", spec, kind, err)
+		}
+	}
+}
+
+func TestMillisScheduleString(t *testing.T) {
+	for spec, expected := range map[string]string{
+		"0,500 * * * * * *":    "0,500 * * * * * *",
+		"*/250 0 * * * *":      "0,250,500,750 0 * * * * *",
+		"* 0 * * * * *":        "* 0 * * * * *",
+		"10-19,30 0 0 * * * *": "10-19,30 0 0 * * * *",
+	} {
+		s, err := ParseMillis(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if str := s.(*MillisSchedule).String(); str != expected {
+			t.Errorf("%s: expected %q, got %q", spec, expected, str)
+		}
+	}
+}
+
+func TestWithMillisSpecs(t *testing.T) {
+	var calls int32
+	cron := New(WithMillisSpecs())
+	if _, err := cron.AddFunc("*/100 * * * * * *", func() { atomic.AddInt32(&calls, 1) }); err != nil {
+		t.Fatal(err)
+	}
+	cron.Start()
+	time.Sleep(ONE_SECOND)
+	cron.Stop()
+	if n := atomic.LoadInt32(&calls); n < 5 {
+		t.Errorf("expected activations every 100ms, got %d in a second", n)
+	}
+
+	if _, err := New(WithMillisSpecs(), WithResolution(time.Minute)).AddFunc("0,500 0 * * * * *", func() {}); err == nil {
+		t.Error("expected a spec finer than the resolution to be rejected")
+	}
+}
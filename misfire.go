@@ -0,0 +1,59 @@
+package cron
+
+import "time"
+
+// MisfirePolicy selects what happens to an activation that misfired, that
+// is, came due more than the entry's MisfireThreshold before it could be
+// dispatched, for instance because the process was suspended.
+type MisfirePolicy int
+
+const (
+	// MisfireRunNow runs the misfired activation now, and keeps the entry's
+	// schedule in phase: the next activation is the one that follows on
+	// from the missed one.
+	MisfireRunNow MisfirePolicy = iota
+
+	// MisfireSkip skips the misfired activation, recording it as skipped,
+	// and waits for the next one in phase.
+	MisfireSkip
+
+	// MisfireReschedule runs the misfired activation now and reschedules
+	// the entry from now, so that the phase of an @every interval starts
+	// over.
+	MisfireReschedule
+)
+
+// WithMisfire returns an EntryOption that applies the policy to activations
+// of the entry dispatched more than threshold after their scheduled time.
+// Without it, late activations are always run and the entry is rescheduled
+// from the time they ran, as with MisfireReschedule.
+func WithMisfire(threshold time.Duration, policy MisfirePolicy) EntryOption {
+	return func(e *Entry) {
+		e.MisfireThreshold = threshold
+		e.MisfirePolicy = policy
+	}
+}
+
+// misfired reports whether the activation of the entry scheduled at the
+// given time misfired, being dispatched at now.
+func (e *Entry) misfired(scheduled, now time.Time) bool {
+	return e.MisfireThreshold > 0 && now.Sub(scheduled) > e.MisfireThreshold
+}
+
+// inPhase returns the time from which to compute the next activation of an
+// entry whose activation scheduled at the given time misfired: the last
+// activation of its schedule not after now, so that the next one is in
+// phase with the missed one.  It is now if the policy does not keep the
+// phase.
+func (c *Cron) inPhase(e *Entry, scheduled, now time.Time) time.Time {
+	if e.MisfirePolicy == MisfireReschedule {
+		return now
+	}
+	for {
+		next := c.next(e, scheduled)
+		if next.IsZero() || next.After(now) {
+			return scheduled
+		}
+		scheduled = next
+	}
+}
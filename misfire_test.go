@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMisfired(t *testing.T) {
+	scheduled := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	e := &Entry{}
+	if e.misfired(scheduled, scheduled.Add(time.Hour)) {
+		t.Error("expected no misfires without a threshold")
+	}
+	e.MisfireThreshold = time.Minute
+	if e.misfired(scheduled, scheduled.Add(time.Minute)) {
+		t.Error("expected an activation within the threshold not to misfire")
+	}
+	if !e.misfired(scheduled, scheduled.Add(time.Minute+time.Second)) {
+		t.Error("expected an activation beyond the threshold to misfire")
+	}
+}
+
+func TestMisfireKeepsPhase(t *testing.T) {
+	cron := New()
+	scheduled := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := scheduled.Add(35 * time.Second)
+	e := &Entry{Schedule: Every(10 * time.Second), MisfirePolicy: MisfireRunNow}
+	if from := cron.inPhase(e, scheduled, now); !from.Equal(scheduled.Add(30 * time.Second)) {
+		t.Errorf("expected the last activation in phase, got %v", from)
+	}
+	if next := cron.next(e, cron.inPhase(e, scheduled, now)); !next.Equal(scheduled.Add(40 * time.Second)) {
+		t.Errorf("expected the next activation in phase, got %v", next)
+	}
+
+	e.MisfirePolicy = MisfireReschedule
+	if from := cron.inPhase(e, scheduled, now); !from.Equal(now) {
+		t.Errorf("expected a reschedule from now, got %v", from)
+	}
+}
+
+func TestMisfireSkip(t *testing.T) {
+	cron := New()
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+	runs := make(chan struct{}, 10)
+	id, _ := cron.AddFunc("@every 1s", func() { runs <- struct{}{} },
+		WithMisfire(100*time.Millisecond, MisfireSkip))
+	cron.Start()
+	defer cron.Stop()
+
+	cron.PauseAll()
+	time.Sleep(ONE_SECOND + 500*time.Millisecond)
+	cron.ResumeAll(RunMissedOnce)
+
+	event := expectEvent(t, events, RunSkipped)
+	if event.EntryID != id || event.Run.Error != "misfired" {
+		t.Errorf("expected the misfired activation to be skipped, got %+v", event.Run)
+	}
+	select {
+	case <-runs:
+		t.Error("expected the misfired activation not to run")
+	default:
+	}
+	if next := cron.Entry(id).Next; !next.After(time.Now()) {
+		t.Errorf("expected the next activation to be in the future, got %v", next)
+	}
+}
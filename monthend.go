@@ -0,0 +1,37 @@
+package cron
+
+import "time"
+
+// MonthEndSchedule activates at midnight at the start of the last day of
+// each month, as the "@monthend" descriptor specifies, for jobs such as
+// closing the month's books, which "@monthly", activating on the 1st, runs a
+// day late for.
+type MonthEndSchedule struct{}
+
+// Next returns the start of the next last day of a month after t, in the
+// location of t.
+func (MonthEndSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	for month := t.Month(); ; month++ {
+		// Date normalizes a month past December into the next year.
+		first := time.Date(t.Year(), month, 1, 0, 0, 0, 0, loc)
+		last := time.Date(first.Year(), first.Month(), daysIn(first.Month(), first.Year()), 0, 0, 0, 0, loc)
+		if last.After(t) {
+			return last
+		}
+	}
+}
+
+// String returns "@monthend", which Parse parses to the schedule.
+func (MonthEndSchedule) String() string {
+	return "@monthend"
+}
+
+// WithMonthlyAtMonthEnd returns an Option that makes AddJob and Parse read
+// "@monthly" as "@monthend", activating on the last day of each month rather
+// than the first.
+func WithMonthlyAtMonthEnd() Option {
+	return func(c *Cron) {
+		c.monthlyAtEnd = true
+	}
+}
@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthEndSchedule(t *testing.T) {
+	sched, err := Parse("@monthend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ny, _ := time.LoadLocation("America/New_York")
+	entries := []struct {
+		time, expected string
+	}{
+		{"Mon Jan 15 10:00 2024", "Wed Jan 31 00:00 2024"},
+		{"Wed Jan 31 00:00 2024", "Thu Feb 29 00:00 2024"},
+		{"Thu Feb 29 12:00 2024", "Sun Mar 31 00:00 2024"},
+		{"Wed Feb 1 00:00 2023", "Tue Feb 28 00:00 2023"},
+		{"Tue Dec 31 00:00 2024", "Fri Jan 31 00:00 2025"},
+		{"Tue Apr 29 23:59 2025", "Wed Apr 30 00:00 2025"},
+	}
+	for _, c := range entries {
+		actual := sched.Next(getTime(c.time))
+		if expected := getTime(c.expected); !actual.Equal(expected) {
+			t.Errorf("%s: expected %v, got %v", c.time, expected, actual)
+		}
+	}
+
+	next := sched.Next(time.Date(2024, time.October, 1, 0, 0, 0, 0, ny))
+	if next.Location() != ny || next.Day() != 31 || next.Hour() != 0 {
+		t.Errorf("expected midnight on 31 October in New York, got %v", next)
+	}
+	if s := sched.(MonthEndSchedule).String(); s != "@monthend" {
+		t.Errorf("expected @monthend, got %q", s)
+	}
+	if d := Describe(sched); d != "At 00:00:00, on the last day of the month" {
+		t.Errorf("unexpected description %q", d)
+	}
+	if rule, err := ExportRRULE(sched); err != nil || rule != "FREQ=MONTHLY;BYMONTHDAY=-1;BYHOUR=0;BYMINUTE=0;BYSECOND=0" {
+		t.Errorf("unexpected rule %q, %v", rule, err)
+	}
+}
+
+func TestWithMonthlyAtMonthEnd(t *testing.T) {
+	c := New(WithMonthlyAtMonthEnd(), WithParseCache(NewParseCache(10)))
+	sched, err := c.Parse("@monthly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sched.(MonthEndSchedule); !ok {
+		t.Errorf("expected @monthly at month end, got %T", sched)
+	}
+
+	// A cache shared with a Cron without the option keeps them apart.
+	cache := NewParseCache(10)
+	plain := New(WithParseCache(cache))
+	end := New(WithParseCache(cache), WithMonthlyAtMonthEnd())
+	plain.Parse("@monthly")
+	if sched, _ := end.Parse("@monthly"); sched != Schedule(MonthEndSchedule{}) {
+		t.Errorf("expected @monthly at month end, got %#v", sched)
+	}
+	if sched, _ := plain.Parse("@monthly"); sched == Schedule(MonthEndSchedule{}) {
+		t.Error("expected @monthly on the 1st without the option")
+	}
+}
@@ -0,0 +1,262 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrQuotaExceeded is returned when adding an entry to a namespace that
+// already holds its NamespaceLimits' MaxEntries.
+var ErrQuotaExceeded = errors.New("cron: namespace entry quota exceeded")
+
+// NamespaceLimits bounds the entries and runs of a namespace.  Zero values
+// mean no limit.
+type NamespaceLimits struct {
+	// MaxEntries is the number of entries the namespace may hold.
+	MaxEntries int
+
+	// MaxConcurrent is the number of the namespace's runs that may be in
+	// flight at once, counting those waiting out a delay or for a worker.
+	// Activations beyond it are skipped.
+	MaxConcurrent int
+}
+
+// WithNamespaceLimits returns an Option that applies the given limits to the
+// namespace with the given name.
+func WithNamespaceLimits(name string, limits NamespaceLimits) Option {
+	return func(c *Cron) {
+		c.namespace(name).limits = limits
+	}
+}
+
+// Namespace is a view of a Cron restricted to the entries of one tenant,
+// letting one process host the schedules of many.  Entries added through a
+// Namespace belong to it, and its other methods only see and affect its own
+// entries and events.  Entries added to the Cron directly belong to the
+// namespace with the empty name.
+type Namespace struct {
+	cron *Cron
+	ns   *namespace
+}
+
+// namespace is the state of a namespace, shared by its Namespace handles.
+type namespace struct {
+	name    string
+	limits  NamespaceLimits
+	running int32
+
+	// mu serializes additions to the namespace, so that the quota holds,
+	// and guards paused and pausedIDs, the entries the namespace paused
+	// rather than paused individually, which Resume resumes.
+	mu        sync.Mutex
+	paused    bool
+	pausedIDs map[EntryID]bool
+}
+
+// Namespace returns the namespace with the given name, creating it if it
+// does not exist yet.
+func (c *Cron) Namespace(name string) *Namespace {
+	return &Namespace{cron: c, ns: c.namespace(name)}
+}
+
+// namespace returns the state of the namespace with the given name, creating
+// it if need be.
+func (c *Cron) namespace(name string) *namespace {
+	c.namespacesMu.Lock()
+	defer c.namespacesMu.Unlock()
+	ns, ok := c.namespaces[name]
+	if !ok {
+		if c.namespaces == nil {
+			c.namespaces = make(map[string]*namespace)
+		}
+		ns = &namespace{name: name}
+		c.namespaces[name] = ns
+	}
+	return ns
+}
+
+// limited returns the state of the entry's namespace if it limits
+// concurrency, or nil.
+func (c *Cron) limited(e *Entry) *namespace {
+	c.namespacesMu.Lock()
+	defer c.namespacesMu.Unlock()
+	if ns, ok := c.namespaces[e.Namespace]; ok && ns.limits.MaxConcurrent > 0 {
+		return ns
+	}
+	return nil
+}
+
+// acquire counts a run in flight, returning false if the namespace's
+// concurrency limit is reached.  Runs are only acquired from the scheduler
+// goroutine, or with runningMu held, so the check cannot be raced.
+func (ns *namespace) acquire() bool {
+	if int(atomic.LoadInt32(&ns.running)) >= ns.limits.MaxConcurrent {
+		return false
+	}
+	atomic.AddInt32(&ns.running, 1)
+	return true
+}
+
+// release counts a run as no longer in flight.  It does nothing on a nil
+// namespace.
+func (ns *namespace) release() {
+	if ns != nil {
+		atomic.AddInt32(&ns.running, -1)
+	}
+}
+
+// Name returns the name of the namespace.
+func (n *Namespace) Name() string {
+	return n.ns.name
+}
+
+// AddFunc adds a func to the namespace to be run on the given schedule.
+func (n *Namespace) AddFunc(spec string, cmd func(), opts ...EntryOption) (EntryID, error) {
+	return n.AddJob(spec, FuncJob(cmd), opts...)
+}
+
+// AddContextFunc adds a context-aware func to the namespace to be run on the
+// given schedule.
+func (n *Namespace) AddContextFunc(spec string, cmd func(ctx context.Context) error, opts ...EntryOption) (EntryID, error) {
+	return n.AddJob(spec, ContextFuncJob(cmd), opts...)
+}
+
+// AddJob adds a Job to the namespace to be run on the given schedule.  It
+// returns ErrQuotaExceeded if the namespace is full.
+func (n *Namespace) AddJob(spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
+	schedule, err := n.cron.parse(spec)
+	if err != nil {
+		return 0, err
+	}
+	return n.schedule(&Entry{Schedule: schedule, Job: cmd, Spec: spec}, opts)
+}
+
+// Schedule adds a Job to the namespace to be run on the given schedule.  It
+// returns ErrQuotaExceeded if the namespace is full.
+func (n *Namespace) Schedule(schedule Schedule, cmd Job, opts ...EntryOption) (EntryID, error) {
+	return n.schedule(&Entry{Schedule: schedule, Job: cmd}, opts)
+}
+
+// schedule adds the entry to the Cron in the namespace, unless it is full.
+// Entries added while the namespace is paused start paused.
+func (n *Namespace) schedule(entry *Entry, opts []EntryOption) (EntryID, error) {
+	n.ns.mu.Lock()
+	defer n.ns.mu.Unlock()
+	if max := n.ns.limits.MaxEntries; max > 0 && len(n.Entries()) >= max {
+		return 0, ErrQuotaExceeded
+	}
+	paused := false
+	opts = append(opts[:len(opts):len(opts)], func(e *Entry) {
+		e.Namespace = n.ns.name
+		if n.ns.paused && !e.Paused {
+			e.Paused, paused = true, true
+		}
+	})
+	id := n.cron.schedule(entry, opts)
+	if paused {
+		n.ns.pausedIDs[id] = true
+	}
+	return id, nil
+}
+
+// Entries returns a snapshot of the namespace's entries.
+func (n *Namespace) Entries() []*Entry {
+	entries := []*Entry{}
+	for _, e := range n.cron.Entries() {
+		if e.Namespace == n.ns.name {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Entry returns a snapshot of the given entry, or nil if it couldn't be
+// found in the namespace.
+func (n *Namespace) Entry(id EntryID) *Entry {
+	if e := n.cron.Entry(id); e != nil && e.Namespace == n.ns.name {
+		return e
+	}
+	return nil
+}
+
+// Remove removes the given entry, if it is in the namespace.
+func (n *Namespace) Remove(id EntryID) {
+	n.cron.do(func() {
+		if e := n.find(id); e != nil {
+			n.cron.removeEntry(e)
+		}
+	})
+}
+
+// Trigger runs the given entry's job immediately, if it is in the namespace,
+// see Cron.Trigger.
+func (n *Namespace) Trigger(id EntryID) {
+	n.cron.do(func() {
+		if e := n.find(id); e != nil && !observe(e, n.cron.now()) {
+			n.cron.dispatch([]activation{{entry: e, scheduled: n.cron.now()}})
+		}
+	})
+}
+
+// Pause pauses every entry of the namespace, including those added until it
+// is resumed.
+func (n *Namespace) Pause() {
+	n.ns.mu.Lock()
+	defer n.ns.mu.Unlock()
+	n.ns.paused = true
+	if n.ns.pausedIDs == nil {
+		n.ns.pausedIDs = make(map[EntryID]bool)
+	}
+	n.each(func(e *Entry) {
+		if !e.Paused {
+			n.cron.pause(e)
+			n.ns.pausedIDs[e.ID] = true
+		}
+	})
+}
+
+// Resume resumes the entries of the namespace that Pause paused.  Entries
+// that were already paused individually stay paused.
+func (n *Namespace) Resume() {
+	n.ns.mu.Lock()
+	defer n.ns.mu.Unlock()
+	n.ns.paused = false
+	now := n.cron.now()
+	n.each(func(e *Entry) {
+		if n.ns.pausedIDs[e.ID] {
+			n.cron.resume(e, now)
+		}
+	})
+	n.ns.pausedIDs = nil
+}
+
+// Subscribe returns a channel on which the Cron sends the events about the
+// namespace's entries, and SchedulerStopped, see Cron.Subscribe.
+func (n *Namespace) Subscribe(buffer int) (<-chan Event, func()) {
+	return n.cron.events.subscribeFunc(buffer, func(event Event) bool {
+		return event.Type == SchedulerStopped || event.Namespace == n.ns.name
+	})
+}
+
+// find returns the entry with the given ID if it is in the namespace.  It
+// must be called with exclusive access to the entries.
+func (n *Namespace) find(id EntryID) *Entry {
+	if e := n.cron.find(id); e != nil && e.Namespace == n.ns.name {
+		return e
+	}
+	return nil
+}
+
+// each calls fn with every entry of the namespace, with exclusive access to
+// the entries.
+func (n *Namespace) each(fn func(e *Entry)) {
+	n.cron.do(func() {
+		for _, e := range n.cron.queue.list() {
+			if e.Namespace == n.ns.name {
+				fn(e)
+			}
+		}
+	})
+}
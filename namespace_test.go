@@ -0,0 +1,165 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNamespaceIsolation(t *testing.T) {
+	cron := New()
+	acme, globex := cron.Namespace("acme"), cron.Namespace("globex")
+	acmeID, _ := acme.AddFunc("@hourly", func() {})
+	globexID, _ := globex.AddFunc("@hourly", func() {})
+	cron.AddFunc("@hourly", func() {})
+
+	if entries := acme.Entries(); len(entries) != 1 || entries[0].ID != acmeID || entries[0].Namespace != "acme" {
+		t.Errorf("expected only acme's entry, got %v", entries)
+	}
+	if acme.Entry(globexID) != nil {
+		t.Error("expected globex's entry to be hidden from acme")
+	}
+	acme.Remove(globexID)
+	if globex.Entry(globexID) == nil {
+		t.Error("expected acme not to be able to remove globex's entry")
+	}
+	if len(cron.Entries()) != 3 || len(cron.Namespace("").Entries()) != 1 {
+		t.Error("expected the Cron to see every entry")
+	}
+}
+
+func TestNamespaceQuota(t *testing.T) {
+	cron := New(WithNamespaceLimits("acme", NamespaceLimits{MaxEntries: 2}))
+	acme := cron.Namespace("acme")
+	cron.Start()
+	defer cron.Stop()
+
+	for i := 0; i < 2; i++ {
+		if _, err := acme.AddFunc("@hourly", func() {}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := acme.AddFunc("@hourly", func() {})
+	if err != ErrQuotaExceeded {
+		t.Errorf("expected ErrQuotaExceeded, got %v", err)
+	}
+	if _, err := cron.Namespace("globex").AddFunc("@hourly", func() {}); err != nil {
+		t.Errorf("expected other namespaces to be unaffected, got %v", err)
+	}
+
+	id := acme.Entries()[0].ID
+	acme.Remove(id)
+	if _, err := acme.AddFunc("@hourly", func() {}); err != nil {
+		t.Errorf("expected room after a removal, got %v", err)
+	}
+}
+
+func TestNamespacePause(t *testing.T) {
+	cron := New()
+	acme, globex := cron.Namespace("acme"), cron.Namespace("globex")
+	acme.AddFunc("@hourly", func() {})
+	globex.AddFunc("@hourly", func() {})
+	cron.Start()
+	defer cron.Stop()
+
+	acme.Pause()
+	added, _ := acme.AddFunc("@hourly", func() {})
+	for _, e := range acme.Entries() {
+		if !e.Paused || !e.Next.IsZero() {
+			t.Errorf("expected entry %d to be paused", e.ID)
+		}
+	}
+	if globex.Entries()[0].Paused {
+		t.Error("expected globex to be unaffected")
+	}
+
+	acme.Resume()
+	for _, e := range acme.Entries() {
+		if e.Paused || e.Next.IsZero() {
+			t.Errorf("expected entry %d to be resumed", e.ID)
+		}
+	}
+	if acme.Entry(added) == nil {
+		t.Error("expected the entry added while paused")
+	}
+}
+
+// Resume leaves the entries paused individually before Pause paused.
+func TestNamespaceResumeKeepsEntriesPaused(t *testing.T) {
+	cron := New()
+	acme := cron.Namespace("acme")
+	own, _ := acme.AddFunc("@hourly", func() {})
+	other, _ := acme.AddFunc("@hourly", func() {})
+	cron.Start()
+	defer cron.Stop()
+
+	cron.Pause(own)
+	acme.Pause()
+	added, _ := acme.AddFunc("@hourly", func() {})
+	acme.Resume()
+	if !acme.Entry(own).Paused {
+		t.Error("expected the entry paused individually to stay paused")
+	}
+	for _, id := range []EntryID{other, added} {
+		if acme.Entry(id).Paused {
+			t.Errorf("expected entry %d to be resumed", id)
+		}
+	}
+}
+
+// Triggering an observer in a namespace sends the current time rather than
+// dispatching a run.
+func TestNamespaceTriggerObserver(t *testing.T) {
+	cron := New()
+	ticks := cron.Observe(Every(time.Hour), func(e *Entry) { e.Namespace = "acme" })
+	cron.Namespace("acme").Trigger(cron.Entries()[0].ID)
+	select {
+	case <-ticks:
+	default:
+		t.Fatal("expected a tick")
+	}
+}
+
+func TestNamespaceConcurrencyLimit(t *testing.T) {
+	cron := New(WithNamespaceLimits("acme", NamespaceLimits{MaxConcurrent: 1}))
+	acme := cron.Namespace("acme")
+	events, cancel := acme.Subscribe(100)
+	defer cancel()
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	var ids []EntryID
+	for i := 0; i < 2; i++ {
+		id, _ := acme.AddFunc("@hourly", func() {
+			started <- struct{}{}
+			<-release
+		})
+		ids = append(ids, id)
+	}
+
+	acme.Trigger(ids[0])
+	<-started
+	acme.Trigger(ids[1])
+	if event := expectEvent(t, events, RunSkipped); event.EntryID != ids[1] || event.Namespace != "acme" {
+		t.Errorf("expected entry %d to be skipped, got %+v", ids[1], event)
+	}
+	close(release)
+	<-cron.Stop().Done()
+
+	acme.Trigger(ids[1])
+	select {
+	case <-started:
+	case <-time.After(ONE_SECOND):
+		t.Error("expected the limit to be released once the run finished")
+	}
+	<-cron.Stop().Done()
+}
+
+func TestNamespaceEvents(t *testing.T) {
+	cron := New()
+	events, cancel := cron.Namespace("acme").Subscribe(100)
+	defer cancel()
+
+	cron.Namespace("globex").AddFunc("@hourly", func() {})
+	id, _ := cron.Namespace("acme").AddFunc("@hourly", func() {})
+	if event := <-events; event.Type != EntryAdded || event.EntryID != id {
+		t.Errorf("expected only acme's events, got %+v", event)
+	}
+}
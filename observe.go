@@ -0,0 +1,51 @@
+package cron
+
+import "time"
+
+// Observe returns a channel on which the Cron sends the activation times of
+// the schedule, for components that need a tick signal rather than a Job.
+// The Cron manages the subscription as an entry, which the options apply
+// to, so that it is listed by Entries and paused and removed as any other;
+// removing it closes the channel.
+//
+// The channel is buffered for one activation.  As with a time.Ticker,
+// activations sent while the receiver is behind are dropped.  No run is
+// dispatched for an activation, so none is recorded or subject to the
+// Cron's sharding, blackouts, budgets or rate limits.  Trigger sends the
+// current time, and Backfill returns an error.
+func (c *Cron) Observe(s Schedule, opts ...EntryOption) <-chan time.Time {
+	o := &observer{c: make(chan time.Time, 1)}
+	c.Schedule(s, o, opts...)
+	return o.c
+}
+
+// observer is the Job of an entry added by Observe.  Its channel is only
+// used from the scheduler goroutine.
+type observer struct {
+	c chan time.Time
+}
+
+// Run does nothing: the activations of an observer are sent by observe,
+// instead of dispatching runs.
+func (o *observer) Run() {}
+
+// observe sends the activation of the entry at t to its observer, if it has
+// one, reporting whether it does.
+func observe(e *Entry, t time.Time) bool {
+	o, ok := e.Job.(*observer)
+	if !ok {
+		return false
+	}
+	select {
+	case o.c <- t:
+	default:
+	}
+	return true
+}
+
+// unobserve closes the channel of the entry's observer, if it has one.
+func unobserve(e *Entry) {
+	if o, ok := e.Job.(*observer); ok {
+		close(o.c)
+	}
+}
@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// An observer receives the activation times of its schedule, without runs
+// being recorded, until it is removed.
+func TestObserve(t *testing.T) {
+	store := NewMemoryStore()
+	cron := New(WithStore(store))
+	ticks := cron.Observe(Every(time.Second), WithName("ticker"))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case tick := <-ticks:
+		if tick.Nanosecond() != 0 {
+			t.Errorf("expected a whole second, got %v", tick)
+		}
+	case <-time.After(2 * ONE_SECOND):
+		t.Fatal("expected a tick")
+	}
+
+	entries := cron.Entries()
+	if len(entries) != 1 || entries[0].Name != "ticker" {
+		t.Fatalf("expected the observer's entry, got %v", entries)
+	}
+	id := entries[0].ID
+	if history, _ := cron.History(id, time.Time{}, 0); len(history) != 0 {
+		t.Errorf("expected no runs, got %v", history)
+	}
+	if _, err := cron.Backfill(context.Background(), id, NewBackfill(Every(time.Second), time.Now().Add(-time.Minute), time.Now(), BackfillOptions{})); err == nil {
+		t.Error("expected backfilling an observer to fail")
+	}
+
+	cron.Remove(id)
+	timeout := time.After(2 * ONE_SECOND)
+	for {
+		select {
+		case _, ok := <-ticks:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("expected the channel to be closed")
+		}
+	}
+}
+
+// Triggering an observer sends the current time.
+func TestObserveTrigger(t *testing.T) {
+	cron := New()
+	ticks := cron.Observe(Every(time.Hour))
+	cron.Trigger(cron.Entries()[0].ID)
+	select {
+	case <-ticks:
+	default:
+		t.Fatal("expected a tick")
+	}
+}
@@ -0,0 +1,77 @@
+package cron
+
+import "time"
+
+// OffPeak is a daily window of low load, as times of day in the Cron's time
+// zone, see WithOffPeak.
+type OffPeak struct {
+	// Start and End are the window's bounds, as durations since midnight.
+	// A window whose End is not after its Start wraps past midnight, as
+	// 22:00 to 06:00 does.  They are taken modulo a day.
+	Start, End time.Duration
+}
+
+// WithOffPeak returns an Option that sets the daily windows of low load into
+// which the scheduled runs of flexible entries are shifted, see
+// WithFlexibility.
+func WithOffPeak(windows ...OffPeak) Option {
+	return func(c *Cron) {
+		c.offPeak = append([]OffPeak(nil), windows...)
+	}
+}
+
+// WithFlexibility returns an EntryOption that lets each scheduled run of the
+// entry be delayed by up to max, to the start of the next off-peak window set
+// with WithOffPeak, so that jobs that need not run at an exact time stay out
+// of business hours.  A run due within an off-peak window, or with none
+// starting within max of it, runs at its scheduled time.
+//
+// Like the jitter, the delay is applied when the run is dispatched: the
+// entry's Next and Prev times, and the run's scheduled time and RunKey, are
+// those of the schedule.  Runs started with Trigger are not delayed.
+func WithFlexibility(max time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.Flexibility = max
+	}
+}
+
+// offPeakDelay returns how long to delay the entry's run scheduled at t to
+// move it into an off-peak window.
+func (c *Cron) offPeakDelay(e *Entry, t time.Time) time.Duration {
+	if e.Flexibility <= 0 || len(c.offPeak) == 0 {
+		return 0
+	}
+	t = t.In(c.Location())
+	const day = 24 * time.Hour
+	// Starting with the day before, for windows wrapping past midnight.
+	for d := -1; d <= int(e.Flexibility/day)+1; d++ {
+		midnight := time.Date(t.Year(), t.Month(), t.Day()+d, 0, 0, 0, 0, t.Location())
+		delay := time.Duration(-1)
+		for _, w := range c.offPeak {
+			start, end := w.Start%day, w.End%day
+			if start < 0 {
+				start += day
+			}
+			if end < 0 {
+				end += day
+			}
+			if end <= start {
+				end += day
+			}
+			from, to := midnight.Add(start), midnight.Add(end)
+			switch {
+			case !t.Before(from) && t.Before(to):
+				return 0
+			case from.After(t) && from.Sub(t) <= e.Flexibility:
+				if wait := from.Sub(t); delay < 0 || wait < delay {
+					delay = wait
+				}
+			}
+		}
+		// The windows of later days start later still.
+		if delay >= 0 {
+			return delay
+		}
+	}
+	return 0
+}
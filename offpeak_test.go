@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOffPeakDelay(t *testing.T) {
+	cron := New(WithOffPeak(
+		OffPeak{Start: 22 * time.Hour, End: 6 * time.Hour},
+		OffPeak{Start: 12 * time.Hour, End: 13 * time.Hour},
+	))
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2024, time.March, day, hour, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		flex      time.Duration
+		scheduled time.Time
+		want      time.Duration
+	}{
+		// Already off-peak, in the wrapping window before and after midnight.
+		{4 * time.Hour, at(4, 23, 0), 0},
+		{4 * time.Hour, at(5, 3, 0), 0},
+		// Moved to the earliest window within reach.
+		{4 * time.Hour, at(5, 10, 30), 90 * time.Minute},
+		{12 * time.Hour, at(5, 14, 0), 8 * time.Hour},
+		{24 * time.Hour, at(5, 13, 0), 9 * time.Hour},
+		// No window within reach, so run at the scheduled time.
+		{time.Hour, at(5, 14, 0), 0},
+		{0, at(5, 10, 30), 0},
+	}
+	for _, test := range tests {
+		e := &Entry{Flexibility: test.flex}
+		if got := cron.offPeakDelay(e, test.scheduled); got != test.want {
+			t.Errorf("flexibility %v, scheduled %v: got delay %v, want %v", test.flex, test.scheduled, got, test.want)
+		}
+	}
+
+	if got := New().offPeakDelay(&Entry{Flexibility: time.Hour}, at(5, 10, 30)); got != 0 {
+		t.Errorf("without windows: got delay %v, want 0", got)
+	}
+}
+
+// A flexible run moved into an off-peak window keeps its scheduled time.
+func TestOffPeakDelaysRun(t *testing.T) {
+	now := time.Now().In(time.UTC)
+	start := now.Sub(now.Truncate(24*time.Hour)) + 500*time.Millisecond
+	store := NewMemoryStore()
+	cron := NewWithLocation(time.UTC, WithStore(store), WithOffPeak(OffPeak{Start: start + ONE_SECOND, End: start + time.Hour}))
+	id, _ := cron.AddFunc("* * * * * ?", func() {}, WithFlexibility(5*time.Second))
+	cron.Start()
+	defer cron.Stop()
+
+	time.Sleep(2*ONE_SECOND + 500*time.Millisecond)
+	history, _ := cron.History(id, time.Time{}, 0)
+	if len(history) == 0 {
+		t.Fatal("expected a run")
+	}
+	run := history[0]
+	for _, r := range history {
+		if r.Scheduled.Before(run.Scheduled) {
+			run = r
+		}
+	}
+	if run.Scheduled.Nanosecond() != 0 || run.Start.Sub(run.Scheduled) < 400*time.Millisecond {
+		t.Errorf("unexpected run times: scheduled %v, started %v", run.Scheduled, run.Start)
+	}
+}
@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestEntryBefore(t *testing.T) {
+	now := time.Now()
+	entries := []*Entry{
+		{ID: 4, Next: now},
+		{ID: 5},
+		{ID: 3, Next: now.Add(time.Second)},
+		{ID: 2, Next: now, Name: "b"},
+		{ID: 2, Next: now, Name: "a"},
+		{ID: 1},
+	}
+	sort.Sort(byTime(entries))
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name+string(rune('0'+e.ID)))
+	}
+	want := []string{"a2", "b2", "4", "3", "1", "5"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, got)
+		}
+	}
+}
+
+// Entries due at the same instant are dispatched in registration order,
+// whichever queue holds them.
+func TestSameTimeOrder(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithTimingWheel()}} {
+		batches := make(chan []*Entry, 1)
+		cron := New(append(opts, WithBatchHook(func(_ time.Time, batch []*Entry) {
+			select {
+			case batches <- batch:
+			default:
+			}
+		}))...)
+		for i := 0; i < 50; i++ {
+			cron.AddFunc("* * * * * ?", func() {})
+		}
+		cron.Start()
+
+		select {
+		case batch := <-batches:
+			for i, e := range batch {
+				if e.ID != EntryID(i+1) {
+					t.Errorf("expected entry %d at position %d, got %d", i+1, i, e.ID)
+				}
+			}
+		case <-time.After(ONE_SECOND):
+			t.Error("expected a batch")
+		}
+		cron.Stop()
+	}
+}
@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ScheduleTable stores SpecSchedules compactly, for programs holding
+// millions of them, such as multi-tenant services.  Each distinct bit set of
+// each field, such as that of a "*" minute, is stored once, and a schedule is
+// stored as six 16-bit indexes into them: 12 bytes, against the 48 of a
+// SpecSchedule and the overhead of allocating it.
+//
+// A ScheduleTable is safe for concurrent use.  Schedules cannot be removed
+// from it.
+type ScheduleTable struct {
+	mu     sync.RWMutex
+	values [6][]uint64          // the distinct bit sets of each field
+	index  [6]map[uint64]uint16 // of values
+	rows   [][6]uint16
+}
+
+// NewScheduleTable returns an empty ScheduleTable.
+func NewScheduleTable() *ScheduleTable {
+	t := &ScheduleTable{}
+	for i := range t.index {
+		t.index[i] = make(map[uint64]uint16)
+	}
+	return t
+}
+
+// PackedSchedule is a schedule stored in a ScheduleTable.  It is a small
+// value, which can be copied and added to a Cron like any Schedule.
+type PackedSchedule struct {
+	table *ScheduleTable
+	row   int
+}
+
+// Add stores the schedule in the table, returning the PackedSchedule for it.
+// It returns an error if one of the schedule's fields would be the table's
+// 65537th distinct bit set of that field.
+func (t *ScheduleTable) Add(s *SpecSchedule) (PackedSchedule, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fields := s.fields()
+	var row [6]uint16
+	for i, bits := range fields {
+		if _, ok := t.index[i][bits]; !ok && len(t.values[i]) > math.MaxUint16 {
+			return PackedSchedule{}, fmt.Errorf("cron: schedule table holds too many distinct %s fields", fieldNames[i])
+		}
+	}
+	for i, bits := range fields {
+		n, ok := t.index[i][bits]
+		if !ok {
+			n = uint16(len(t.values[i]))
+			t.index[i][bits] = n
+			t.values[i] = append(t.values[i], bits)
+		}
+		row[i] = n
+	}
+	t.rows = append(t.rows, row)
+	return PackedSchedule{t, len(t.rows) - 1}, nil
+}
+
+// Len returns the number of schedules in the table.
+func (t *ScheduleTable) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.rows)
+}
+
+// spec returns the SpecSchedule stored in a row.
+func (t *ScheduleTable) spec(row int) SpecSchedule {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	r := t.rows[row]
+	v := &t.values
+	return SpecSchedule{
+		Second: v[0][r[0]],
+		Minute: v[1][r[1]],
+		Hour:   v[2][r[2]],
+		Dom:    v[3][r[3]],
+		Month:  v[4][r[4]],
+		Dow:    v[5][r[5]],
+	}
+}
+
+// Next returns the next activation of the schedule, see SpecSchedule.Next.
+func (p PackedSchedule) Next(t time.Time) time.Time {
+	s := p.table.spec(p.row)
+	return s.Next(t)
+}
+
+// Spec returns a copy of the schedule as a SpecSchedule.
+func (p PackedSchedule) Spec() *SpecSchedule {
+	s := p.table.spec(p.row)
+	return &s
+}
+
+var fieldNames = [6]string{"second", "minute", "hour", "day of month", "month", "day of week"}
+
+// fields returns the bit sets of the schedule's fields, in the order of a
+// spec.
+func (s *SpecSchedule) fields() [6]uint64 {
+	return [6]uint64{s.Second, s.Minute, s.Hour, s.Dom, s.Month, s.Dow}
+}
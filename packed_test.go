@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduleTable(t *testing.T) {
+	table := NewScheduleTable()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var packed []PackedSchedule
+	var specs []Schedule
+	for i := 0; i < 1000; i++ {
+		spec := fmt.Sprintf("0 %d %d * * MON-FRI", i%60, i%24)
+		s, _ := Parse(spec)
+		p, err := table.Add(s.(*SpecSchedule))
+		if err != nil {
+			t.Fatal(err)
+		}
+		packed = append(packed, p)
+		specs = append(specs, s)
+	}
+	if table.Len() != 1000 {
+		t.Errorf("expected 1000 schedules, got %d", table.Len())
+	}
+	for i, p := range packed {
+		if !p.Next(from).Equal(specs[i].Next(from)) {
+			t.Errorf("%d: expected %v, got %v", i, specs[i].Next(from), p.Next(from))
+		}
+		if *p.Spec() != *specs[i].(*SpecSchedule) {
+			t.Errorf("%d: expected %v, got %v", i, specs[i], p.Spec())
+		}
+	}
+	// The seconds, days of the month, months and days of the week are
+	// shared by every schedule.
+	for i, n := range []int{1, 60, 24, 1, 1, 1} {
+		if len(table.values[i]) != n {
+			t.Errorf("expected %d distinct %s fields, got %d", n, fieldNames[i], len(table.values[i]))
+		}
+	}
+	if d := Describe(packed[0]); d != Describe(specs[0]) {
+		t.Errorf("expected %q, got %q", Describe(specs[0]), d)
+	}
+}
+
+func TestScheduleTableFull(t *testing.T) {
+	table := NewScheduleTable()
+	// Fill the distinct second fields with even bit sets, among them that
+	// of the second 1.
+	for i := 0; i <= 0xffff; i++ {
+		table.index[0][uint64(i)<<1] = uint16(i)
+		table.values[0] = append(table.values[0], uint64(i)<<1)
+	}
+	s, _ := Parse("1 0 0 * * *")
+	if _, err := table.Add(s.(*SpecSchedule)); err != nil {
+		t.Errorf("expected an existing field to be accepted, got %v", err)
+	}
+	s, _ = Parse("59 0 0 * * *")
+	if _, err := table.Add(s.(*SpecSchedule)); err == nil {
+		t.Error("expected an error for a new field in a full table")
+	}
+	if table.Len() != 1 {
+		t.Errorf("expected the failed schedule not to be added, got %d schedules", table.Len())
+	}
+}
+
+func TestScheduleTableConcurrent(t *testing.T) {
+	table := NewScheduleTable()
+	first, _ := Parse("0 30 9 * * *")
+	p, _ := table.Add(first.(*SpecSchedule))
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				s, _ := Parse(fmt.Sprintf("0 %d * * * *", i%60))
+				table.Add(s.(*SpecSchedule))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				p.Next(from)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPackedScheduleNextAllocations(t *testing.T) {
+	table := NewScheduleTable()
+	s, _ := Parse("0 30 9 * * MON-FRI")
+	p, _ := table.Add(s.(*SpecSchedule))
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if n := testing.AllocsPerRun(100, func() { p.Next(from) }); n > 0 {
+		t.Errorf("expected no allocations, got %v", n)
+	}
+}
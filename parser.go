@@ -1,11 +1,11 @@
 package cron
 
 import (
-	"fmt"
 	"math"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // ParseStandard returns a new crontab schedule representing the given standardSpec
@@ -17,16 +17,17 @@ import (
 //   - Standard crontab specs, e.g. "* * * * ?"
 //   - Descriptors, e.g. "@midnight", "@every 1h30m"
 func ParseStandard(standardSpec string) (Schedule, error) {
-	if standardSpec[0] == '@' {
+	if strings.HasPrefix(standardSpec, "@") {
 		return parseDescriptor(standardSpec)
 	}
 
 	// Split on whitespace.  We require exactly 5 fields.
 	// (minute) (hour) (day of month) (month) (day of week)
-	fields := strings.Fields(standardSpec)
-	if len(fields) != 5 {
-		return nil, fmt.Errorf("Expected exactly 5 fields, found %d: %s", len(fields), standardSpec)
+	var buf [6]string
+	if n := splitFields(standardSpec, &buf); n != 5 {
+		return nil, specErrorf(ErrFieldCount, "Expected exactly 5 fields, found %d: %s", n, standardSpec)
 	}
+	fields := buf[:5]
 
 	var err error
 	field := func(field string, r bounds) uint64 {
@@ -59,26 +60,28 @@ func ParseStandard(standardSpec string) (Schedule, error) {
 }
 
 // Parse returns a new crontab schedule representing the given spec.
-// It returns a descriptive error if the spec is not valid.
+// It returns a descriptive error if the spec is not valid.  Like the other
+// parsers of this package, it keeps no state and is safe for concurrent use.
 //
 // It accepts
 //   - Full crontab specs, e.g. "* * * * * ?"
 //   - Descriptors, e.g. "@midnight", "@every 1h30m"
 func Parse(spec string) (Schedule, error) {
-	if spec[0] == '@' {
+	if strings.HasPrefix(spec, "@") {
 		return parseDescriptor(spec)
 	}
 
 	// Split on whitespace.  We require 5 or 6 fields.
 	// (second) (minute) (hour) (day of month) (month) (day of week, optional)
-	fields := strings.Fields(spec)
-	if len(fields) != 5 && len(fields) != 6 {
-		return nil, fmt.Errorf("Expected 5 or 6 fields, found %d: %s", len(fields), spec)
+	var fields [6]string
+	n := splitFields(spec, &fields)
+	if n != 5 && n != 6 {
+		return nil, specErrorf(ErrFieldCount, "Expected 5 or 6 fields, found %d: %s", n, spec)
 	}
 
 	// If a sixth field is not provided (DayOfWeek), then it is equivalent to star.
-	if len(fields) == 5 {
-		fields = append(fields, "*")
+	if n == 5 {
+		fields[5] = "*"
 	}
 
 	var err error
@@ -112,13 +115,56 @@ func Parse(spec string) (Schedule, error) {
 	}, nil
 }
 
+// splitFields stores the fields of spec, separated by whitespace as
+// strings.Fields separates them, in fields, and returns how many there are,
+// which may be more than it can store.  Unlike strings.Fields it does not
+// allocate, which matters to programs parsing many specs.
+func splitFields(spec string, fields *[6]string) int {
+	for i := 0; i < len(spec); i++ {
+		if spec[i] >= utf8.RuneSelf {
+			all := strings.Fields(spec)
+			copy(fields[:], all)
+			return len(all)
+		}
+	}
+	n := 0
+	for i := 0; i < len(spec); {
+		for i < len(spec) && asciiSpace(spec[i]) {
+			i++
+		}
+		start := i
+		for i < len(spec) && !asciiSpace(spec[i]) {
+			i++
+		}
+		if i > start {
+			if n < len(fields) {
+				fields[n] = spec[start:i]
+			}
+			n++
+		}
+	}
+	return n
+}
+
+func asciiSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\v' || c == '\f' || c == '\r'
+}
+
 // getField returns an Int with the bits set representing all of the times that
 // the field represents or error parsing field value.  A "field" is a comma-separated
 // list of "ranges".
 func getField(field string, r bounds) (uint64, error) {
 	var bits uint64
-	ranges := strings.FieldsFunc(field, func(r rune) bool { return r == ',' })
-	for _, expr := range ranges {
+	for field != "" {
+		expr := field
+		if i := strings.IndexByte(field, ','); i >= 0 {
+			expr, field = field[:i], field[i+1:]
+		} else {
+			field = ""
+		}
+		if expr == "" {
+			continue
+		}
 		bit, err := getRange(expr, r)
 		if err != nil {
 			return bits, err
@@ -132,45 +178,63 @@ func getField(field string, r bounds) (uint64, error) {
 //   number | number "-" number [ "/" number ]
 // or error parsing range.
 func getRange(expr string, r bounds) (uint64, error) {
+	start, end, step, star, err := parseRange(expr, r)
+	if err != nil {
+		return 0, err
+	}
+	if star {
+		return getBits(start, end, step) | starBit, nil
+	}
+	return getBits(start, end, step), nil
+}
+
+// parseRange returns the start, end and step of the range the expression
+// indicates, in the syntax of getRange, and whether it begins with a star,
+// or error parsing range.  Fields too wide for a single bit set, such as
+// milliseconds, set their bits from these themselves.
+func parseRange(expr string, r bounds) (start, end, step uint, star bool, err error) {
 	var (
-		start, end, step uint
-		rangeAndStep     = strings.Split(expr, "/")
-		lowAndHigh       = strings.Split(rangeAndStep[0], "-")
-		singleDigit      = len(lowAndHigh) == 1
-		err              error
-		zero             = uint64(0)
+		rangeExpr = expr
+		stepExpr  string
+		hasStep   bool
 	)
+	if i := strings.IndexByte(expr, '/'); i >= 0 {
+		rangeExpr, stepExpr, hasStep = expr[:i], expr[i+1:], true
+	}
+	low, high, singleDigit := rangeExpr, "", true
+	if i := strings.IndexByte(rangeExpr, '-'); i >= 0 {
+		low, high, singleDigit = rangeExpr[:i], rangeExpr[i+1:], false
+	}
 
-	var extra_star uint64
-	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
+	if low == "*" || low == "?" {
 		start = r.min
 		end = r.max
-		extra_star = starBit
+		star = true
 	} else {
-		start, err = parseIntOrName(lowAndHigh[0], r.names)
+		start, err = parseIntOrName(low, r.names)
 		if err != nil {
-			return zero, err
+			return 0, 0, 0, false, err
 		}
-		switch len(lowAndHigh) {
-		case 1:
+		switch {
+		case singleDigit:
 			end = start
-		case 2:
-			end, err = parseIntOrName(lowAndHigh[1], r.names)
+		case strings.IndexByte(high, '-') < 0:
+			end, err = parseIntOrName(high, r.names)
 			if err != nil {
-				return zero, err
+				return 0, 0, 0, false, err
 			}
 		default:
-			return zero, fmt.Errorf("Too many hyphens: %s", expr)
+			return 0, 0, 0, false, specErrorf(ErrSyntax, "Too many hyphens: %s", expr)
 		}
 	}
 
-	switch len(rangeAndStep) {
-	case 1:
+	switch {
+	case !hasStep:
 		step = 1
-	case 2:
-		step, err = mustParseInt(rangeAndStep[1])
+	case strings.IndexByte(stepExpr, '/') < 0:
+		step, err = mustParseInt(stepExpr)
 		if err != nil {
-			return zero, err
+			return 0, 0, 0, false, &specError{ErrBadStep, err.Error()}
 		}
 
 		// Special handling: "N/step" means "N-max/step".
@@ -178,43 +242,64 @@ func getRange(expr string, r bounds) (uint64, error) {
 			end = r.max
 		}
 	default:
-		return zero, fmt.Errorf("Too many slashes: %s", expr)
+		return 0, 0, 0, false, specErrorf(ErrBadStep, "Too many slashes: %s", expr)
 	}
 
 	if start < r.min {
-		return zero, fmt.Errorf("Beginning of range (%d) below minimum (%d): %s", start, r.min, expr)
+		return 0, 0, 0, false, specErrorf(ErrValueOutOfRange, "Beginning of range (%d) below minimum (%d): %s", start, r.min, expr)
 	}
 	if end > r.max {
-		return zero, fmt.Errorf("End of range (%d) above maximum (%d): %s", end, r.max, expr)
+		return 0, 0, 0, false, specErrorf(ErrValueOutOfRange, "End of range (%d) above maximum (%d): %s", end, r.max, expr)
 	}
 	if start > end {
-		return zero, fmt.Errorf("Beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
+		return 0, 0, 0, false, specErrorf(ErrValueOutOfRange, "Beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
 	}
 	if step == 0 {
-		return zero, fmt.Errorf("Step of range should be a positive number: %s", expr)
+		return 0, 0, 0, false, specErrorf(ErrBadStep, "Step of range should be a positive number: %s", expr)
 	}
 
-	return getBits(start, end, step) | extra_star, nil
+	return start, end, step, star, nil
 }
 
+// maxNameLen bounds the length of the names of months and days of the week.
+const maxNameLen = 16
+
 // parseIntOrName returns the (possibly-named) integer contained in expr.
 func parseIntOrName(expr string, names map[string]uint) (uint, error) {
-	if names != nil {
-		if namedInt, ok := names[strings.ToLower(expr)]; ok {
+	if names != nil && len(expr) <= maxNameLen {
+		// Lower the case into a buffer rather than with strings.ToLower,
+		// which allocates.  Names are ASCII.
+		var lower [maxNameLen]byte
+		for i := 0; i < len(expr); i++ {
+			c := expr[i]
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			lower[i] = c
+		}
+		if namedInt, ok := names[string(lower[:len(expr)])]; ok {
 			return namedInt, nil
 		}
 	}
-	return mustParseInt(expr)
+	num, err := mustParseInt(expr)
+	if err != nil && names != nil {
+		candidates := make([]string, 0, len(names))
+		for name := range names {
+			candidates = append(candidates, name)
+		}
+		return 0, withSuggestion(err, expr, candidates)
+	}
+	return num, err
 }
 
 // mustParseInt parses the given expression as an int or returns an error.
 func mustParseInt(expr string) (uint, error) {
 	num, err := strconv.Atoi(expr)
 	if err != nil {
-		return 0, fmt.Errorf("Failed to parse int from %s: %s", expr, err)
+		return 0, specErrorf(ErrSyntax, "Failed to parse int from %s: %s", expr, err)
 	}
 	if num < 0 {
-		return 0, fmt.Errorf("Negative number (%d) not allowed: %s", num, expr)
+		return 0, specErrorf(ErrValueOutOfRange, "Negative number (%d) not allowed: %s", num, expr)
 	}
 
 	return uint(num), nil
@@ -264,6 +349,9 @@ func parseDescriptor(descriptor string) (Schedule, error) {
 			Dow:    all(dow),
 		}, nil
 
+	case "@monthend":
+		return MonthEndSchedule{}, nil
+
 	case "@weekly":
 		return &SpecSchedule{
 			Second: 1 << seconds.min,
@@ -299,10 +387,15 @@ func parseDescriptor(descriptor string) (Schedule, error) {
 	if strings.HasPrefix(descriptor, every) {
 		duration, err := time.ParseDuration(descriptor[len(every):])
 		if err != nil {
-			return nil, fmt.Errorf("Failed to parse duration %s: %s", descriptor, err)
+			return nil, specErrorf(ErrSyntax, "Failed to parse duration %s: %s", descriptor, err)
 		}
 		return Every(duration), nil
 	}
 
-	return nil, fmt.Errorf("Unrecognized descriptor: %s", descriptor)
+	err := specErrorf(ErrUnknownDescriptor, "Unrecognized descriptor: %s", descriptor)
+	return nil, withSuggestion(err, descriptor, descriptors)
 }
+
+// descriptors lists the descriptors parseDescriptor accepts, for suggesting
+// one in place of a misspelled descriptor.
+var descriptors = []string{"@annually", "@daily", "@every", "@hourly", "@midnight", "@monthend", "@monthly", "@weekly", "@yearly"}
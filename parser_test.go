@@ -1,6 +1,9 @@
 package cron
 
 import (
+	"bytes"
+	"log"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
@@ -55,6 +58,61 @@ func TestRange(t *testing.T) {
 	}
 }
 
+func TestNamedSteps(t *testing.T) {
+	ranges := []struct {
+		expr     string
+		r        bounds
+		expected []uint
+	}{
+		{"MON-FRI/2", dow, []uint{1, 3, 5}},
+		{"mon-fri/2", dow, []uint{1, 3, 5}},
+		{"SUN-SAT/3", dow, []uint{0, 3, 6}},
+		{"MON/2", dow, []uint{1, 3, 5}},
+		{"MON-5/2", dow, []uint{1, 3, 5}},
+		{"JAN-DEC/3", months, []uint{1, 4, 7, 10}},
+		{"Jan/3", months, []uint{1, 4, 7, 10}},
+		{"FEB-AUG/2", months, []uint{2, 4, 6, 8}},
+		{"NOV/2", months, []uint{11}},
+	}
+	for _, c := range ranges {
+		bits, err := getRange(c.expr, c.r)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.expr, err)
+			continue
+		}
+		if actual := values(bits, c.r); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => expected %v, got %v", c.expr, c.expected, actual)
+		}
+	}
+
+	// Each item of a list takes its own step, named or not.
+	fields := []struct {
+		field    string
+		r        bounds
+		expected []uint
+	}{
+		{"MON/2,SUN", dow, []uint{0, 1, 3, 5}},
+		{"SUN,TUE-SAT/2", dow, []uint{0, 2, 4, 6}},
+		{"JAN/6,MAR/6", months, []uint{1, 3, 7, 9}},
+	}
+	for _, c := range fields {
+		bits, err := getField(c.field, c.r)
+		if err != nil {
+			t.Errorf("%s => unexpected error %v", c.field, err)
+			continue
+		}
+		if actual := values(bits, c.r); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => expected %v, got %v", c.field, c.expected, actual)
+		}
+	}
+
+	for _, expr := range []string{"FRI-MON/2", "MON-FRI/0", "MON-FRI/TUE"} {
+		if _, err := getRange(expr, dow); err == nil {
+			t.Errorf("%s => expected an error", expr)
+		}
+	}
+}
+
 func TestField(t *testing.T) {
 	fields := []struct {
 		expr     string
@@ -175,6 +233,10 @@ func TestParse(t *testing.T) {
 			expr: "* * * *",
 			err:  "Expected 5 or 6 fields",
 		},
+		{
+			expr: "",
+			err:  "Expected 5 or 6 fields, found 0",
+		},
 	}
 
 	for _, c := range entries {
@@ -213,6 +275,10 @@ func TestStandardSpecSchedule(t *testing.T) {
 			expr: "* * * *",
 			err:  "Expected exactly 5 fields",
 		},
+		{
+			expr: "",
+			err:  "Expected exactly 5 fields, found 0",
+		},
 	}
 
 	for _, c := range entries {
@@ -228,3 +294,95 @@ func TestStandardSpecSchedule(t *testing.T) {
 		}
 	}
 }
+
+// The specs parsed by the allocation test and the benchmarks: plain
+// numbers, stars, ranges, steps and names.
+var benchmarkSpecs = []string{
+	"0 30 9 * * MON-FRI",
+	"*/15 * * * * *",
+	"0 0 0,12 1-15 JAN,jul ?",
+	"0 5 4 * * sun",
+}
+
+func TestParseAllocations(t *testing.T) {
+	for _, spec := range benchmarkSpecs {
+		// The returned SpecSchedule is the only allocation.
+		if n := testing.AllocsPerRun(100, func() { Parse(spec) }); n > 1 {
+			t.Errorf("%s: expected 1 allocation, got %v", spec, n)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() { ParseStandard("30 9 * * MON-FRI") }); n > 1 {
+		t.Errorf("ParseStandard: expected 1 allocation, got %v", n)
+	}
+}
+
+func TestParseFieldsSeparators(t *testing.T) {
+	expected, _ := Parse("0 30 9 * * MON-FRI")
+	for _, spec := range []string{
+		"  0\t30 9 *\n* MON-FRI ",
+		"0 30 9 * * MON-FRI",
+	} {
+		actual, err := Parse(spec)
+		if err != nil || !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%q => expected %v, got %v, %v", spec, expected, actual, err)
+		}
+	}
+	if _, err := Parse("0 0 0 * * * * *"); err == nil || !strings.Contains(err.Error(), "found 8") {
+		t.Errorf("expected an error counting 8 fields, got %v", err)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Parse(benchmarkSpecs[i%len(benchmarkSpecs)])
+	}
+}
+
+func BenchmarkParseStandard(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseStandard("30 9 * * MON-FRI")
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, spec := range append(benchmarkSpecs,
+		"", " ", "@", "@every", "@every 0s", "@every -1h", "* * * * * * *",
+		"0-", "-0 * * * *", "1/0 * * * *", "*/99999999999999999999 * * * *",
+		"0 0 0 ? JAN-DEC SUN-SAT", " * * * * *", "0,,1 * * * * *") {
+		f.Add(spec)
+	}
+	f.Fuzz(func(t *testing.T, spec string) {
+		for _, parse := range []func(string) (Schedule, error){Parse, ParseStandard} {
+			schedule, err := parse(spec)
+			if err != nil {
+				if schedule != nil || err.Error() == "" {
+					t.Errorf("%q: expected a nil schedule and a message with the error, got %v, %q", spec, schedule, err)
+				}
+				continue
+			}
+			if schedule == nil {
+				t.Errorf("%q: expected a schedule or an error", spec)
+			}
+		}
+	})
+}
+
+// Invalid specs are reported with errors alone, so that programs embedding
+// the parser see nothing on their logs.
+func TestParseDoesNotLog(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	for _, spec := range []string{"", "x", "* * *", "60 * * * * *", "1-2-3 * * * *", "*/0 * * * *", "@every x", "@never"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+		ParseStandard(spec)
+		ParseRobfig(spec)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("expected nothing to be logged, got %q", buf.String())
+	}
+}
@@ -0,0 +1,97 @@
+package cron
+
+import "time"
+
+// NextStore is implemented by Stores that can persist the next activation
+// time of named entries, see WithPersistedNext.
+type NextStore interface {
+	// SaveNext records the next activation time of the named entry, and
+	// the spec it was computed from.
+	SaveNext(name, spec string, next time.Time) error
+
+	// LoadNext returns the recorded next activation time of the named
+	// entry and its spec, or the zero time if there is none.
+	LoadNext(name string) (spec string, next time.Time, err error)
+}
+
+// WithPersistedNext returns an Option that persists the next activation time
+// of each named entry in the Store, which must implement NextStore, and
+// resumes from it when the entry is first scheduled, instead of computing it
+// from the current time.  That way @every intervals and StartAt anchored
+// schedules keep their phase across restarts.  If the persisted activation
+// was missed while the process was down, the entry resumes at its first
+// activation from then that is not in the past.  If the entry's spec changed
+// since, the persisted activation is ignored, as it is for an entry added
+// without a spec whose persisted activation is later than its next one from
+// now, as after a change to a shorter interval.
+//
+// Entries are persisted under their name, prefixed with their namespace and
+// a slash if they have one; entries without a name are not persisted.
+func WithPersistedNext() Option {
+	return func(c *Cron) {
+		c.persistNext = true
+	}
+}
+
+// nextStore returns the Store to persist the entry's next activation time in
+// and its name there, or nil if it is not persisted.
+func (c *Cron) nextStore(e *Entry) (NextStore, string) {
+	if !c.persistNext || e.Name == "" {
+		return nil, ""
+	}
+	s, ok := c.store.(NextStore)
+	if !ok {
+		return nil, ""
+	}
+	if e.Namespace != "" {
+		return s, e.Namespace + "/" + e.Name
+	}
+	return s, e.Name
+}
+
+// restoreNext returns the entry's imported or persisted next activation
+// time, moved past any activations missed before now, or the zero time if it
+// has none.  Only a ConstantDelaySchedule keeps the phase of the restored
+// time, which it is moved ahead in whole delays to keep; other schedules
+// activate next as they would without it.
+func (c *Cron) restoreNext(e *Entry, now time.Time) time.Time {
+	next := e.imported
+	if s, name := c.nextStore(e); next.IsZero() && s != nil {
+		spec, persisted, err := s.LoadNext(name)
+		if err != nil {
+			c.logf("cron: failed to load the next activation of %s: %v", name, err)
+			return time.Time{}
+		}
+		switch {
+		case spec != e.Spec:
+			// Computed from a schedule the entry no longer has.
+			return time.Time{}
+		case spec == "" && persisted.After(c.next(e, now)):
+			return time.Time{}
+		}
+		next = persisted
+	}
+	if next.IsZero() || !next.Before(now) {
+		return next
+	}
+	s, ok := e.schedule().(ConstantDelaySchedule)
+	if !ok {
+		return c.next(e, now)
+	}
+	next = next.Add(now.Sub(next) / s.Delay * s.Delay)
+	for next.Before(now) {
+		next = c.next(e, next)
+	}
+	return next
+}
+
+// saveNext persists the entry's next activation time, if it has one.
+func (c *Cron) saveNext(e *Entry) {
+	s, name := c.nextStore(e)
+	if s == nil || e.Next.IsZero() {
+		return
+	}
+	if err := s.SaveNext(name, e.Spec, e.Next); err != nil {
+		c.logf("cron: failed to save the next activation of %s: %v", name, err)
+	}
+}
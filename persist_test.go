@@ -0,0 +1,106 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPersistedNextSurvivesRestart(t *testing.T) {
+	store := NewMemoryStore()
+	first := New(WithStore(store), WithPersistedNext())
+	id, _ := first.AddFunc("@every 1h", func() {}, WithName("sync"))
+	first.Start()
+	next := first.Entry(id).Next
+	first.Stop()
+
+	time.Sleep(10 * time.Millisecond)
+	second := New(WithStore(store), WithPersistedNext())
+	id, _ = second.AddFunc("@every 1h", func() {}, WithName("sync"))
+	second.Start()
+	defer second.Stop()
+	if got := second.Entry(id).Next; !got.Equal(next) {
+		t.Errorf("expected the restarted entry to resume at %v, got %v", next, got)
+	}
+}
+
+func TestPersistedNextSkipsMissed(t *testing.T) {
+	store := NewMemoryStore()
+	missed := time.Now().Add(-90 * time.Minute).Truncate(time.Second)
+	store.SaveNext("acme/sync", "@every 1h", missed)
+
+	cron := New(WithStore(store), WithPersistedNext())
+	id, _ := cron.Namespace("acme").AddFunc("@every 1h", func() {}, WithName("sync"))
+	cron.Start()
+	defer cron.Stop()
+
+	expected := missed.Add(2 * time.Hour)
+	if next := cron.Entry(id).Next; !next.Equal(expected) {
+		t.Errorf("expected the first activation in phase after now, %v, got %v", expected, next)
+	}
+	if _, saved, _ := store.LoadNext("acme/sync"); !saved.Equal(expected) {
+		t.Errorf("expected %v to be saved, got %v", expected, saved)
+	}
+}
+
+// Restoring after a long outage moves an interval ahead in phase without
+// stepping through every missed activation.
+func TestPersistedNextLongOutage(t *testing.T) {
+	store := NewMemoryStore()
+	missed := time.Now().AddDate(-1, 0, 0).Truncate(time.Second).Add(-17 * time.Minute)
+	store.SaveNext("sync", "@every 1h", missed)
+	store.SaveNext("tick", "* * * * * *", missed)
+
+	cron := New(WithStore(store), WithPersistedNext())
+	sync, _ := cron.AddFunc("@every 1h", func() {}, WithName("sync"))
+	tick, _ := cron.AddFunc("* * * * * *", func() {}, WithName("tick"))
+	begin := time.Now()
+	cron.Start()
+	defer cron.Stop()
+	next := cron.Entry(sync).Next
+	if elapsed := time.Since(begin); elapsed > 500*time.Millisecond {
+		t.Errorf("expected a quick restore, took %v", elapsed)
+	}
+
+	if next.Before(begin) || next.After(begin.Add(time.Hour)) || next.Sub(missed)%time.Hour != 0 {
+		t.Errorf("expected the first activation in phase with %v within an hour, got %v", missed, next)
+	}
+	if next := cron.Entry(tick).Next; next.Before(begin) || next.After(time.Now().Add(time.Second)) {
+		t.Errorf("expected the next second, got %v", next)
+	}
+}
+
+// A persisted activation computed from another spec is ignored, so that an
+// entry whose interval was shortened between deploys does not wait out the
+// old one.
+func TestPersistedNextSpecChanged(t *testing.T) {
+	store := NewMemoryStore()
+	stale := time.Now().Add(23 * time.Hour).Truncate(time.Second)
+	store.SaveNext("sync", "@every 24h", stale)
+	store.SaveNext("tick", "", stale)
+
+	cron := New(WithStore(store), WithPersistedNext())
+	id, _ := cron.AddFunc("@every 1m", func() {}, WithName("sync"))
+	tick := cron.Schedule(Every(time.Minute), FuncJob(func() {}), WithName("tick"))
+	cron.Start()
+	defer cron.Stop()
+
+	for _, id := range []EntryID{id, tick} {
+		if next := cron.Entry(id).Next; next.After(time.Now().Add(time.Minute)) {
+			t.Errorf("entry %d: expected the next activation within a minute, got %v", id, next)
+		}
+	}
+	if spec, saved, _ := store.LoadNext("sync"); spec != "@every 1m" || saved.Equal(stale) {
+		t.Errorf("expected the new spec's activation to be saved, got %q at %v", spec, saved)
+	}
+}
+
+func TestPersistedNextUnnamed(t *testing.T) {
+	store := NewMemoryStore()
+	cron := New(WithStore(store), WithPersistedNext())
+	cron.AddFunc("@every 1h", func() {})
+	cron.Start()
+	defer cron.Stop()
+	if len(store.next) != 0 {
+		t.Errorf("expected unnamed entries not to be persisted, got %v", store.next)
+	}
+}
@@ -0,0 +1,78 @@
+package cron
+
+import "time"
+
+// PredicateSchedule is a SpecSchedule that activates only at those of its
+// activations for which a predicate returns true, such as "only in leap
+// years" or "only in even weeks", which no spec can express.  The
+// predicate is evaluated within the spec's search, with each activation it
+// finds in the location of the time Next is given, and a rejected
+// activation resumes the search from there, so Next still gives up after
+// five years.
+type PredicateSchedule struct {
+	Spec      *SpecSchedule
+	Predicate func(time.Time) bool
+}
+
+// Next returns the next activation of the spec, after the given time, for
+// which the predicate returns true, or the zero time if there is none.
+func (s PredicateSchedule) Next(t time.Time) time.Time {
+	return s.Spec.next(t, s.Predicate)
+}
+
+// Where returns the schedule restricted to the activations for which the
+// predicate returns true.
+func (s *SpecSchedule) Where(predicate func(time.Time) bool) PredicateSchedule {
+	return PredicateSchedule{Spec: s, Predicate: predicate}
+}
+
+// Where returns the schedule further restricted to the activations for
+// which the predicate returns true, so that both predicates must hold.
+func (s PredicateSchedule) Where(predicate func(time.Time) bool) PredicateSchedule {
+	prev := s.Predicate
+	return PredicateSchedule{Spec: s.Spec, Predicate: func(t time.Time) bool { return prev(t) && predicate(t) }}
+}
+
+// WithPredicate returns an EntryOption that restricts the entry's
+// activations to those for which the predicate returns true.  A
+// SpecSchedule or PredicateSchedule, possibly in a LocationSchedule,
+// evaluates it within its search, see PredicateSchedule; other schedules
+// are searched on from each activation the predicate rejects, for up to
+// five years.
+//
+// The predicate is code, so Export leaves it out, as it does jobs.
+func WithPredicate(predicate func(time.Time) bool) EntryOption {
+	return func(e *Entry) {
+		e.Schedule = where(e.Schedule, predicate)
+	}
+}
+
+// where returns the schedule restricted by the predicate.
+func where(schedule Schedule, predicate func(time.Time) bool) Schedule {
+	switch s := schedule.(type) {
+	case *SpecSchedule:
+		return s.Where(predicate)
+	case PredicateSchedule:
+		return s.Where(predicate)
+	case LocationSchedule:
+		return LocationSchedule{Schedule: where(s.Schedule, predicate), Location: s.Location}
+	}
+	return filteredSchedule{schedule, predicate}
+}
+
+// filteredSchedule activates at the activations of the schedule for which
+// the predicate returns true.
+type filteredSchedule struct {
+	schedule  Schedule
+	predicate func(time.Time) bool
+}
+
+func (s filteredSchedule) Next(t time.Time) time.Time {
+	limit := t.AddDate(5, 0, 0)
+	for next := s.schedule.Next(t); !next.IsZero() && !next.After(limit); next = s.schedule.Next(next) {
+		if s.predicate(next) {
+			return next
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func leapYear(t time.Time) bool { return daysIn(time.February, t.Year()) == 29 }
+
+func evenWeek(t time.Time) bool {
+	_, week := t.ISOWeek()
+	return week%2 == 0
+}
+
+func TestPredicateSchedule(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		spec      string
+		predicate func(time.Time) bool
+		expected  []time.Time
+	}{
+		{"0 0 0 1 Jan *", leapYear, []time.Time{
+			time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"0 0 9 * * MON", evenWeek, []time.Time{
+			time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC),
+		}},
+		// The predicate may reject times within a day.
+		{"0 0 * * * *", func(t time.Time) bool { return t.Hour()%5 == 4 }, []time.Time{
+			time.Date(2025, 1, 1, 4, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC),
+		}},
+		{"0 0 0 * * *", func(time.Time) bool { return false }, []time.Time{{}}},
+	}
+	for _, test := range tests {
+		s := mustParse(test.spec).(*SpecSchedule).Where(test.predicate)
+		next := from
+		for _, expected := range test.expected {
+			next = s.Next(next)
+			if !next.Equal(expected) {
+				t.Errorf("%s: expected %v, got %v", test.spec, expected, next)
+				break
+			}
+		}
+	}
+}
+
+func TestPredicateScheduleWhere(t *testing.T) {
+	s := mustParse("0 0 0 1 * *").(*SpecSchedule).Where(leapYear).Where(func(t time.Time) bool { return t.Month() == time.March })
+	if next := s.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); !next.Equal(time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected both predicates to hold, got %v", next)
+	}
+	if desc := Describe(s); desc != "At 00:00:00, on day 1 of the month, when its predicate holds" {
+		t.Errorf("unexpected description %q", desc)
+	}
+}
+
+func TestWithPredicate(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newYork, _ := time.LoadLocation("America/New_York")
+	for _, schedule := range []Schedule{
+		mustParse("0 0 0 * * *").(*SpecSchedule),
+		LocationSchedule{Schedule: mustParse("0 0 0 * * *").(*SpecSchedule), Location: newYork},
+		Every(24 * time.Hour),
+	} {
+		e := &Entry{Schedule: schedule}
+		WithPredicate(func(t time.Time) bool { return t.Weekday() == time.Saturday })(e)
+		next := e.Schedule.Next(from)
+		if next.IsZero() || next.Weekday() != time.Saturday || next.Sub(from) > 8*24*time.Hour {
+			t.Errorf("%T: expected the first Saturday, got %v", schedule, next)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Package publish provides a cron.Executor that publishes a message to a
+// message broker at each activation instead of running code in-process,
+// turning a Cron into a distributed job trigger.
+//
+// The package does not depend on any broker's client.  Adapt the client of
+// NATS, Kafka, SQS or any other broker with a PublisherFunc:
+//
+//	nc, _ := nats.Connect(nats.DefaultURL)
+//	p := publish.PublisherFunc(func(ctx context.Context, subject string, body []byte) error {
+//		return nc.Publish(subject, body)
+//	})
+//	c := cron.New(cron.WithExecutor(publish.NewExecutor(p, "cron.activations")))
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// Publisher publishes a message body to a subject, topic or queue.
+type Publisher interface {
+	Publish(ctx context.Context, subject string, body []byte) error
+}
+
+// PublisherFunc is an adapter to use a func as a Publisher.
+type PublisherFunc func(ctx context.Context, subject string, body []byte) error
+
+// Publish calls f.
+func (f PublisherFunc) Publish(ctx context.Context, subject string, body []byte) error {
+	return f(ctx, subject, body)
+}
+
+// Message is the JSON body published for each activation.
+type Message struct {
+	EntryID   cron.EntryID      `json:"entry_id"`
+	Name      string            `json:"name,omitempty"`
+	Scheduled time.Time         `json:"scheduled"`
+	RunID     string            `json:"run_id"`
+	RunKey    cron.RunKey       `json:"run_key"`
+	Host      string            `json:"host,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// Option configures the Executor returned by NewExecutor.
+type Option func(*executor)
+
+// WithSubjectFunc returns an Option that publishes each entry's messages to
+// the subject returned by fn, instead of the default subject.  An empty
+// subject selects the default.
+func WithSubjectFunc(fn func(entry *cron.Entry) string) Option {
+	return func(x *executor) {
+		x.subjectFunc = fn
+	}
+}
+
+type executor struct {
+	publisher   Publisher
+	subject     string
+	subjectFunc func(entry *cron.Entry) string
+}
+
+// NewExecutor returns a cron.Executor that publishes a Message to the given
+// subject with the Publisher for each run.  A run succeeds once its message
+// is published; it fails with the Publisher's error otherwise.  The entries'
+// jobs are never run, so they may be nil.
+func NewExecutor(p Publisher, subject string, opts ...Option) cron.Executor {
+	x := &executor{publisher: p, subject: subject}
+	for _, opt := range opts {
+		opt(x)
+	}
+	return x
+}
+
+func (x *executor) Execute(ctx context.Context, entry *cron.Entry, run cron.Run) error {
+	body, err := json.Marshal(Message{
+		EntryID:   run.EntryID,
+		Name:      entry.Name,
+		Scheduled: run.Scheduled,
+		RunID:     run.ID,
+		RunKey:    run.Key,
+		Host:      run.Host,
+		Metadata:  entry.Metadata,
+	})
+	if err != nil {
+		return err
+	}
+	subject := x.subject
+	if x.subjectFunc != nil {
+		if s := x.subjectFunc(entry); s != "" {
+			subject = s
+		}
+	}
+	return x.publisher.Publish(ctx, subject, body)
+}
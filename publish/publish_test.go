@@ -0,0 +1,72 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+type message struct {
+	subject string
+	body    []byte
+}
+
+func TestExecutor(t *testing.T) {
+	published := make(chan message, 1)
+	p := PublisherFunc(func(ctx context.Context, subject string, body []byte) error {
+		published <- message{subject, body}
+		return nil
+	})
+	c := cron.New(cron.WithExecutor(NewExecutor(p, "cron.activations")))
+	id := c.Schedule(cron.Every(time.Hour), nil,
+		cron.WithName("report"), cron.WithMetadata("team", "billing"))
+
+	c.Trigger(id)
+	m := <-published
+	<-c.Stop().Done()
+	if m.subject != "cron.activations" {
+		t.Errorf("expected subject cron.activations, got %q", m.subject)
+	}
+	var msg Message
+	if err := json.Unmarshal(m.body, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.EntryID != id || msg.Name != "report" || msg.RunID == "" || msg.RunKey == "" ||
+		msg.Scheduled.IsZero() || msg.Metadata["team"] != "billing" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+}
+
+func TestExecutorSubjectFunc(t *testing.T) {
+	x := NewExecutor(PublisherFunc(func(ctx context.Context, subject string, body []byte) error {
+		if subject != "jobs.report" {
+			return errors.New("unexpected subject " + subject)
+		}
+		return nil
+	}), "jobs.default", WithSubjectFunc(func(e *cron.Entry) string {
+		if e.Name == "" {
+			return ""
+		}
+		return "jobs." + e.Name
+	}))
+
+	if err := x.Execute(context.Background(), &cron.Entry{Name: "report"}, cron.Run{}); err != nil {
+		t.Error(err)
+	}
+	if err := x.Execute(context.Background(), &cron.Entry{}, cron.Run{}); err == nil {
+		t.Error("expected the default subject for an entry without a name")
+	}
+}
+
+func TestExecutorPublishFailure(t *testing.T) {
+	x := NewExecutor(PublisherFunc(func(ctx context.Context, subject string, body []byte) error {
+		return errors.New("broker unavailable")
+	}), "cron.activations")
+	if err := x.Execute(context.Background(), &cron.Entry{}, cron.Run{}); err == nil {
+		t.Error("expected the publisher's error")
+	}
+}
@@ -0,0 +1,30 @@
+package cron
+
+import "fmt"
+
+// ExportQuartz renders the schedule as a Quartz cron expression, with the
+// seconds field first, a "?" in whichever day field is not restricted, and
+// days of the week numbered from 1 for Sunday.  It returns an error if Quartz
+// cannot represent the schedule: Quartz cannot restrict both the day of month
+// and the day of week, where this package runs on either.
+func ExportQuartz(s *SpecSchedule) (string, error) {
+	days, err := formatQuartzDays(s, "Quartz")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s %s %s", formatField(s.Second, seconds, 0),
+		formatField(s.Minute, minutes, 0), formatField(s.Hour, hours, 0), days), nil
+}
+
+// formatQuartzDays renders the day of month, month and day of week fields of
+// the schedule in the syntax of Quartz, which the named system shares.
+func formatQuartzDays(s *SpecSchedule, system string) (string, error) {
+	byDom, byDow, _ := s.restrictsDays()
+	switch {
+	case !byDow:
+		return formatField(s.Dom, dom, 0) + " " + formatField(s.Month, months, 0) + " ?", nil
+	case !byDom:
+		return "? " + formatField(s.Month, months, 0) + " " + formatField(s.Dow, dow, 1), nil
+	}
+	return "", fmt.Errorf("cron: %s cannot restrict both the day of month and the day of week", system)
+}
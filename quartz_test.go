@@ -0,0 +1,28 @@
+package cron
+
+import "testing"
+
+func TestExportQuartz(t *testing.T) {
+	for spec, expected := range map[string]string{
+		"0 30 9 * * MON-FRI":  "0 30 9 ? * 2-6",
+		"*/20 * * * * ?":      "0,20,40 * * * * ?",
+		"15 0 12 1,15 * ?":    "15 0 12 1,15 * ?",
+		"0 0 0 * JAN,JUL SUN": "0 0 0 ? 1,7 1",
+		"@weekly":             "0 0 0 ? * 1",
+		"0 0 9 1-31 * MON":    "0 0 9 * * ?",
+	} {
+		schedule, err := Parse(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expr, err := ExportQuartz(schedule.(*SpecSchedule))
+		if err != nil || expr != expected {
+			t.Errorf("%s: expected %s, got %s, %v", spec, expected, expr, err)
+		}
+	}
+
+	schedule, _ := Parse("0 0 12 1 * MON")
+	if _, err := ExportQuartz(schedule.(*SpecSchedule)); err == nil {
+		t.Error("expected an error for a schedule restricting both day fields")
+	}
+}
@@ -0,0 +1,65 @@
+package cron
+
+import "time"
+
+// entryQueue orders a Cron's entries by their next activation time.
+// Entries with a zero Next time are kept, but never due.
+type entryQueue interface {
+	// push adds the entry to the queue.
+	push(e *Entry)
+
+	// remove removes the entry from the queue.
+	remove(e *Entry)
+
+	// fix restores the entry's position after its Next time changed.
+	fix(e *Entry)
+
+	// wakeup returns the time at which the scheduler should next call due,
+	// or the zero time if no entry is scheduled.
+	wakeup() time.Time
+
+	// due removes and returns the entries whose Next time is not after now,
+	// in order of activation.
+	due(now time.Time) []*Entry
+
+	// list returns the entries in the queue, in no particular order.
+	list() []*Entry
+}
+
+// insert adds the entry to the Cron.
+func (c *Cron) insert(e *Entry) {
+	c.queue.push(e)
+	c.byID[e.ID] = e
+	c.emit(EntryAdded, e.Namespace, e.ID, nil)
+}
+
+// removeEntry removes the entry from the Cron.
+func (c *Cron) removeEntry(e *Entry) {
+	if e.index >= 0 {
+		c.queue.remove(e)
+	}
+	delete(c.byID, e.ID)
+	c.health.forget(e.ID)
+	c.budgets.forget(e.ID)
+	unobserve(e)
+	if f, ok := c.store.(Forgetter); ok {
+		if err := f.Forget(e.ID); err != nil {
+			c.logf("cron: failed to forget the history of entry %d: %v", e.ID, err)
+		}
+	}
+	c.emit(EntryRemoved, e.Namespace, e.ID, nil)
+}
+
+// fix restores the entry's position in the queue after its Next time changed,
+// removing it instead if it expired and asked to be removed.  Entries that are
+// not in the queue, such as those being dispatched, are left alone.
+func (c *Cron) fix(e *Entry) {
+	if e.index < 0 {
+		return
+	}
+	if e.expired && e.RemoveWhenExpired {
+		c.removeEntry(e)
+		return
+	}
+	c.queue.fix(e)
+}
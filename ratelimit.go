@@ -0,0 +1,123 @@
+package cron
+
+import "time"
+
+// Overflow selects what happens to a run when a rate limit is exceeded.
+type Overflow int
+
+const (
+	// Queue delays the run until the rate limit allows it to start.
+	Queue Overflow = iota
+
+	// Skip drops the run, recording it as skipped.
+	Skip
+)
+
+// RateLimit limits how many scheduled runs may start per second.
+type RateLimit struct {
+	// Rate is the sustained number of runs allowed to start per second.
+	// With a Rate of zero or less, only Burst runs are ever allowed to
+	// start, and the runs beyond them are skipped, whatever the Overflow,
+	// as they could never start.
+	Rate float64
+
+	// Burst is the number of runs allowed to start at once.  It is at
+	// least 1.
+	Burst int
+
+	// Overflow selects what happens to runs exceeding the limit.
+	Overflow Overflow
+}
+
+// WithRateLimit returns an Option that limits the starts of the scheduled runs
+// of all entries, however their specs align.  Runs started with Trigger are
+// not limited.
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *Cron) {
+		c.limiter = newTokenBucket(limit)
+	}
+}
+
+// WithTagRateLimit returns an Option that limits the starts of the scheduled
+// runs of the entries in the group named by tag.  An entry in several limited
+// groups must be allowed by all of their limits, and by the limit set with
+// WithRateLimit, to start.
+func WithTagRateLimit(tag string, limit RateLimit) Option {
+	return func(c *Cron) {
+		if c.limiters == nil {
+			c.limiters = make(map[string]*tokenBucket)
+		}
+		c.limiters[tag] = newTokenBucket(limit)
+	}
+}
+
+// limit applies the rate limits to a run of the entry starting at now.  It
+// returns how long the run must be delayed, or false if it must be skipped.
+func (c *Cron) limit(e *Entry, now time.Time) (time.Duration, bool) {
+	var buckets []*tokenBucket
+	if c.limiter != nil {
+		buckets = append(buckets, c.limiter)
+	}
+	for _, tag := range e.Tags {
+		if b, ok := c.limiters[tag]; ok {
+			buckets = append(buckets, b)
+		}
+	}
+
+	for _, b := range buckets {
+		if (b.limit.Overflow == Skip || b.limit.Rate <= 0) && !b.available(now) {
+			return 0, false
+		}
+	}
+	var wait time.Duration
+	for _, b := range buckets {
+		if d := b.reserve(now); d > wait {
+			wait = d
+		}
+	}
+	return wait, true
+}
+
+// tokenBucket implements a RateLimit.  It is only used from the scheduler
+// goroutine.
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &tokenBucket{limit: limit, tokens: float64(limit.Burst)}
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+		if burst := float64(b.limit.Burst); b.tokens > burst {
+			b.tokens = burst
+		}
+	}
+	if now.After(b.last) {
+		b.last = now
+	}
+}
+
+// available returns true if a token can be taken without waiting.
+func (b *tokenBucket) available(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= 1
+}
+
+// reserve takes a token, returning how long to wait before it may be used.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.limit.Rate * float64(time.Second))
+}
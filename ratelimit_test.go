@@ -0,0 +1,101 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(RateLimit{Rate: 2, Burst: 2})
+
+	if d := b.reserve(now); d != 0 {
+		t.Errorf("expected no wait, got %v", d)
+	}
+	if d := b.reserve(now); d != 0 {
+		t.Errorf("expected no wait, got %v", d)
+	}
+	if b.available(now) {
+		t.Error("expected the bucket to be empty")
+	}
+	if d := b.reserve(now); d != 500*time.Millisecond {
+		t.Errorf("expected to wait 500ms, got %v", d)
+	}
+	if d := b.reserve(now); d != time.Second {
+		t.Errorf("expected to wait 1s, got %v", d)
+	}
+
+	// Tokens accumulate up to the burst.
+	later := now.Add(time.Hour)
+	b.reserve(later)
+	b.reserve(later)
+	if b.available(later) {
+		t.Error("expected the burst to be capped")
+	}
+}
+
+func TestLimitSkip(t *testing.T) {
+	now := time.Now()
+	cron := New(
+		WithRateLimit(RateLimit{Rate: 1, Burst: 2, Overflow: Queue}),
+		WithTagRateLimit("billing", RateLimit{Rate: 1, Overflow: Skip}),
+	)
+	billing := &Entry{Tags: []string{"billing"}}
+	other := &Entry{}
+
+	if _, ok := cron.limit(billing, now); !ok {
+		t.Error("expected the first run to start")
+	}
+	if _, ok := cron.limit(billing, now); ok {
+		t.Error("expected the second billing run to be skipped")
+	}
+	if wait, ok := cron.limit(other, now); !ok || wait != 0 {
+		t.Errorf("expected the other run to start immediately, got %v %v", wait, ok)
+	}
+	if wait, ok := cron.limit(other, now); !ok || wait != time.Second {
+		t.Errorf("expected the run to be queued for 1s, got %v %v", wait, ok)
+	}
+}
+
+// A zero rate allows only the burst, then skips runs even when queueing.
+func TestRateLimitZeroRate(t *testing.T) {
+	cron := New(WithRateLimit(RateLimit{Rate: 0, Burst: 2, Overflow: Queue}))
+	e := &Entry{}
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		if wait, ok := cron.limit(e, now); !ok || wait != 0 {
+			t.Errorf("run %d: expected the burst to allow it, got %v %v", i, wait, ok)
+		}
+	}
+	if _, ok := cron.limit(e, now.Add(time.Hour)); ok {
+		t.Error("expected the run beyond the burst to be skipped")
+	}
+}
+
+// Runs over the limit are skipped and recorded as such.
+func TestRateLimitSkipsRuns(t *testing.T) {
+	store := NewMemoryStore()
+	cron := New(WithStore(store), WithRateLimit(RateLimit{Rate: 0.001, Overflow: Skip}))
+	a, _ := cron.AddFunc("* * * * * ?", func() {})
+	b, _ := cron.AddFunc("* * * * * ?", func() {})
+	cron.Start()
+	time.Sleep(ONE_SECOND)
+	cron.Stop()
+	time.Sleep(10 * time.Millisecond)
+
+	ha, _ := cron.History(a, time.Time{}, 0)
+	hb, _ := cron.History(b, time.Time{}, 0)
+	runs := append(ha, hb...)
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs, got %v", runs)
+	}
+	skipped := 0
+	for _, run := range runs {
+		if run.Outcome == OutcomeSkipped {
+			skipped++
+		}
+	}
+	if skipped != 1 {
+		t.Errorf("expected 1 skipped run, got %d", skipped)
+	}
+}
@@ -0,0 +1,323 @@
+// Package render draws calendars of the upcoming activations of schedules,
+// as text for terminals and logs or as HTML tables for ops tooling and
+// emails.
+//
+//	report, _ := cron.Parse("0 30 9 * * MON-FRI")
+//	backup, _ := cron.Parse("0 0 2 * * SUN")
+//	render.MonthText(os.Stdout, 2024, time.January, time.UTC,
+//		render.Series{Name: "report", Schedule: report},
+//		render.Series{Name: "backup", Schedule: backup})
+//
+// prints
+//
+//	January 2024
+//	Mon   Tue   Wed   Thu   Fri   Sat   Sun
+//	  1A    2A    3A    4A    5A    6     7 B
+//	...
+//	A: report
+//	B: backup
+//
+// Weeks start on Monday.  Each series is marked by a letter, from A, in the
+// order given.
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// Series is a schedule to mark on a calendar, with the name to label it by.
+type Series struct {
+	Name     string
+	Schedule cron.Schedule
+}
+
+// maxPerCell bounds the activations of a series listed in a cell of an HTML
+// calendar.  Beyond it, the cell notes that there are more.
+const maxPerCell = 5
+
+// MonthText writes a grid of the days of the month in loc, marking the days
+// on which each series activates, followed by a legend of the marks.
+func MonthText(w io.Writer, year int, month time.Month, loc *time.Location, series ...Series) error {
+	m := newMonth(year, month, loc, series)
+	width := 3 + marksWidth(series)
+	var b strings.Builder
+	b.WriteString(m.caption + "\n")
+	b.WriteString(textRow(weekdayNames[:], width) + "\n")
+	for _, week := range m.weeks {
+		cells := make([]string, len(week))
+		for i, d := range week {
+			if !d.date.IsZero() {
+				cells[i] = fmt.Sprintf("%3d", d.date.Day()) + marks(d.times)
+			}
+		}
+		b.WriteString(textRow(cells, width) + "\n")
+	}
+	b.WriteString(legend(series))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WeekText writes a grid of the hours of the seven days from the day of
+// start, in its location, marking the hours in which each series activates,
+// followed by a legend of the marks.
+func WeekText(w io.Writer, start time.Time, series ...Series) error {
+	wk := newWeek(start, series)
+	width := marksWidth(series)
+	for _, d := range wk.days {
+		if len(d) > width {
+			width = len(d)
+		}
+	}
+	var b strings.Builder
+	b.WriteString(wk.caption + "\n")
+	b.WriteString("      " + textRow(wk.days, width) + "\n")
+	for h, hour := range wk.hours {
+		cells := make([]string, len(hour))
+		for i, c := range hour {
+			cells[i] = marks(c)
+		}
+		b.WriteString(strings.TrimRight(fmt.Sprintf("%02d:00 %s", h, textRow(cells, width)), " ") + "\n")
+	}
+	b.WriteString(legend(series))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// MonthHTML writes a table of the days of the month in loc, listing the
+// activations of each series on each day.
+func MonthHTML(w io.Writer, year int, month time.Month, loc *time.Location, series ...Series) error {
+	m := newMonth(year, month, loc, series)
+	t := htmlTable{Caption: m.caption, Header: weekdayNames[:]}
+	for _, week := range m.weeks {
+		var row htmlRow
+		for _, d := range week {
+			c := htmlCellOf(d.times, series, "15:04")
+			if !d.date.IsZero() {
+				c.Day = d.date.Day()
+			}
+			row.Cells = append(row.Cells, c)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return htmlTemplate.Execute(w, t)
+}
+
+// WeekHTML writes a table of the hours of the seven days from the day of
+// start, in its location, listing the activations of each series in each
+// hour.
+func WeekHTML(w io.Writer, start time.Time, series ...Series) error {
+	wk := newWeek(start, series)
+	t := htmlTable{Caption: wk.caption, Header: wk.days, Labeled: true}
+	for h, hour := range wk.hours {
+		row := htmlRow{Label: fmt.Sprintf("%02d:00", h)}
+		for _, c := range hour {
+			row.Cells = append(row.Cells, htmlCellOf(c, series, "15:04:05"))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return htmlTemplate.Execute(w, t)
+}
+
+var weekdayNames = [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// cell is the activations of each series in a cell of a calendar, up to
+// maxPerCell+1 of them.
+type cell [][]time.Time
+
+// month is a calendar of a month, in weeks of days.  The days of other months
+// in its first and last weeks have the zero date.
+type month struct {
+	caption string
+	weeks   [][7]day
+}
+
+type day struct {
+	date  time.Time
+	times cell
+}
+
+func newMonth(year int, m time.Month, loc *time.Location, series []Series) month {
+	first := time.Date(year, m, 1, 0, 0, 0, 0, loc)
+	end := first.AddDate(0, 1, 0)
+	_, cells := activations(series, first, end, func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+	})
+	cal := month{caption: first.Format("January 2006")}
+	// Monday is the first column.
+	col := (int(first.Weekday()) + 6) % 7
+	for d := first; d.Before(end); d = d.AddDate(0, 0, 1) {
+		if col == 0 || len(cal.weeks) == 0 {
+			cal.weeks = append(cal.weeks, [7]day{})
+		}
+		cal.weeks[len(cal.weeks)-1][col] = day{d, cells[d.Day()-1]}
+		col = (col + 1) % 7
+	}
+	return cal
+}
+
+// week is a calendar of seven days, in hours of days.
+type week struct {
+	caption string
+	days    []string
+	hours   [24][7]cell
+}
+
+func newWeek(start time.Time, series []Series) week {
+	loc := start.Location()
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	wk := week{caption: "Week of " + first.Format("Monday, 2 January 2006")}
+	for i := 0; i < 7; i++ {
+		d := first.AddDate(0, 0, i)
+		wk.days = append(wk.days, d.Format("Mon 2"))
+		starts, hours := activations(series, d, d.AddDate(0, 0, 1), func(t time.Time) time.Time {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// The next hour is skipped, and Date went back.
+				next = t.Add(time.Hour)
+			}
+			return next
+		})
+		// An hour the clocks skip has no cell, and one they repeat is in
+		// the cell of its first time.
+		for j, start := range starts {
+			wk.hours[start.Hour()][i] = hours[j]
+		}
+	}
+	return wk
+}
+
+// activations returns the starts of the cells into which next divides the
+// time from start until end, by returning the start of the cell after that of
+// each time, and the activations of each series in each cell.  Once a series
+// has activated more than maxPerCell times in a cell, it is looked for from
+// the next cell, so that frequent schedules are not stepped through.
+func activations(series []Series, start, end time.Time, next func(time.Time) time.Time) ([]time.Time, []cell) {
+	var starts, bounds []time.Time
+	for t := start; t.Before(end); t = next(t) {
+		starts = append(starts, t)
+		bounds = append(bounds, next(t))
+	}
+	cells := make([]cell, len(bounds))
+	for i := range cells {
+		cells[i] = make(cell, len(series))
+	}
+	for s, ser := range series {
+		i := 0
+		for t := ser.Schedule.Next(start.Add(-time.Nanosecond)); !t.IsZero() && t.Before(end); {
+			for !t.Before(bounds[i]) {
+				i++
+			}
+			cells[i][s] = append(cells[i][s], t)
+			if len(cells[i][s]) > maxPerCell {
+				t = ser.Schedule.Next(bounds[i].Add(-time.Nanosecond))
+			} else {
+				t = ser.Schedule.Next(t)
+			}
+		}
+	}
+	return starts, cells
+}
+
+// marks returns the letters of the series that activate in the cell, with
+// spaces for those that do not.
+func marks(c cell) string {
+	b := make([]byte, len(c))
+	for i, times := range c {
+		b[i] = ' '
+		if len(times) > 0 {
+			b[i] = mark(i)
+		}
+	}
+	return string(b)
+}
+
+// mark returns the letter marking the series at index i.
+func mark(i int) byte {
+	if i < 26 {
+		return byte('A' + i)
+	}
+	return '*'
+}
+
+// marksWidth returns the width of the marks of the series, at least one.
+func marksWidth(series []Series) int {
+	if len(series) == 0 {
+		return 1
+	}
+	return len(series)
+}
+
+// textRow pads the cells to the width and joins them, trimming the trailing
+// space.
+func textRow(cells []string, width int) string {
+	padded := make([]string, len(cells))
+	for i, c := range cells {
+		padded[i] = c + strings.Repeat(" ", width-len(c))
+	}
+	return strings.TrimRight(strings.Join(padded, " "), " ")
+}
+
+func legend(series []Series) string {
+	var b strings.Builder
+	for i, s := range series {
+		fmt.Fprintf(&b, "%c: %s\n", mark(i), s.Name)
+	}
+	return b.String()
+}
+
+type htmlTable struct {
+	Caption string
+	Header  []string
+	Labeled bool // whether rows have labels
+	Rows    []htmlRow
+}
+
+type htmlRow struct {
+	Label string
+	Cells []htmlCell
+}
+
+type htmlCell struct {
+	Day   int // of the month, or 0
+	Items []htmlItem
+	More  bool
+}
+
+type htmlItem struct {
+	Series int
+	Time   string
+	Name   string
+	at     time.Time
+}
+
+// htmlCellOf lists the activations in the cell in order, formatting their
+// times with layout.
+func htmlCellOf(c cell, series []Series, layout string) htmlCell {
+	var h htmlCell
+	for s, times := range c {
+		if len(times) > maxPerCell {
+			times, h.More = times[:maxPerCell], true
+		}
+		for _, t := range times {
+			h.Items = append(h.Items, htmlItem{s, t.Format(layout), series[s].Name, t})
+		}
+	}
+	sort.SliceStable(h.Items, func(i, j int) bool { return h.Items[i].at.Before(h.Items[j].at) })
+	return h
+}
+
+var htmlTemplate = template.Must(template.New("calendar").Parse(`<table class="cron-calendar">
+<caption>{{.Caption}}</caption>
+<thead><tr>{{if .Labeled}}<th></th>{{end}}{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Rows}}<tr>{{if $.Labeled}}<th>{{.Label}}</th>{{end}}{{range .Cells}}<td>{{if .Day}}<div class="day">{{.Day}}</div>{{end}}{{if .Items}}<ul>{{range .Items}}<li class="series-{{.Series}}">{{.Time}} {{.Name}}</li>{{end}}{{if .More}}<li class="more">and more</li>{{end}}</ul>{{end}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+`))
@@ -0,0 +1,177 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+func series(t *testing.T, specs ...string) []Series {
+	var s []Series
+	for i := 0; i < len(specs); i += 2 {
+		sched, err := cron.Parse(specs[i+1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		s = append(s, Series{Name: specs[i], Schedule: sched})
+	}
+	return s
+}
+
+func TestMonthText(t *testing.T) {
+	var b strings.Builder
+	s := series(t, "report", "0 30 9 * * MON-FRI", "backup", "0 0 2 * * SUN")
+	if err := MonthText(&b, 2024, time.January, time.UTC, s...); err != nil {
+		t.Fatal(err)
+	}
+	expected := `January 2024
+Mon   Tue   Wed   Thu   Fri   Sat   Sun
+  1A    2A    3A    4A    5A    6     7 B
+  8A    9A   10A   11A   12A   13    14 B
+ 15A   16A   17A   18A   19A   20    21 B
+ 22A   23A   24A   25A   26A   27    28 B
+ 29A   30A   31A
+A: report
+B: backup
+`
+	if b.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, b.String())
+	}
+}
+
+func TestMonthTextLeadingBlanks(t *testing.T) {
+	var b strings.Builder
+	if err := MonthText(&b, 2024, time.September, time.UTC, series(t, "daily", "@daily")...); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(b.String(), "\n")
+	// September 2024 starts on a Sunday.
+	if expected := strings.Repeat(" ", 6*5) + "  1A"; lines[2] != expected {
+		t.Errorf("expected %q, got %q", expected, lines[2])
+	}
+	if expected := " 30A"; lines[len(lines)-3] != expected {
+		t.Errorf("expected %q, got %q", expected, lines[len(lines)-3])
+	}
+}
+
+func TestWeekText(t *testing.T) {
+	var b strings.Builder
+	s := series(t, "report", "0 30 9 * * MON-FRI", "backup", "0 0 2 * * SUN")
+	if err := WeekText(&b, time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC), s...); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(b.String(), "\n")
+	checks := map[int]string{
+		0:  "Week of Monday, 1 January 2024",
+		1:  "      Mon 1 Tue 2 Wed 3 Thu 4 Fri 5 Sat 6 Sun 7",
+		2:  "00:00",
+		4:  "02:00                                      B",
+		11: "09:00 A     A     A     A     A",
+		26: "A: report",
+	}
+	for i, expected := range checks {
+		if lines[i] != expected {
+			t.Errorf("line %d: expected %q, got %q", i, expected, lines[i])
+		}
+	}
+}
+
+func TestWeekTextDaylightSaving(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := series(t, "hourly", "0 30 * * * *")
+	for _, start := range []time.Time{
+		time.Date(2024, time.March, 10, 0, 0, 0, 0, ny),   // 02:00 is skipped
+		time.Date(2024, time.November, 3, 0, 0, 0, 0, ny), // 01:00 is repeated
+	} {
+		var b strings.Builder
+		if err := WeekText(&b, start, s...); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(b.String(), "\n")
+		// The first column is of the day of the change.
+		skipped := start.Month() == time.March
+		if got := lines[4][6] == ' '; got != skipped {
+			t.Errorf("%v: expected 02:00 to be unmarked %v, got %q", start, skipped, lines[4])
+		}
+		if lines[3][6] != 'A' {
+			t.Errorf("%v: expected 01:00 to be marked, got %q", start, lines[3])
+		}
+	}
+}
+
+func TestMonthHTML(t *testing.T) {
+	var b strings.Builder
+	s := series(t, "<report>", "0 30 9 * * MON-FRI", "tick", "* * * * * *")
+	if err := MonthHTML(&b, 2024, time.February, time.UTC, s...); err != nil {
+		t.Fatal(err)
+	}
+	html := b.String()
+	for _, expected := range []string{
+		"<caption>February 2024</caption>",
+		"<th>Mon</th>",
+		`<td></td><td></td><td></td><td><div class="day">1</div>`,
+		`<li class="series-0">09:30 &lt;report&gt;</li>`,
+		`<li class="series-1">00:00 tick</li>`,
+		`<li class="more">and more</li>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected %q in\n%s", expected, html)
+		}
+	}
+	if n := strings.Count(html, "09:30 &lt;report&gt;"); n != 21 {
+		t.Errorf("expected 21 weekdays, got %d", n)
+	}
+	if strings.Contains(html, "<report>") {
+		t.Error("expected names to be escaped")
+	}
+}
+
+func TestWeekHTML(t *testing.T) {
+	var b strings.Builder
+	s := series(t, "report", "0 30 9 * * MON-FRI")
+	if err := WeekHTML(&b, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), s...); err != nil {
+		t.Fatal(err)
+	}
+	html := b.String()
+	for _, expected := range []string{
+		"<caption>Week of Monday, 1 January 2024</caption>",
+		"<thead><tr><th></th><th>Mon 1</th>",
+		`<tr><th>09:00</th><td><ul><li class="series-0">09:30:00 report</li></ul></td>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected %q in\n%s", expected, html)
+		}
+	}
+}
+
+func TestActivationsSkipsFrequentSchedules(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	s := Series{Schedule: scheduleFunc(func(t time.Time) time.Time {
+		calls++
+		return t.Truncate(time.Second).Add(time.Second)
+	})}
+	_, cells := activations([]Series{s}, start, start.AddDate(0, 1, 0), func(t time.Time) time.Time {
+		return t.AddDate(0, 0, 1)
+	})
+	if len(cells) != 31 {
+		t.Fatalf("expected 31 cells, got %d", len(cells))
+	}
+	for i, c := range cells {
+		if len(c[0]) != maxPerCell+1 {
+			t.Errorf("cell %d: expected %d activations, got %d", i, maxPerCell+1, len(c[0]))
+		}
+	}
+	if calls > 31*(maxPerCell+1)+1 {
+		t.Errorf("expected the schedule to be skipped through, got %d calls", calls)
+	}
+}
+
+type scheduleFunc func(time.Time) time.Time
+
+func (f scheduleFunc) Next(t time.Time) time.Time { return f(t) }
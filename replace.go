@@ -0,0 +1,131 @@
+package cron
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// EntryConfig is the desired state of an entry, see ReplaceAll.
+type EntryConfig struct {
+	// Name identifies the entry across calls of ReplaceAll.  It must be
+	// set, and unique among the configs.
+	Name string
+
+	// Spec is the entry's schedule, parsed as AddJob parses it.
+	Spec string
+
+	// Job is the entry's job.
+	Job Job
+
+	// Options configure the entry, as for AddJob.
+	Options []EntryOption
+}
+
+// ReplaceResult reports the changes ReplaceAll made.
+type ReplaceResult struct {
+	Added, Updated, Removed []EntryID
+}
+
+// ReplaceAll makes the named entries of the Cron, outside any namespace,
+// those of the configs, as a reconciliation loop would: it adds an entry for
+// each config whose name has none, updates the entry of each config whose
+// spec or options changed, and removes the named entries no config names,
+// and any named the same as an earlier entry.
+// The changes are applied at once, between activations, so that the
+// scheduler never activates a half-updated set of entries.  Entries without
+// a name, and those of namespaces, are left alone.
+//
+// An updated entry keeps its ID, last activation, run count and paused
+// state, and takes the config's job.  Its next activation is recomputed
+// from the current time if its spec, locations or validity changed, and
+// kept otherwise.
+//
+// If a spec does not parse, or a name is missing or repeated, ReplaceAll
+// returns an error and changes nothing.
+func (c *Cron) ReplaceAll(configs []EntryConfig) (ReplaceResult, error) {
+	desired := make(map[string]*Entry, len(configs))
+	for _, config := range configs {
+		if config.Name == "" {
+			return ReplaceResult{}, fmt.Errorf("cron: entry for %q has no name", config.Spec)
+		}
+		if _, ok := desired[config.Name]; ok {
+			return ReplaceResult{}, fmt.Errorf("cron: entry %q is configured twice", config.Name)
+		}
+		schedule, err := c.parse(config.Spec)
+		if err != nil {
+			return ReplaceResult{}, fmt.Errorf("cron: entry %q: %v", config.Name, err)
+		}
+		e := &Entry{Schedule: schedule, Job: config.Job, Spec: config.Spec}
+		for _, opt := range config.Options {
+			opt(e)
+		}
+		e.Name = config.Name
+		desired[config.Name] = e
+	}
+
+	var result ReplaceResult
+	c.do(func() {
+		ids := make([]EntryID, 0, len(c.byID))
+		for id := range c.byID {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		current := make(map[string]*Entry)
+		for _, id := range ids {
+			e := c.byID[id]
+			if e.Name == "" || e.Namespace != "" {
+				continue
+			}
+			if _, ok := desired[e.Name]; !ok || current[e.Name] != nil {
+				c.removeEntry(e)
+				result.Removed = append(result.Removed, e.ID)
+				continue
+			}
+			current[e.Name] = e
+		}
+		for _, config := range configs {
+			want := desired[config.Name]
+			e, ok := current[config.Name]
+			if !ok {
+				c.add(want)
+				result.Added = append(result.Added, want.ID)
+				continue
+			}
+			e.Job = want.Job
+			if c.update(e, want) {
+				result.Updated = append(result.Updated, e.ID)
+			}
+		}
+	})
+	return result, nil
+}
+
+// update applies the spec and options of want to the entry, returning false
+// if they are unchanged.
+func (c *Cron) update(e, want *Entry) bool {
+	reschedule := e.Spec != want.Spec || !e.ValidFrom.Equal(want.ValidFrom) || !e.ValidUntil.Equal(want.ValidUntil) ||
+		!reflect.DeepEqual(locationNames(e.Locations), locationNames(want.Locations))
+	changed := reschedule || e.RemoveWhenExpired != want.RemoveWhenExpired || e.MaxRuns != want.MaxRuns ||
+		!e.StartAt.Equal(want.StartAt) || e.InitialDelay != want.InitialDelay || e.Jitter != want.Jitter ||
+		e.Flexibility != want.Flexibility || e.IgnoreBlackouts != want.IgnoreBlackouts ||
+		e.MisfireThreshold != want.MisfireThreshold || e.MisfirePolicy != want.MisfirePolicy || e.SLA != want.SLA || e.Budget != want.Budget ||
+		!reflect.DeepEqual(e.Tags, want.Tags) || !reflect.DeepEqual(e.Metadata, want.Metadata) ||
+		!reflect.DeepEqual(e.LogFields, want.LogFields)
+	if !changed {
+		return false
+	}
+	e.Schedule, e.Spec, e.Locations = want.Schedule, want.Spec, want.Locations
+	e.Tags, e.Metadata, e.LogFields = want.Tags, want.Metadata, want.LogFields
+	e.ValidFrom, e.ValidUntil, e.RemoveWhenExpired = want.ValidFrom, want.ValidUntil, want.RemoveWhenExpired
+	e.MaxRuns, e.StartAt, e.InitialDelay, e.Jitter = want.MaxRuns, want.StartAt, want.InitialDelay, want.Jitter
+	e.MisfireThreshold, e.MisfirePolicy, e.SLA, e.Budget = want.MisfireThreshold, want.MisfirePolicy, want.SLA, want.Budget
+	e.Flexibility, e.IgnoreBlackouts = want.Flexibility, want.IgnoreBlackouts
+	if reschedule || e.expired != (e.MaxRuns > 0 && e.Runs >= e.MaxRuns) {
+		e.expired = false
+		if c.running {
+			c.advance(e, c.now())
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReplaceAll(t *testing.T) {
+	cron := New()
+	noop := FuncJob(func() {})
+	keep, _ := cron.AddFunc("@every 10m", func() {}, WithName("keep"))
+	change, _ := cron.AddFunc("@hourly", func() {}, WithName("change"))
+	retag, _ := cron.AddFunc("@daily", func() {}, WithName("retag"))
+	drop, _ := cron.AddFunc("@daily", func() {}, WithName("drop"))
+	unnamed, _ := cron.AddFunc("@daily", func() {})
+	tenant, _ := cron.Namespace("tenant").AddFunc("@daily", func() {}, WithName("drop"))
+	cron.Start()
+	defer cron.Stop()
+	next := cron.Entry(keep).Next
+
+	ran := make(chan struct{}, 1)
+	result, err := cron.ReplaceAll([]EntryConfig{
+		{Name: "keep", Spec: "@every 10m", Job: FuncJob(func() { ran <- struct{}{} })},
+		{Name: "change", Spec: "@every 1h", Job: noop},
+		{Name: "retag", Spec: "@daily", Job: noop, Options: []EntryOption{WithTags("nightly")}},
+		{Name: "add", Spec: "@weekly", Job: noop},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ReplaceResult{Added: []EntryID{tenant + 1}, Updated: []EntryID{change, retag}, Removed: []EntryID{drop}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+
+	if e := cron.Entry(keep); !e.Next.Equal(next) {
+		t.Errorf("expected the unchanged entry to keep its next activation %v, got %v", next, e.Next)
+	}
+	cron.Trigger(keep)
+	select {
+	case <-ran:
+	case <-time.After(ONE_SECOND):
+		t.Error("expected the unchanged entry to take the new job")
+	}
+	if e := cron.Entry(change); e.Spec != "@every 1h" || e.ID != change {
+		t.Errorf("expected the entry to be updated in place, got %+v", e)
+	}
+	if e := cron.Entry(retag); len(e.Tags) != 1 || e.Tags[0] != "nightly" {
+		t.Errorf("expected the entry's tags to be updated, got %v", e.Tags)
+	}
+	if cron.Entry(drop) != nil {
+		t.Error("expected the unconfigured entry to be removed")
+	}
+	if cron.Entry(unnamed) == nil || cron.Entry(tenant) == nil {
+		t.Error("expected unnamed and namespaced entries to be left alone")
+	}
+	if e := cron.Entry(tenant + 1); e == nil || e.Name != "add" || e.Next.IsZero() {
+		t.Errorf("expected the new entry to be scheduled, got %+v", e)
+	}
+
+	// Applying the same configs again changes nothing.
+	result, err = cron.ReplaceAll([]EntryConfig{
+		{Name: "keep", Spec: "@every 10m", Job: noop},
+		{Name: "change", Spec: "@every 1h", Job: noop},
+		{Name: "retag", Spec: "@daily", Job: noop, Options: []EntryOption{WithTags("nightly")}},
+		{Name: "add", Spec: "@weekly", Job: noop},
+	})
+	if err != nil || len(result.Added)+len(result.Updated)+len(result.Removed) != 0 {
+		t.Errorf("expected no changes, got %+v, %v", result, err)
+	}
+}
+
+func TestReplaceAllErrors(t *testing.T) {
+	cron := New()
+	id, _ := cron.AddFunc("@daily", func() {}, WithName("existing"))
+	noop := FuncJob(func() {})
+	for _, configs := range [][]EntryConfig{
+		{{Name: "a", Spec: "@daily", Job: noop}, {Spec: "@daily", Job: noop}},
+		{{Name: "a", Spec: "@daily", Job: noop}, {Name: "a", Spec: "@hourly", Job: noop}},
+		{{Name: "a", Spec: "@daily", Job: noop}, {Name: "b", Spec: "bogus", Job: noop}},
+	} {
+		if _, err := cron.ReplaceAll(configs); err == nil {
+			t.Errorf("expected an error for %+v", configs)
+		}
+	}
+	if entries := cron.Entries(); len(entries) != 1 || entries[0].ID != id {
+		t.Errorf("expected a failed replacement to change nothing, got %v", entries)
+	}
+}
+
+func TestReplaceAllDuplicateNames(t *testing.T) {
+	cron := New()
+	first, _ := cron.AddFunc("@daily", func() {}, WithName("dup"))
+	second, _ := cron.AddFunc("@daily", func() {}, WithName("dup"))
+	result, err := cron.ReplaceAll([]EntryConfig{{Name: "dup", Spec: "@daily", Job: FuncJob(func() {})}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Removed, []EntryID{second}) || cron.Entry(first) == nil {
+		t.Errorf("expected the later duplicate to be removed, got %+v", result)
+	}
+}
@@ -0,0 +1,57 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// RescheduleAt asks the Cron to activate the entry of the run the context was
+// created for next at t, instead of at its schedule's next activation.  The
+// schedule applies again after that activation, so a job may keep calling
+// RescheduleAt to poll faster while work remains.  The hint takes effect when
+// the job returns.  RescheduleAt returns false if the context is not that of
+// a run.
+func RescheduleAt(ctx context.Context, t time.Time) bool {
+	state, ok := ctx.Value(runStateContextKey).(*runState)
+	if !ok {
+		return false
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.next = t
+	return true
+}
+
+// RescheduleIn is like RescheduleAt, with the next activation d after the
+// current time on the Cron's clock.
+func RescheduleIn(ctx context.Context, d time.Duration) bool {
+	state, ok := ctx.Value(runStateContextKey).(*runState)
+	if !ok {
+		return false
+	}
+	return RescheduleAt(ctx, state.now().Add(d))
+}
+
+func (s *runState) nextHint() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.next
+}
+
+// reschedule sets the next activation of the given entry to t, if it is
+// still scheduled.
+func (c *Cron) reschedule(id EntryID, t time.Time) {
+	c.do(func() {
+		e := c.find(id)
+		if e == nil || e.Paused || e.expired || !c.running {
+			return
+		}
+		if !e.ValidUntil.IsZero() && t.After(e.ValidUntil) {
+			e.expired = true
+			e.Next = time.Time{}
+		} else {
+			e.Next = t.In(c.Location())
+		}
+		c.fix(e)
+	})
+}
@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRescheduleOutsideRun(t *testing.T) {
+	if RescheduleIn(context.Background(), time.Second) {
+		t.Error("expected RescheduleIn to fail outside of a run")
+	}
+}
+
+// A job's hint overrides the schedule for one activation.
+func TestRescheduleIn(t *testing.T) {
+	runs := make(chan time.Time, 10)
+	first := true
+	cron := New()
+	id := cron.Schedule(Every(time.Hour), ContextFuncJob(func(ctx context.Context) error {
+		if first {
+			first = false
+			RescheduleIn(ctx, 100*time.Millisecond)
+		}
+		runs <- time.Now()
+		return nil
+	}))
+	cron.Start()
+	defer cron.Stop()
+	cron.Trigger(id)
+
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("expected the triggered run")
+	}
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("expected the rescheduled run")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if next := cron.Entry(id).Next; next.Before(time.Now().Add(59 * time.Minute)) {
+		t.Errorf("expected the schedule to apply again, got next %v", next)
+	}
+}
+
+// shiftedClock is the system clock, shifted by a fixed duration.
+type shiftedClock struct {
+	systemClock
+	shift time.Duration
+}
+
+func (c shiftedClock) Now() time.Time { return time.Now().Add(c.shift) }
+
+// RescheduleIn takes the current time from the Cron's clock.
+func TestRescheduleInClock(t *testing.T) {
+	done := make(chan struct{})
+	cron := New(WithClock(shiftedClock{shift: -365 * 24 * time.Hour}))
+	id := cron.Schedule(Every(24*time.Hour), ContextFuncJob(func(ctx context.Context) error {
+		RescheduleIn(ctx, time.Hour)
+		close(done)
+		return nil
+	}))
+	cron.Start()
+	defer cron.Stop()
+	cron.Trigger(id)
+	<-done
+
+	time.Sleep(10 * time.Millisecond)
+	if next, now := cron.Entry(id).Next, cron.now(); next.After(now.Add(time.Hour)) || next.Before(now.Add(59*time.Minute)) {
+		t.Errorf("expected the next activation an hour after %v, got %v", now, next)
+	}
+}
@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// WithResolution returns an Option that coarsens the Cron's activations to
+// the given resolution, such as time.Minute: every activation is moved to the
+// start of its tick, so that the scheduler wakes at most once per tick, and
+// specs that would activate more than once per tick are rejected by AddJob.
+// With a resolution of a minute or more, AddJob also accepts standard
+// five-field specs, and the seconds field of six-field specs is ignored.
+//
+// Schedules added with Schedule or UpdateSchedule are not checked; they are
+// activated at most once per tick.
+func WithResolution(resolution time.Duration) Option {
+	return func(c *Cron) {
+		c.resolution = resolution
+	}
+}
+
+// Parse returns the schedule for a spec in the syntax AddJob accepts, which
+// depends on the Cron's options, see WithResolution, WithRobfigSpecs,
+// WithMillisSpecs, WithMonthlyAtMonthEnd and WithFields.
+func (c *Cron) Parse(spec string) (Schedule, error) {
+	return c.parse(spec)
+}
+
+// parse parses the spec for AddJob, checking it against the resolution, or
+// looks it up in the Cron's ParseCache.
+func (c *Cron) parse(spec string) (Schedule, error) {
+	if c.parseCache != nil {
+		return c.parseCache.get(parseKey{spec, c.robfigSpecs, c.millisSpecs, c.monthlyAtEnd, c.resolution, c.fields}, c.parseSpec)
+	}
+	return c.parseSpec(spec)
+}
+
+func (c *Cron) parseSpec(spec string) (Schedule, error) {
+	if c.monthlyAtEnd && spec == "@monthly" {
+		spec = "@monthend"
+	}
+	parse := Parse
+	switch {
+	case c.fields != nil:
+		parse = c.fields.Parse
+	case c.robfigSpecs:
+		parse = ParseRobfig
+	case c.millisSpecs:
+		parse = ParseMillis
+	case c.resolution >= time.Minute && !strings.HasPrefix(spec, "@") && len(strings.Fields(spec)) == 5:
+		parse = ParseStandard
+	}
+	schedule, err := parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, c.checkResolution(spec, schedule)
+}
+
+// checkResolution returns an error if the schedule may activate more than
+// once per tick.
+func (c *Cron) checkResolution(spec string, schedule Schedule) error {
+	if c.resolution <= time.Second {
+		return nil
+	}
+	if l, ok := schedule.(LocationSchedule); ok {
+		schedule = l.Schedule
+	}
+	if p, ok := schedule.(PredicateSchedule); ok {
+		schedule = p.Spec
+	}
+	fine := false
+	switch s := schedule.(type) {
+	case *SpecSchedule:
+		fine = c.resolution >= time.Minute && bits.OnesCount64(s.Second&^starBit) > 1
+	case ConstantDelaySchedule:
+		fine = s.Delay%c.resolution != 0
+	case *MillisSchedule:
+		n := 0
+		for _, w := range s.Millis {
+			n += bits.OnesCount64(w)
+		}
+		fine = n > 1 || c.resolution >= time.Minute && bits.OnesCount64(s.Spec.Second&^starBit) > 1
+	}
+	if fine {
+		return fmt.Errorf("cron: spec %q is finer than the resolution of %s", spec, c.resolution)
+	}
+	return nil
+}
+
+// tick returns the time from which to look for the activation following t,
+// so that once moved to the start of its tick, the activation is still after
+// t.
+func (c *Cron) tick(t time.Time) time.Time {
+	if c.resolution <= 0 {
+		return t
+	}
+	return t.Truncate(c.resolution).Add(c.resolution - time.Nanosecond)
+}
@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolutionRejectsFinerSpecs(t *testing.T) {
+	cron := New(WithResolution(time.Minute))
+	for spec, ok := range map[string]bool{
+		"* * * * * ?":    false,
+		"*/10 * * * * ?": false,
+		"@every 30s":     false,
+		"@every 90s":     false,
+		"0 * * * * ?":    true,
+		"30 0 9 * * ?":   true,
+		"*/5 * * * *":    true,
+		"@every 2m":      true,
+		"@hourly":        true,
+	} {
+		if _, err := cron.AddFunc(spec, func() {}); (err == nil) != ok {
+			t.Errorf("%s: expected ok %v, got error %v", spec, ok, err)
+		}
+		if _, err := cron.Parse(spec); (err == nil) != ok {
+			t.Errorf("%s: expected Parse to agree with AddFunc, got error %v", spec, err)
+		}
+	}
+
+	if _, err := New().AddFunc("* * * * * ?", func() {}); err != nil {
+		t.Errorf("expected seconds without a resolution, got %v", err)
+	}
+}
+
+func TestResolutionMovesActivations(t *testing.T) {
+	cron := New(WithResolution(time.Minute))
+	schedule, _ := Parse("30 * * * * ?")
+	e := &Entry{Schedule: schedule}
+
+	for _, test := range []struct{ from, expected string }{
+		{"2024-01-01T12:00:10Z", "2024-01-01T12:01:00Z"},
+		{"2024-01-01T12:00:00Z", "2024-01-01T12:01:00Z"},
+		{"2024-01-01T11:59:59.5Z", "2024-01-01T12:00:00Z"},
+	} {
+		from, _ := time.Parse(time.RFC3339Nano, test.from)
+		expected, _ := time.Parse(time.RFC3339Nano, test.expected)
+		if next := cron.next(e, from); !next.Equal(expected) {
+			t.Errorf("from %s: expected %v, got %v", test.from, expected, next)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package cron
+
+import (
+	"strings"
+	"time"
+)
+
+// ParseRobfig parses a spec the way the default parser of robfig/cron v3
+// does.  It accepts
+//   - Standard five-field specs, e.g. "30 9 * * MON-FRI"
+//   - Descriptors, e.g. "@midnight", "@every 1h30m"
+//   - Either of them prefixed with "CRON_TZ=<zone> " or "TZ=<zone> ", which
+//     interprets the schedule in the named time zone, see LocationSchedule.
+func ParseRobfig(spec string) (Schedule, error) {
+	var location *time.Location
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		i := strings.Index(spec, " ")
+		if i < 0 {
+			return nil, specErrorf(ErrFieldCount, "Missing schedule after time zone: %s", spec)
+		}
+		name := spec[strings.Index(spec, "=")+1 : i]
+		var err error
+		if location, err = time.LoadLocation(name); err != nil {
+			return nil, specErrorf(ErrSyntax, "Provided bad location %s: %v", name, err)
+		}
+		spec = strings.TrimSpace(spec[i:])
+	}
+	if spec == "" {
+		return nil, specErrorf(ErrFieldCount, "Empty spec string")
+	}
+	schedule, err := ParseStandard(spec)
+	if err != nil {
+		return nil, err
+	}
+	if location != nil {
+		return LocationSchedule{Schedule: schedule, Location: location}, nil
+	}
+	return schedule, nil
+}
+
+// WithRobfigSpecs returns an Option that makes AddFunc and AddJob parse specs
+// with ParseRobfig, so that specs written for robfig/cron v3 can be added
+// unchanged.
+func WithRobfigSpecs() Option {
+	return func(c *Cron) {
+		c.robfigSpecs = true
+	}
+}
+
+// LocationSchedule interprets a Schedule in a fixed time zone, whatever the
+// Cron's.
+type LocationSchedule struct {
+	Schedule Schedule
+	Location *time.Location
+}
+
+// Next returns the next activation of the schedule in its time zone, in the
+// location of t.
+func (s LocationSchedule) Next(t time.Time) time.Time {
+	next := s.Schedule.Next(t.In(s.Location))
+	if next.IsZero() {
+		return next
+	}
+	return next.In(t.Location())
+}
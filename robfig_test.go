@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRobfig(t *testing.T) {
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"30 9 * * *", time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 1, 12, 15, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{"CRON_TZ=Etc/GMT-2 0 15 * * *", time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{"TZ=Etc/GMT-2 0 13 * * *", time.Date(2024, 1, 2, 11, 0, 0, 0, time.UTC)},
+	} {
+		schedule, err := ParseRobfig(test.spec)
+		if err != nil {
+			t.Errorf("%s: %v", test.spec, err)
+			continue
+		}
+		next := schedule.Next(from)
+		if !next.Equal(test.expected) || next.Location() != time.UTC {
+			t.Errorf("%s: expected %v, got %v", test.spec, test.expected, next)
+		}
+	}
+
+	for _, spec := range []string{"", "0 30 9 * * *", "CRON_TZ=Nowhere/Special 0 9 * * *", "TZ=UTC"} {
+		if _, err := ParseRobfig(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestWithRobfigSpecs(t *testing.T) {
+	cron := New(WithRobfigSpecs())
+	id, err := cron.AddFunc("30 9 * * MON-FRI", func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := cron.Entry(id).Schedule.(*SpecSchedule)
+	if s.Second != 1 || s.Minute != 1<<30 || s.Hour != 1<<9 {
+		t.Errorf("expected a minute-first spec, got %+v", s)
+	}
+}
+
+// robfigSchedule has the method set of robfig/cron v3's Schedule.
+type robfigSchedule struct{ every time.Duration }
+
+func (s robfigSchedule) Next(t time.Time) time.Time { return t.Add(s.every) }
+
+func TestRobfigScheduleInterop(t *testing.T) {
+	cron := New()
+	id := cron.Schedule(robfigSchedule{time.Hour}, FuncJob(func() {}))
+	if _, ok := cron.Entry(id).Schedule.(robfigSchedule); !ok {
+		t.Error("expected a robfig schedule to be usable as is")
+	}
+}
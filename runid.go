@@ -0,0 +1,23 @@
+package cron
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// fallbackRunIDs numbers the run IDs made when the system's random source
+// fails.
+var fallbackRunIDs uint64
+
+// newRunID returns a new, unique run ID: 32 random hexadecimal digits.
+func newRunID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		n := atomic.AddUint64(&fallbackRunIDs, 1)
+		return strconv.FormatInt(time.Now().UnixNano(), 16) + "-" + strconv.FormatUint(n, 16)
+	}
+	return hex.EncodeToString(b[:])
+}
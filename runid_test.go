@@ -0,0 +1,45 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunIDs(t *testing.T) {
+	ids := make(chan string, 2)
+	cron := New(WithStore(NewMemoryStore()))
+	events, cancel := cron.Subscribe(10)
+	defer cancel()
+	id, _ := cron.AddContextFunc("@hourly", func(ctx context.Context) error {
+		runID, ok := RunIDFromContext(ctx)
+		if !ok {
+			t.Error("expected a run ID in the context")
+		}
+		ids <- runID
+		return nil
+	})
+	cron.Trigger(id)
+	first := <-ids
+	<-cron.Stop().Done()
+	cron.Trigger(id)
+	second := <-ids
+	<-cron.Stop().Done()
+
+	if len(first) != 32 || first == second {
+		t.Errorf("expected two unique run IDs, got %q and %q", first, second)
+	}
+	history, _ := cron.History(id, time.Time{}, 0)
+	if len(history) != 2 || (history[0].ID != first && history[0].ID != second) {
+		t.Errorf("expected the run IDs in the history, got %+v", history)
+	}
+	if event := expectEvent(t, events, RunStarted); event.Run.ID != first {
+		t.Errorf("expected run ID %s in the event, got %s", first, event.Run.ID)
+	}
+}
+
+func TestRunIDOutsideRun(t *testing.T) {
+	if _, ok := RunIDFromContext(context.Background()); ok {
+		t.Error("expected no run ID")
+	}
+}
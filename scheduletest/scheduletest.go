@@ -0,0 +1,116 @@
+// Package scheduletest provides assertions for testing cron.Schedule
+// implementations, so that schedules defined outside this module, and those
+// built from its own, can share one conformance suite:
+//
+//	func TestBusinessHours(t *testing.T) {
+//		s := BusinessHours(time.UTC)
+//		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//		scheduletest.NextIsMonotonic(t, s, from, 1000)
+//		scheduletest.NextMatchesSpec(t, s, "0 0 9-17 * * MON-FRI", from, 1000)
+//	}
+//
+// Each assertion walks the activations of a schedule from a given time,
+// reporting the first problem it finds with t.Errorf.
+package scheduletest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// NextIsMonotonic asserts that the first n activations of the schedule
+// after from, or as many as it has, each follow the time Next was called
+// with.  A schedule may end by returning the zero time.
+func NextIsMonotonic(t testing.TB, schedule cron.Schedule, from time.Time, n int) {
+	t.Helper()
+	prev := from
+	for i := 0; i < n; i++ {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			return
+		}
+		if !next.After(prev) {
+			t.Errorf("expected the activation after %v to follow it, got %v", prev, next)
+			return
+		}
+		prev = next
+	}
+}
+
+// NextMatchesSpec asserts that the first n activations of the schedule after
+// from, or as many as it has, are activations of the spec, in the syntax of
+// cron.Parse.  "@every" specs, whose activations depend on when they are
+// looked for, are not accepted.
+func NextMatchesSpec(t testing.TB, schedule cron.Schedule, spec string, from time.Time, n int) {
+	t.Helper()
+	expected, err := cron.Parse(spec)
+	if err != nil {
+		t.Errorf("invalid spec %q: %v", spec, err)
+		return
+	}
+	if _, ok := expected.(cron.ConstantDelaySchedule); ok {
+		t.Errorf("spec %q has no fixed activations to match", spec)
+		return
+	}
+	prev := from
+	for i := 0; i < n; i++ {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			return
+		}
+		// The spec activates at whole seconds, so an activation of it is
+		// the first one after the second before.
+		if !expected.Next(next.Add(-time.Second)).Equal(next) {
+			t.Errorf("expected the activation after %v to be an activation of %q, got %v", prev, spec, next)
+			return
+		}
+		prev = next
+	}
+}
+
+// EquivalentOver asserts that the schedules have the same activations from
+// from until horizon after it.  Every activation in that time is compared,
+// so the horizon of frequent schedules should be short.
+func EquivalentOver(t testing.TB, a, b cron.Schedule, from time.Time, horizon time.Duration) {
+	t.Helper()
+	if err := equivalent(a, b, from, horizon); err != nil {
+		t.Error(err)
+	}
+}
+
+func equivalent(a, b cron.Schedule, from time.Time, horizon time.Duration) error {
+	end := from.Add(horizon)
+	for prev := from; ; {
+		na, nb := a.Next(prev), b.Next(prev)
+		if !na.Equal(nb) {
+			return fmt.Errorf("expected the activations after %v to be the same, got %v and %v", prev, na, nb)
+		}
+		if na.IsZero() || na.After(end) {
+			return nil
+		}
+		prev = na
+	}
+}
+
+// RoundTripsThroughString asserts that the schedule implements fmt.Stringer
+// and that parsing its string, with parse, returns a schedule equivalent to
+// it from from until horizon after it, see EquivalentOver.
+func RoundTripsThroughString(t testing.TB, schedule cron.Schedule, parse func(string) (cron.Schedule, error), from time.Time, horizon time.Duration) {
+	t.Helper()
+	s, ok := schedule.(fmt.Stringer)
+	if !ok {
+		t.Errorf("expected %T to implement fmt.Stringer", schedule)
+		return
+	}
+	parsed, err := parse(s.String())
+	if err != nil {
+		t.Errorf("expected %q to parse: %v", s.String(), err)
+		return
+	}
+	if err := equivalent(schedule, parsed, from, horizon); err != nil {
+		t.Errorf("%q: %v", s.String(), err)
+	}
+}
@@ -0,0 +1,95 @@
+package scheduletest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+var from = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// recorder is a testing.TB recording the errors reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...interface{}) { r.errors = append(r.errors, fmt.Sprint(args...)) }
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// scheduleFunc is a Schedule implemented by a function.
+type scheduleFunc func(time.Time) time.Time
+
+func (f scheduleFunc) Next(t time.Time) time.Time { return f(t) }
+
+func mustParse(spec string) cron.Schedule {
+	s, err := cron.Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func TestConformingSchedules(t *testing.T) {
+	for _, spec := range []string{
+		"0 30 9 * * MON-FRI",
+		"*/15 * * * * *",
+		"0 0 0 1,15 * MON",
+		"0 0 0 */2 JAN-MAR *",
+		"0 0 12 29 FEB *",
+	} {
+		s := mustParse(spec)
+		NextIsMonotonic(t, s, from, 500)
+		NextMatchesSpec(t, s, spec, from, 500)
+		EquivalentOver(t, s, mustParse(spec), from, 24*time.Hour)
+		RoundTripsThroughString(t, s, cron.Parse, from, 90*24*time.Hour)
+	}
+
+	every := cron.Every(90 * time.Minute)
+	NextIsMonotonic(t, every, from, 500)
+	RoundTripsThroughString(t, every, cron.Parse, from, 24*time.Hour)
+}
+
+func TestFailures(t *testing.T) {
+	backwards := scheduleFunc(func(t time.Time) time.Time { return t.Add(-time.Second) })
+	hourly := mustParse("@hourly")
+	for name, assert := range map[string]func(t testing.TB){
+		"monotonic":      func(t testing.TB) { NextIsMonotonic(t, backwards, from, 10) },
+		"matches spec":   func(t testing.TB) { NextMatchesSpec(t, hourly, "@daily", from, 10) },
+		"invalid spec":   func(t testing.TB) { NextMatchesSpec(t, hourly, "x", from, 10) },
+		"every spec":     func(t testing.TB) { NextMatchesSpec(t, hourly, "@every 1h", from, 10) },
+		"equivalent":     func(t testing.TB) { EquivalentOver(t, hourly, mustParse("0 30 * * * *"), from, time.Hour) },
+		"not a stringer": func(t testing.TB) { RoundTripsThroughString(t, backwards, cron.Parse, from, time.Hour) },
+		"round trip": func(t testing.TB) {
+			RoundTripsThroughString(t, hourly, func(string) (cron.Schedule, error) { return mustParse("@daily"), nil }, from, 48*time.Hour)
+		},
+	} {
+		r := &recorder{}
+		assert(r)
+		if len(r.errors) != 1 {
+			t.Errorf("%s: expected one error, got %q", name, r.errors)
+		}
+	}
+}
+
+func TestEndingSchedule(t *testing.T) {
+	once := scheduleFunc(func(t time.Time) time.Time {
+		if t.Before(from.Add(time.Hour)) {
+			return from.Add(time.Hour)
+		}
+		return time.Time{}
+	})
+	r := &recorder{}
+	NextIsMonotonic(r, once, from, 10)
+	EquivalentOver(r, once, once, from, 24*time.Hour)
+	if len(r.errors) != 0 {
+		t.Errorf("expected no errors, got %q", r.errors)
+	}
+}
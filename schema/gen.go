@@ -0,0 +1,24 @@
+//go:build ignore
+// +build ignore
+
+// gen writes the published schemas to the current directory.
+package main
+
+import (
+	"io/ioutil"
+	"log"
+
+	"github.com/webconnex/cron/schema"
+)
+
+func main() {
+	for name, doc := range schema.Documents {
+		b, err := schema.Generate(doc.Value, doc.Title).Marshal()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, b, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
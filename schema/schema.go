@@ -0,0 +1,48 @@
+// Package schema publishes JSON Schemas for the serialized forms of
+// schedules and entries, so that external tools and configuration pipelines
+// can validate schedule documents before they reach a Cron.
+//
+// The schemas are generated from the Go types with Generate, and checked in
+// next to this file:
+//
+//	schedule.schema.json   a cron.KubernetesSchedule
+//	entry.schema.json      an admin.Entry, as served by the admin API
+//
+// Run "go generate" in this directory after changing those types.
+package schema
+
+//go:generate go run gen.go
+
+import (
+	"github.com/webconnex/cron"
+	"github.com/webconnex/cron/admin"
+	"github.com/webconnex/cron/internal/jsonschema"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Documents maps the file name of each published schema to the type it
+// describes and its title.
+var Documents = map[string]struct {
+	Value interface{}
+	Title string
+}{
+	"schedule.schema.json": {cron.KubernetesSchedule{}, "Schedule"},
+	"entry.schema.json":    {admin.Entry{}, "Entry"},
+}
+
+// Schema is a JSON Schema.  Its Marshal method returns its indented JSON, as
+// checked in.
+type Schema = jsonschema.Schema
+
+// Generate returns the JSON Schema of the JSON encoding of v's type, with the
+// given title.  Struct fields are required unless they are tagged omitempty.
+// It panics on types encoding/json cannot encode to a fixed shape, such as
+// interfaces and channels.
+func Generate(v interface{}, title string) Schema {
+	s := jsonschema.Of(v)
+	s["$schema"] = Draft
+	s["title"] = title
+	return s
+}
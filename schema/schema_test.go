@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestDocumentsUpToDate(t *testing.T) {
+	for name, doc := range Documents {
+		expected, err := Generate(doc.Value, doc.Title).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := ioutil.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(actual, expected) {
+			t.Errorf("%s is out of date, run go generate", name)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(struct{ A int }{}, "A")
+	if s["$schema"] != Draft || s["title"] != "A" || s["type"] != "object" {
+		t.Errorf("unexpected header: %v", s)
+	}
+}
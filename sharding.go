@@ -0,0 +1,90 @@
+package cron
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+)
+
+// WithSharding returns an Option that splits the entries among the replicas
+// of a service, each running the same entries: the Cron only dispatches the
+// scheduled runs of the entries it owns, so the replicas share the workload
+// without claiming every activation in a shared Store.
+//
+// self names this replica and members the replicas sharing the entries,
+// including self.  Ownership is decided by rendezvous hashing of each entry's
+// name, or of its ID if it has none, so every replica given the same members
+// agrees on it, and a change of members only moves the entries of the
+// replicas that joined or left.  A replica that is not among the members owns
+// no entries.  Runs started with Trigger are not affected.
+func WithSharding(self string, members ...string) Option {
+	return func(c *Cron) {
+		c.shard = &shard{self: self}
+		c.shard.set(members)
+	}
+}
+
+// SetMembers replaces the members given to WithSharding, for instance when
+// the service scales.  It does nothing if the Cron is not sharded.
+func (c *Cron) SetMembers(members ...string) {
+	if c.shard != nil {
+		c.shard.set(members)
+	}
+}
+
+// Owns reports whether this replica owns the given entry, see WithSharding.
+// It always does if the Cron is not sharded.
+func (c *Cron) Owns(e *Entry) bool {
+	return c.shard == nil || c.shard.owner(shardKey(e)) == c.shard.self
+}
+
+// shardKey returns the key an entry is sharded by.
+func shardKey(e *Entry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return strconv.Itoa(int(e.ID))
+}
+
+// shard holds the members of a sharded Cron.
+type shard struct {
+	self    string
+	mu      sync.RWMutex
+	members []string
+}
+
+func (s *shard) set(members []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.members = append([]string(nil), members...)
+}
+
+// owner returns the member owning the key: the one with the highest hash of
+// the key and its name, ties going to the lowest name.
+func (s *shard) owner(key string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var owner string
+	var max uint64
+	for i, m := range s.members {
+		h := fnv.New64a()
+		h.Write([]byte(m))
+		h.Write([]byte{0})
+		h.Write([]byte(key))
+		if sum := mix(h.Sum64()); i == 0 || sum > max || sum == max && m < owner {
+			owner, max = m, sum
+		}
+	}
+	return owner
+}
+
+// mix scrambles the bits of an FNV hash, whose high bits depend little on
+// the first bytes hashed, so that members compare fairly.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
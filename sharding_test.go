@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShardingPartitions(t *testing.T) {
+	members := []string{"a", "b", "c"}
+	replicas := map[string]*Cron{}
+	for _, m := range members {
+		replicas[m] = New(WithSharding(m, members...))
+	}
+
+	owned := map[string]int{}
+	for i := 0; i < 300; i++ {
+		e := &Entry{Name: fmt.Sprintf("job-%d", i)}
+		owners := 0
+		for m, c := range replicas {
+			if c.Owns(e) {
+				owners++
+				owned[m]++
+			}
+		}
+		if owners != 1 {
+			t.Fatalf("expected %s to have one owner, got %d", e.Name, owners)
+		}
+	}
+	for _, m := range members {
+		if owned[m] < 50 {
+			t.Errorf("expected the entries to be spread evenly, got %v", owned)
+		}
+	}
+}
+
+func TestShardingMembershipChange(t *testing.T) {
+	c := New(WithSharding("a", "a", "b", "c"))
+	before := map[string]bool{}
+	for i := 0; i < 300; i++ {
+		e := &Entry{Name: fmt.Sprintf("job-%d", i)}
+		before[e.Name] = c.Owns(e)
+	}
+
+	// When c leaves, a keeps its entries and takes some of c's.
+	c.SetMembers("a", "b")
+	for i := 0; i < 300; i++ {
+		e := &Entry{Name: fmt.Sprintf("job-%d", i)}
+		if before[e.Name] && !c.Owns(e) {
+			t.Errorf("expected %s to stay with a", e.Name)
+		}
+	}
+
+	c.SetMembers("b", "c")
+	if c.Owns(&Entry{Name: "job-0"}) {
+		t.Error("expected a replica outside the members to own nothing")
+	}
+	if !New().Owns(&Entry{Name: "job-0"}) {
+		t.Error("expected an unsharded Cron to own everything")
+	}
+}
+
+func TestShardingDispatchesOwnedEntries(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		dispatched = map[string]bool{}
+	)
+	c := New(WithSharding("a", "a", "b"), WithDryRun(),
+		WithBatchHook(func(scheduled time.Time, batch []*Entry) {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, e := range batch {
+				dispatched[e.Name] = true
+			}
+		}))
+	c.ErrorLog = log.New(ioutil.Discard, "", 0)
+	var owned []string
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("job-%d", i)
+		c.AddFunc("* * * * * ?", func() {}, WithName(name))
+		if c.Owns(&Entry{Name: name}) {
+			owned = append(owned, name)
+		}
+	}
+	c.Start()
+	time.Sleep(ONE_SECOND)
+	<-c.Stop().Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(dispatched) != len(owned) {
+		t.Errorf("expected the %d owned entries to be dispatched, got %v", len(owned), dispatched)
+	}
+	for _, name := range owned {
+		if !dispatched[name] {
+			t.Errorf("expected %s to be dispatched", name)
+		}
+	}
+}
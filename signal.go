@@ -0,0 +1,52 @@
+//go:build !js && !wasip1 && !tinygo
+// +build !js,!wasip1,!tinygo
+
+package cron
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ErrDrainTimeout is returned by RunUntilSignal when jobs are still running
+// once the drain timeout has passed.
+var ErrDrainTimeout = errors.New("cron: jobs still running after drain timeout")
+
+// RunUntilSignal starts the Cron and blocks until one of the given signals
+// arrives, SIGINT or SIGTERM if none are given.  It then stops the Cron and
+// waits up to drain for the running jobs to finish, or indefinitely if drain
+// is not positive, returning ErrDrainTimeout if they did not.
+//
+//	c := cron.New()
+//	c.AddFunc("@hourly", cleanup)
+//	if err := cron.RunUntilSignal(c, 30*time.Second); err != nil {
+//		log.Fatal(err)
+//	}
+func RunUntilSignal(c *Cron, drain time.Duration, signals ...os.Signal) error {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	defer signal.Stop(ch)
+
+	c.Start()
+	<-ch
+
+	done := c.Stop().Done()
+	if drain <= 0 {
+		<-done
+		return nil
+	}
+	timer := time.NewTimer(drain)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return ErrDrainTimeout
+	}
+}
@@ -0,0 +1,50 @@
+//go:build !windows && !js && !wasip1 && !tinygo
+// +build !windows,!js,!wasip1,!tinygo
+
+package cron
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func runUntilSignal(t *testing.T, job func(), drain time.Duration) error {
+	cron := New()
+	started := make(chan struct{}, 1)
+	cron.AddFunc("* * * * * ?", func() {
+		select {
+		case started <- struct{}{}:
+			job()
+		default:
+		}
+	})
+	errs := make(chan error, 1)
+	go func() { errs <- RunUntilSignal(cron, drain, syscall.SIGUSR1) }()
+
+	select {
+	case <-started:
+	case <-time.After(2 * ONE_SECOND):
+		t.Fatal("expected the job to start")
+	}
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	select {
+	case err := <-errs:
+		return err
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected RunUntilSignal to return")
+	}
+	return nil
+}
+
+func TestRunUntilSignal(t *testing.T) {
+	if err := runUntilSignal(t, func() { time.Sleep(100 * time.Millisecond) }, time.Second); err != nil {
+		t.Errorf("expected the job to drain, got %v", err)
+	}
+}
+
+func TestRunUntilSignalDrainTimeout(t *testing.T) {
+	if err := runUntilSignal(t, func() { time.Sleep(time.Second) }, 100*time.Millisecond); err != ErrDrainTimeout {
+		t.Errorf("expected ErrDrainTimeout, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Simplify rewrites a spec, in the syntax of Parse, into the shortest
+// equivalent six-field spec it can find, for storing user input in a
+// canonical form: lists are deduplicated and sorted, adjacent values and
+// ranges merged, evenly stepped values written as steps, and fields holding
+// every value collapsed to "*".  Values are written as numbers, and day of
+// month and day of week fields that together allow every day both become
+// "*".  Descriptors are returned unchanged.
+//
+// It returns an error if the spec does not parse.
+func Simplify(spec string) (string, error) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(spec, "@") {
+		return spec, nil
+	}
+	s := schedule.(*SpecSchedule)
+	fields := []string{
+		simplifyField(s.Second, seconds, starAllowed),
+		simplifyField(s.Minute, minutes, starAllowed),
+		simplifyField(s.Hour, hours, starAllowed),
+	}
+	days := simplifyDays(s)
+	fields = append(fields, days[0], simplifyField(s.Month, months, starAllowed), days[1])
+	return strings.Join(fields, " "), nil
+}
+
+// Whether a simplified field may, must or must not begin with a star, which
+// decides how the day fields combine.
+const (
+	starAllowed = iota
+	starRequired
+	starForbidden
+)
+
+// simplifyDays returns the simplified day of month and day of week fields.
+// With neither starred, the fields allow the days either allows, and
+// otherwise the days both allow, so the star of a restricted field is kept
+// while the other is restricted too.
+func simplifyDays(s *SpecSchedule) [2]string {
+	domAll, dowAll := isAll(s.Dom, dom), isAll(s.Dow, dow)
+	domStar, dowStar := s.Dom&starBit > 0, s.Dow&starBit > 0
+	switch {
+	case !domStar && !dowStar:
+		if domAll || dowAll {
+			return [2]string{"*", "*"}
+		}
+		return [2]string{simplifyField(s.Dom, dom, starForbidden), simplifyField(s.Dow, dow, starForbidden)}
+	case domAll:
+		return [2]string{"*", simplifyField(s.Dow, dow, starAllowed)}
+	case dowAll:
+		return [2]string{simplifyField(s.Dom, dom, starAllowed), "*"}
+	}
+	star := func(starred bool) int {
+		if starred {
+			return starRequired
+		}
+		return starAllowed
+	}
+	return [2]string{simplifyField(s.Dom, dom, star(domStar)), simplifyField(s.Dow, dow, star(dowStar))}
+}
+
+// simplifyField returns the shortest rendering of the field's values that
+// Parse parses back to them, beginning with a star or not as star requires.
+func simplifyField(bits uint64, r bounds, star int) string {
+	var values []uint
+	for v := r.min; v <= r.max; v++ {
+		if 1<<v&bits != 0 {
+			values = append(values, v)
+		}
+	}
+	if isAll(bits, r) && star != starForbidden {
+		return "*"
+	}
+
+	var candidates []string
+	list := formatList(bits&^starBit, r, "-", func(v uint) string { return fmt.Sprint(v) })
+	if isAll(bits, r) {
+		list = fmt.Sprintf("%d-%d", r.min, r.max)
+	}
+	if star != starRequired {
+		candidates = append(candidates, list)
+	}
+	// A star with a step beyond the bounds adds the minimum alone.
+	if values[0] == r.min && star != starForbidden {
+		candidates = append(candidates, list+fmt.Sprintf(",*/%d", r.max-r.min+1))
+	}
+	if step, ok := stepOf(values); ok {
+		first, last := values[0], values[len(values)-1]
+		toEnd := last+step > r.max
+		switch {
+		case first == r.min && toEnd && star != starForbidden:
+			candidates = append(candidates, fmt.Sprintf("*/%d", step))
+		case star == starRequired:
+		case toEnd:
+			candidates = append(candidates, fmt.Sprintf("%d/%d", first, step))
+		default:
+			candidates = append(candidates, fmt.Sprintf("%d-%d/%d", first, last, step))
+		}
+	}
+
+	shortest := candidates[0]
+	for _, c := range candidates[1:] {
+		if len(c) < len(shortest) {
+			shortest = c
+		}
+	}
+	return shortest
+}
+
+// stepOf returns the step between the values, if there are at least three
+// evenly stepped ones.
+func stepOf(values []uint) (uint, bool) {
+	if len(values) < 3 {
+		return 0, false
+	}
+	step := values[1] - values[0]
+	for i := 2; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return 0, false
+		}
+	}
+	return step, true
+}
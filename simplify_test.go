@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"0 0 9 * * MON-FRI", "0 0 9 * * 1-5"},
+		{"0,0,0 5,1-3,4 9,10,11,12 * * *", "0 1-5 9-12 * * *"},
+		{"0-59 */1 0-23 * JAN-DEC SUN-SAT", "* * * * * *"},
+		{"0,15,30,45 0 0 * * *", "*/15 0 0 * * *"},
+		{"0 0 0 2,4,6,8,10,12,14,16,18,20,22,24,26,28,30 * *", "0 0 0 2/2 * *"},
+		{"0 0 1,4,7,10 * * *", "0 0 1-10/3 * * *"},
+		{"0 0 0 * * MON,TUE,WED", "0 0 0 * * 1-3"},
+		{"0 0 0 * Feb,Jan *", "0 0 0 * 1-2 *"},
+		// Days of month or days of week, either of which allows every day.
+		{"0 0 0 1-31 * MON", "0 0 0 * * *"},
+		// A stepped star in one day field with the other restricted keeps
+		// its star, so that the fields still both restrict the day.
+		{"0 0 0 */2 * MON", "0 0 0 */2 * 1"},
+		{"0 0 0 1-31/2 * MON", "0 0 0 1/2 * 1"},
+		{"0 0 0 */10,5 * MON", "0 0 0 1,5,11,21,31,*/31 * 1"},
+		{"0 0 0 1 * *", "0 0 0 1 * *"},
+		{"@daily", "@daily"},
+	}
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, test := range tests {
+		simplified, err := Simplify(test.spec)
+		if err != nil {
+			t.Errorf("%s: %v", test.spec, err)
+			continue
+		}
+		if simplified != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.spec, test.expected, simplified)
+		}
+		if d := Diff(mustParse(test.spec), mustParse(simplified), from, 2*366*24*time.Hour); !d.Empty() {
+			t.Errorf("%s: expected %q to be equivalent, got %s", test.spec, simplified, d)
+		}
+	}
+
+	if _, err := Simplify("0 0 0 32 * *"); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+}
@@ -0,0 +1,214 @@
+// Package simulate runs a Cron against a virtual clock, fast-forwarding
+// through its schedule and collecting every activation, so that complex sets
+// of schedules can be regression-tested in milliseconds.
+//
+//	sim := simulate.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	sim.Cron.AddFunc("0 30 9 * * MON-FRI", func() {}, cron.WithName("report"))
+//	for _, a := range sim.Run(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)) {
+//		fmt.Println(a.Name, a.Scheduled)
+//	}
+package simulate
+
+import (
+	"io/ioutil"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// Activation is an activation of an entry seen by a Simulation.
+type Activation struct {
+	// EntryID and Name identify the entry.
+	EntryID cron.EntryID
+	Name    string
+
+	// Scheduled is the time the entry was activated for.
+	Scheduled time.Time
+}
+
+// Simulation runs a Cron on a virtual clock.  The Cron is in dry-run mode,
+// so jobs are never run; everything else, from the entries' options to
+// rate limits, applies as it would in production.
+type Simulation struct {
+	// Cron is the simulated scheduler.  Entries may be added to it before
+	// and between calls to Run.
+	Cron *cron.Cron
+
+	clock       *clock
+	activations []Activation
+}
+
+// New returns a Simulation whose clock starts at the given time, in its
+// location.  The options are applied to the Cron, except that it is always
+// in dry-run mode and any batch hook is replaced by the Simulation's.
+func New(start time.Time, opts ...cron.Option) *Simulation {
+	s := &Simulation{clock: newClock(start)}
+	opts = append(opts,
+		cron.WithClock(s.clock),
+		cron.WithDryRun(),
+		cron.WithBatchHook(s.collect))
+	s.Cron = cron.NewWithLocation(start.Location(), opts...)
+	s.Cron.ErrorLog = log.New(ioutil.Discard, "", 0)
+	return s
+}
+
+// Now returns the current time of the simulation.
+func (s *Simulation) Now() time.Time {
+	return s.clock.Now()
+}
+
+// Run runs the Cron until the given time, and returns the activations seen
+// meanwhile, in order.  The clock is left at the given time, so that a
+// further Run carries on from there.
+func (s *Simulation) Run(until time.Time) []Activation {
+	s.activations = nil
+	s.Cron.Start()
+	s.clock.waitArmed()
+	for {
+		t := s.clock.next()
+		if t == nil || t.when.After(until) {
+			break
+		}
+		if s.clock.fire(t) {
+			s.clock.waitArmed()
+		}
+	}
+	s.Cron.Stop()
+	s.clock.set(until)
+	return s.activations
+}
+
+// collect records a batch of activations; it is the Cron's batch hook.
+func (s *Simulation) collect(scheduled time.Time, batch []*cron.Entry) {
+	for _, e := range batch {
+		s.activations = append(s.activations, Activation{
+			EntryID:   e.ID,
+			Name:      e.Name,
+			Scheduled: scheduled,
+		})
+	}
+}
+
+// clock is a virtual cron.Clock.  Time only moves when the Simulation fires
+// a timer, which it does once the Cron is idle, waiting on a new timer.
+type clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*timer
+	seq    int
+	armed  chan struct{}
+}
+
+func newClock(start time.Time) *clock {
+	return &clock{now: start, armed: make(chan struct{}, 1)}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) NewTimer(d time.Duration) cron.Timer {
+	t := c.add(d, nil)
+	select {
+	case c.armed <- struct{}{}:
+	default:
+	}
+	return t
+}
+
+func (c *clock) AfterFunc(d time.Duration, f func()) cron.Timer {
+	return c.add(d, f)
+}
+
+func (c *clock) add(d time.Duration, f func()) *timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	t := &timer{clock: c, when: c.now.Add(d), seq: c.seq, f: f}
+	if f == nil {
+		t.c = make(chan time.Time, 1)
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// set moves the clock forward to the given time.
+func (c *clock) set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.After(c.now) {
+		c.now = now
+	}
+}
+
+// waitArmed waits for the Cron to set its next timer.
+func (c *clock) waitArmed() {
+	<-c.armed
+}
+
+// next returns the timer due to fire first, or nil if there is none.
+func (c *clock) next() *timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sort.Slice(c.timers, func(i, j int) bool {
+		if !c.timers[i].when.Equal(c.timers[j].when) {
+			return c.timers[i].when.Before(c.timers[j].when)
+		}
+		return c.timers[i].seq < c.timers[j].seq
+	})
+	if len(c.timers) == 0 {
+		return nil
+	}
+	return c.timers[0]
+}
+
+// fire moves the clock to the timer's time, if later, and fires it.  It
+// returns true if the timer was a Cron's, which then goes on to set another.
+func (c *clock) fire(t *timer) bool {
+	c.mu.Lock()
+	if t.when.After(c.now) {
+		c.now = t.when
+	}
+	now := c.now
+	c.remove(t)
+	c.mu.Unlock()
+	if t.f != nil {
+		go t.f()
+		return false
+	}
+	t.c <- now
+	return true
+}
+
+// remove removes the timer, returning false if it was not pending.  The
+// caller must hold mu.
+func (c *clock) remove(t *timer) bool {
+	for i, pending := range c.timers {
+		if pending == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type timer struct {
+	clock *clock
+	when  time.Time
+	seq   int
+	c     chan time.Time
+	f     func()
+}
+
+func (t *timer) C() <-chan time.Time { return t.c }
+
+func (t *timer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
@@ -0,0 +1,73 @@
+package simulate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestRun(t *testing.T) {
+	sim := New(start)
+	report, _ := sim.Cron.AddFunc("0 30 9 * * MON-FRI", func() {}, cron.WithName("report"))
+	sim.Cron.AddFunc("@monthly", func() {})
+
+	begun := time.Now()
+	activations := sim.Run(start.AddDate(0, 6, 0))
+	if elapsed := time.Since(begun); elapsed > 5*time.Second {
+		t.Errorf("expected six months to take well under 5s, took %v", elapsed)
+	}
+
+	var reports, monthly int
+	prev := time.Time{}
+	for _, a := range activations {
+		if a.Scheduled.Before(prev) {
+			t.Fatalf("expected activations in order, got %v after %v", a.Scheduled, prev)
+		}
+		prev = a.Scheduled
+		if a.EntryID == report {
+			reports++
+			if a.Name != "report" || a.Scheduled.Hour() != 9 || a.Scheduled.Minute() != 30 {
+				t.Errorf("unexpected activation %+v", a)
+			}
+		} else {
+			monthly++
+		}
+	}
+	// 2024 has 130 weekdays from January through June.
+	if reports != 130 {
+		t.Errorf("expected 130 reports, got %d", reports)
+	}
+	// From February 1st to July 1st: the start itself is not included, the
+	// end is.
+	if monthly != 6 {
+		t.Errorf("expected 6 monthly activations, got %d", monthly)
+	}
+	if !sim.Now().Equal(start.AddDate(0, 6, 0)) {
+		t.Errorf("expected the clock at the end of the run, got %v", sim.Now())
+	}
+}
+
+func TestRunContinues(t *testing.T) {
+	sim := New(start)
+	sim.Cron.Schedule(cron.Every(time.Hour), cron.FuncJob(func() {}), cron.MaxRuns(30, false))
+
+	first := sim.Run(start.AddDate(0, 0, 1))
+	second := sim.Run(start.AddDate(0, 0, 2))
+	if len(first) != 24 || len(second) != 6 {
+		t.Fatalf("expected 24 then 6 activations, got %d then %d", len(first), len(second))
+	}
+	if want := start.Add(25 * time.Hour); !second[0].Scheduled.Equal(want) {
+		t.Errorf("expected the second run to start at %v, got %v", want, second[0].Scheduled)
+	}
+}
+
+func TestRunDoesNotRunJobs(t *testing.T) {
+	sim := New(start)
+	sim.Cron.AddFunc("@hourly", func() { t.Error("expected the job not to run") })
+	if n := len(sim.Run(start.AddDate(0, 0, 1))); n != 24 {
+		t.Errorf("expected 24 activations, got %d", n)
+	}
+}
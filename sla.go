@@ -0,0 +1,104 @@
+package cron
+
+import "time"
+
+// SLA is a service level an entry's runs must meet, see WithSLA.
+type SLA struct {
+	// StartWithin is how long after its scheduled time a run must start,
+	// including any jitter.  Zero does not bound it.
+	StartWithin time.Duration
+
+	// FinishWithin is how long after its scheduled time a run must finish.
+	// Zero does not bound it.
+	FinishWithin time.Duration
+}
+
+// SLAKind identifies the bound of an SLA that a run breached.
+type SLAKind int
+
+const (
+	// SLAStart means the run started later than its SLA's StartWithin.
+	SLAStart SLAKind = iota
+
+	// SLAFinish means the run had not finished by its SLA's FinishWithin.
+	SLAFinish
+)
+
+func (k SLAKind) String() string {
+	switch k {
+	case SLAStart:
+		return "start"
+	case SLAFinish:
+		return "finish"
+	}
+	return "unknown"
+}
+
+// SLABreach reports a run that breached its entry's SLA.
+type SLABreach struct {
+	Kind SLAKind
+
+	// Run is the run that breached the SLA.  For an SLAFinish breach, it
+	// is still running: its End and Outcome are not yet set.
+	Run Run
+
+	// Limit is the bound that was breached, after the run's scheduled
+	// time.
+	Limit time.Duration
+}
+
+// WithSLA returns an EntryOption that holds the entry's runs to the SLA.
+// Each breach is logged, sent as an SLABreached event, and passed to the
+// hook set with WithSLAHook.  A run that starts late is reported when it
+// starts, and one that runs late as soon as its deadline passes, without
+// waiting for it to finish, so that someone can be paged while it is still
+// running.  Runs started by Cron.Backfill are not held to it.
+func WithSLA(sla SLA) EntryOption {
+	return func(e *Entry) {
+		e.SLA = sla
+	}
+}
+
+// WithSLAHook returns an Option that calls hook with each breach of an
+// entry's SLA, see WithSLA.  Unlike the hooks for failures, it is meant for
+// paging: a breach is reported whether or not the run then succeeds.  The
+// hook is called in its own goroutine for SLAFinish breaches, and in the
+// run's goroutine, before the job runs, for SLAStart breaches.
+func WithSLAHook(hook func(breach SLABreach)) Option {
+	return func(c *Cron) {
+		c.slaHook = hook
+	}
+}
+
+// watchSLA checks the SLA of the entry for the run just started, returning a
+// function to call once it has finished.
+func (c *Cron) watchSLA(entry *Entry, run Run, rlog runLog) func() {
+	sla := entry.SLA
+	if sla.StartWithin > 0 && run.Start.Sub(run.Scheduled) > sla.StartWithin {
+		c.breach(entry, SLABreach{Kind: SLAStart, Run: run, Limit: sla.StartWithin}, rlog)
+	}
+	if sla.FinishWithin <= 0 {
+		return func() {}
+	}
+	breach := SLABreach{Kind: SLAFinish, Run: run, Limit: sla.FinishWithin}
+	timer := c.clock.AfterFunc(run.Scheduled.Add(sla.FinishWithin).Sub(run.Start), func() {
+		c.breach(entry, breach, rlog)
+	})
+	return func() { timer.Stop() }
+}
+
+// breach reports the breach of the entry's SLA.
+func (c *Cron) breach(entry *Entry, breach SLABreach, rlog runLog) {
+	rlog.logf("cron: run %s breached its SLA: did not %s within %s", rlog.name, breach.Kind, breach.Limit)
+	c.events.send(Event{
+		Type:      SLABreached,
+		Time:      c.now(),
+		EntryID:   entry.ID,
+		Namespace: entry.Namespace,
+		Run:       &breach.Run,
+		Breach:    &breach,
+	})
+	if c.slaHook != nil {
+		c.slaHook(breach)
+	}
+}
@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+)
+
+// executeAt runs the entry's job for an activation scheduled at the given
+// time, as the Cron would have dispatched it.
+func executeAt(c *Cron, id EntryID, scheduled time.Time) Run {
+	entry := c.Entry(id)
+	run := Run{EntryID: id, Scheduled: scheduled}
+	return c.execute(context.Background(), entry, run, c.runLog(entry, run), scheduled)
+}
+
+func TestSLAStart(t *testing.T) {
+	breaches := make(chan SLABreach, 2)
+	cron := New(WithSLAHook(func(b SLABreach) { breaches <- b }))
+	cron.ErrorLog = log.New(ioutil.Discard, "", 0)
+	id, _ := cron.AddFunc("@yearly", func() {}, WithSLA(SLA{StartWithin: time.Minute}))
+	events, cancel := cron.Subscribe(10)
+	defer cancel()
+
+	executeAt(cron, id, time.Now().Add(-2*time.Minute))
+	select {
+	case b := <-breaches:
+		if b.Kind != SLAStart || b.Limit != time.Minute || b.Run.EntryID != id {
+			t.Errorf("unexpected breach %+v", b)
+		}
+	default:
+		t.Fatal("expected a breach")
+	}
+	if event := expectEvent(t, events, SLABreached); event.Breach == nil || event.Breach.Kind != SLAStart {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	executeAt(cron, id, time.Now())
+	select {
+	case b := <-breaches:
+		t.Errorf("expected no breach, got %+v", b)
+	default:
+	}
+}
+
+func TestSLAFinish(t *testing.T) {
+	breaches := make(chan SLABreach, 2)
+	cron := New(WithSLAHook(func(b SLABreach) { breaches <- b }))
+	cron.ErrorLog = log.New(ioutil.Discard, "", 0)
+	finished := make(chan struct{})
+	slow, _ := cron.AddFunc("@yearly", func() {
+		select {
+		case b := <-breaches:
+			if b.Kind != SLAFinish || !b.Run.End.IsZero() {
+				t.Errorf("unexpected breach %+v", b)
+			}
+		case <-time.After(time.Second):
+			t.Error("expected a breach while the job was running")
+		}
+		close(finished)
+	}, WithSLA(SLA{FinishWithin: 20 * time.Millisecond}))
+	fast, _ := cron.AddFunc("@yearly", func() {}, WithSLA(SLA{FinishWithin: 20 * time.Millisecond}))
+
+	executeAt(cron, slow, time.Now())
+	<-finished
+	executeAt(cron, fast, time.Now())
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case b := <-breaches:
+		t.Errorf("expected no breach of the job that finished in time, got %+v", b)
+	default:
+	}
+}
+
+func TestSLABackfill(t *testing.T) {
+	breached := false
+	cron := New(WithSLAHook(func(SLABreach) { breached = true }))
+	id, _ := cron.AddFunc("@yearly", func() {}, WithSLA(SLA{StartWithin: time.Minute, FinishWithin: time.Minute}))
+	s, _ := Parse("0 0 * * * *")
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := cron.Backfill(context.Background(), id, NewBackfill(s, from, from.Add(2*time.Hour), BackfillOptions{})); err != nil {
+		t.Fatal(err)
+	}
+	if breached {
+		t.Error("expected backfilled runs not to breach the SLA")
+	}
+}
+
+func TestSLAKindString(t *testing.T) {
+	if SLAStart.String() != "start" || SLAFinish.String() != "finish" || SLAKind(-1).String() != "unknown" {
+		t.Error("unexpected SLAKind strings")
+	}
+}
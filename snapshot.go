@@ -0,0 +1,174 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Snapshot is a serializable record of the entries of a Cron, for handing
+// them over to another process, see Export and Import.
+type Snapshot struct {
+	// Time is when the snapshot was taken.
+	Time time.Time `json:"time"`
+
+	Entries []EntrySnapshot `json:"entries"`
+}
+
+// EntrySnapshot is a serializable record of an entry: its spec, options and
+// progress.  Its job is not recorded, as jobs are code.
+type EntrySnapshot struct {
+	// ID is the entry's ID in the Cron the snapshot was taken of.  Import
+	// assigns new IDs.
+	ID EntryID `json:"id"`
+
+	Name      string            `json:"name,omitempty"`
+	Spec      string            `json:"spec"`
+	Namespace string            `json:"namespace,omitempty"`
+	Paused    bool              `json:"paused,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	LogFields map[string]string `json:"log_fields,omitempty"`
+
+	ValidFrom         time.Time `json:"valid_from,omitempty"`
+	ValidUntil        time.Time `json:"valid_until,omitempty"`
+	RemoveWhenExpired bool      `json:"remove_when_expired,omitempty"`
+	MaxRuns           int       `json:"max_runs,omitempty"`
+	Runs              int       `json:"runs,omitempty"`
+
+	StartAt          time.Time     `json:"start_at,omitempty"`
+	InitialDelay     time.Duration `json:"initial_delay,omitempty"`
+	Jitter           time.Duration `json:"jitter,omitempty"`
+	Flexibility      time.Duration `json:"flexibility,omitempty"`
+	MisfireThreshold time.Duration `json:"misfire_threshold,omitempty"`
+	MisfirePolicy    MisfirePolicy `json:"misfire_policy,omitempty"`
+	SLAStartWithin   time.Duration `json:"sla_start_within,omitempty"`
+	SLAFinishWithin  time.Duration `json:"sla_finish_within,omitempty"`
+	BudgetRuntime    time.Duration `json:"budget_runtime,omitempty"`
+	BudgetWindow     time.Duration `json:"budget_window,omitempty"`
+	IgnoreBlackouts  bool          `json:"ignore_blackouts,omitempty"`
+
+	// Locations are the names of the entry's locations, see WithLocations.
+	Locations []string `json:"locations,omitempty"`
+
+	// Prev and Next are the entry's last and next activation times.
+	Prev time.Time `json:"prev,omitempty"`
+	Next time.Time `json:"next,omitempty"`
+}
+
+// Export returns a snapshot of the entries, ordered by ID, from which Import
+// can reconstruct them on another Cron, as in a warm handoff between
+// processes.  Entries added with Schedule rather than a spec are left out,
+// as their schedules cannot be recorded.
+func (c *Cron) Export() Snapshot {
+	entries := c.Entries()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	s := Snapshot{Time: c.now(), Entries: []EntrySnapshot{}}
+	for _, e := range entries {
+		if e.Spec == "" {
+			continue
+		}
+		s.Entries = append(s.Entries, EntrySnapshot{
+			ID:                e.ID,
+			Name:              e.Name,
+			Spec:              e.Spec,
+			Namespace:         e.Namespace,
+			Paused:            e.Paused,
+			Tags:              e.Tags,
+			Metadata:          e.Metadata,
+			LogFields:         e.LogFields,
+			ValidFrom:         e.ValidFrom,
+			ValidUntil:        e.ValidUntil,
+			RemoveWhenExpired: e.RemoveWhenExpired,
+			MaxRuns:           e.MaxRuns,
+			Runs:              e.Runs,
+			StartAt:           e.StartAt,
+			InitialDelay:      e.InitialDelay,
+			Jitter:            e.Jitter,
+			Flexibility:       e.Flexibility,
+			MisfireThreshold:  e.MisfireThreshold,
+			MisfirePolicy:     e.MisfirePolicy,
+			SLAStartWithin:    e.SLA.StartWithin,
+			SLAFinishWithin:   e.SLA.FinishWithin,
+			BudgetRuntime:     e.Budget.Runtime,
+			BudgetWindow:      e.Budget.Window,
+			IgnoreBlackouts:   e.IgnoreBlackouts,
+			Locations:         locationNames(e.Locations),
+			Prev:              e.Prev,
+			Next:              e.Next,
+		})
+	}
+	return s
+}
+
+// Import adds the entries of the snapshot to the Cron, with the jobs that
+// jobs returns for them, and returns their new IDs in order.  Each entry
+// resumes at its recorded next activation, or at its first activation from
+// then that is not in the past, so that @every intervals keep their phase
+// across the handoff.  Specs are parsed as AddJob parses them.
+//
+// If a spec does not parse or jobs returns an error, Import returns the
+// error and adds none of the entries.
+func (c *Cron) Import(s Snapshot, jobs func(e EntrySnapshot) (Job, error)) ([]EntryID, error) {
+	entries := make([]*Entry, len(s.Entries))
+	for i, es := range s.Entries {
+		schedule, err := c.parse(es.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("cron: entry %d: %v", es.ID, err)
+		}
+		var locations []*time.Location
+		for _, name := range es.Locations {
+			loc, err := time.LoadLocation(name)
+			if err != nil {
+				return nil, fmt.Errorf("cron: entry %d: %v", es.ID, err)
+			}
+			locations = append(locations, loc)
+		}
+		job, err := jobs(es)
+		if err != nil {
+			return nil, fmt.Errorf("cron: entry %d: %v", es.ID, err)
+		}
+		entries[i] = &Entry{
+			Name:              es.Name,
+			Schedule:          schedule,
+			Prev:              es.Prev,
+			Job:               job,
+			Spec:              es.Spec,
+			Paused:            es.Paused,
+			Tags:              append([]string(nil), es.Tags...),
+			Namespace:         es.Namespace,
+			Metadata:          copyMetadata(es.Metadata),
+			LogFields:         copyMetadata(es.LogFields),
+			ValidFrom:         es.ValidFrom,
+			ValidUntil:        es.ValidUntil,
+			RemoveWhenExpired: es.RemoveWhenExpired,
+			MaxRuns:           es.MaxRuns,
+			Runs:              es.Runs,
+			StartAt:           es.StartAt,
+			InitialDelay:      es.InitialDelay,
+			Jitter:            es.Jitter,
+			Flexibility:       es.Flexibility,
+			MisfireThreshold:  es.MisfireThreshold,
+			MisfirePolicy:     es.MisfirePolicy,
+			SLA:               SLA{StartWithin: es.SLAStartWithin, FinishWithin: es.SLAFinishWithin},
+			Budget:            Budget{Runtime: es.BudgetRuntime, Window: es.BudgetWindow},
+			IgnoreBlackouts:   es.IgnoreBlackouts,
+			Locations:         locations,
+			imported:          es.Next,
+		}
+	}
+	ids := make([]EntryID, len(entries))
+	for i, e := range entries {
+		ids[i] = c.schedule(e, nil)
+	}
+	return ids, nil
+}
+
+// locationNames returns the names of the locations.
+func locationNames(locations []*time.Location) []string {
+	var names []string
+	for _, loc := range locations {
+		names = append(names, loc.String())
+	}
+	return names
+}
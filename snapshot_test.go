@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExportImport(t *testing.T) {
+	src := New()
+	src.AddFunc("@every 10m", func() {}, WithName("sync"), WithTags("billing"),
+		WithMetadata("owner", "ops"), WithJitter(time.Second), MaxRuns(5, true),
+		WithSLA(SLA{FinishWithin: time.Minute}))
+	src.AddFunc("0 0 9 * * MON-FRI", func() {}, WithName("report"))
+	src.Schedule(Every(time.Hour), FuncJob(func() {}))
+	src.Pause(2)
+	src.Start()
+	defer src.Stop()
+
+	snapshot := src.Export()
+	if len(snapshot.Entries) != 2 {
+		t.Fatalf("expected the entries with specs, got %+v", snapshot.Entries)
+	}
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Snapshot
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := New()
+	var names []string
+	ids, err := dst.Import(decoded, func(e EntrySnapshot) (Job, error) {
+		names = append(names, e.Name)
+		return FuncJob(func() {}), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || !reflect.DeepEqual(names, []string{"sync", "report"}) {
+		t.Fatalf("unexpected import of %v: %v", names, ids)
+	}
+	dst.Start()
+	defer dst.Stop()
+
+	sync, original := dst.Entry(ids[0]), src.Entry(1)
+	if !sync.Next.Equal(original.Next) {
+		t.Errorf("expected the imported entry to keep its phase, at %v, got %v", original.Next, sync.Next)
+	}
+	if sync.Spec != "@every 10m" || sync.Tags[0] != "billing" || sync.Metadata["owner"] != "ops" ||
+		sync.Jitter != time.Second || sync.RemainingRuns() != 5 || sync.SLA.FinishWithin != time.Minute {
+		t.Errorf("expected the imported entry to keep its options, got %+v", sync)
+	}
+	if report := dst.Entry(ids[1]); !report.Paused || !report.Next.IsZero() {
+		t.Errorf("expected the imported entry to stay paused, got %+v", report)
+	}
+}
+
+func TestImportMissedNext(t *testing.T) {
+	c := New()
+	next := time.Now().Add(-25 * time.Minute).Truncate(time.Second)
+	ids, err := c.Import(Snapshot{Entries: []EntrySnapshot{{Spec: "@every 10m", Next: next}}},
+		func(EntrySnapshot) (Job, error) { return FuncJob(func() {}), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Start()
+	defer c.Stop()
+	if e := c.Entry(ids[0]); !e.Next.Equal(next.Add(30 * time.Minute)) {
+		t.Errorf("expected the first activation in phase after now, %v, got %v", next.Add(30*time.Minute), e.Next)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	c := New()
+	job := func(e EntrySnapshot) (Job, error) {
+		if e.Name == "unknown" {
+			return nil, errors.New("no such job")
+		}
+		return FuncJob(func() {}), nil
+	}
+	for _, s := range []Snapshot{
+		{Entries: []EntrySnapshot{{Spec: "@hourly"}, {Spec: "bogus"}}},
+		{Entries: []EntrySnapshot{{Spec: "@hourly"}, {Spec: "@daily", Name: "unknown"}}},
+	} {
+		if _, err := c.Import(s, job); err == nil {
+			t.Errorf("expected an error importing %+v", s)
+		}
+	}
+	if len(c.Entries()) != 0 {
+		t.Error("expected a failed import to add no entries")
+	}
+}
+
+func TestExportImportLocations(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	src := New()
+	src.AddFunc("0 0 9 * * *", func() {}, WithLocations(tokyo, time.UTC))
+	dst := New()
+	ids, err := dst.Import(src.Export(), func(EntrySnapshot) (Job, error) { return FuncJob(func() {}), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := dst.Entry(ids[0]); len(e.Locations) != 2 || e.Locations[0].String() != "Asia/Tokyo" || e.Locations[1] != time.UTC {
+		t.Errorf("expected the imported entry to keep its locations, got %v", e.Locations)
+	}
+	_, err = dst.Import(Snapshot{Entries: []EntrySnapshot{{Spec: "@daily", Locations: []string{"Nowhere/Special"}}}},
+		func(EntrySnapshot) (Job, error) { return FuncJob(func() {}), nil })
+	if err == nil {
+		t.Error("expected an error importing an unknown location")
+	}
+}
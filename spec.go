@@ -1,9 +1,16 @@
 package cron
 
-import "time"
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"time"
+)
 
 // SpecSchedule specifies a duty cycle (to the second granularity), based on a
 // traditional crontab specification. It is computed initially and stored as bit sets.
+// It has no time zone of its own: Next interprets it in the location of the
+// time it is given, see Floating.
 type SpecSchedule struct {
 	Second, Minute, Hour, Dom, Month, Dow uint64
 }
@@ -50,9 +57,53 @@ const (
 	starBit = 1 << 63
 )
 
+// String returns the schedule as a six-field spec, which Parse parses to an
+// equivalent schedule.
+func (s *SpecSchedule) String() string {
+	return strings.Join([]string{
+		formatSpecField(s.Second, seconds),
+		formatSpecField(s.Minute, minutes),
+		formatSpecField(s.Hour, hours),
+		formatSpecField(s.Dom, dom),
+		formatSpecField(s.Month, months),
+		formatSpecField(s.Dow, dow),
+	}, " ")
+}
+
+// formatSpecField renders a field's bit set in the syntax of Parse.  The
+// star bit, which decides how the day fields combine, is kept: fields with
+// it are rendered with a star, and fields without it are rendered as values
+// and ranges even if they hold every value.
+func formatSpecField(bits uint64, r bounds) string {
+	values := bits &^ starBit
+	if bits&starBit == 0 {
+		if isAll(values, r) {
+			return fmt.Sprintf("%d-%d", r.min, r.max)
+		}
+		return formatField(values, r, 0)
+	}
+	for step := uint(1); step <= r.max-r.min; step++ {
+		if values == getBits(r.min, r.max, step) {
+			if step == 1 {
+				return "*"
+			}
+			return fmt.Sprintf("*/%d", step)
+		}
+	}
+	// A star with a step beyond the bounds sets the minimum and the star
+	// bit alone.
+	return formatField(values, r, 0) + fmt.Sprintf(",*/%d", r.max-r.min+1)
+}
+
 // Next returns the next time this schedule is activated, greater than the given
 // time.  If no time can be found to satisfy the schedule, return the zero time.
 func (s *SpecSchedule) Next(t time.Time) time.Time {
+	return s.next(t, nil)
+}
+
+// next returns the next activation of the schedule greater than the given
+// time for which the predicate, if not nil, returns true.
+func (s *SpecSchedule) next(t time.Time, predicate func(time.Time) bool) time.Time {
 	// General approach:
 	// For Month, Day, Hour, Minute, Second:
 	// Check if the time value matches.  If yes, continue to the next field.
@@ -75,34 +126,19 @@ WRAP:
 		return time.Time{}
 	}
 
-	// Find the first applicable month.
-	// If it's this month, then do nothing.
-	for 1<<uint(t.Month())&s.Month == 0 {
-		// If we have to add a month, reset the other parts to 0.
-		if !added {
-			added = true
-			// Otherwise, set the date at the beginning (since the current time is irrelevant).
-			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
-		}
-		t = t.AddDate(0, 1, 0)
-
-		// Wrapped around.
-		if t.Month() == time.January {
-			goto WRAP
-		}
+	// Find the first applicable day, in the first applicable month.
+	// If it's today, then do nothing.
+	// Otherwise, set the time to the beginning of that day (since the current
+	// time is irrelevant).  The search steps through the month and day bits
+	// without constructing a time for each day.
+	year, month, day := t.Date()
+	y, m, d := s.nextDate(year, month, day, t.Weekday(), yearLimit)
+	if y == 0 {
+		return time.Time{}
 	}
-
-	// Now get a day in that month.
-	for !dayMatches(s, t) {
-		if !added {
-			added = true
-			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
-		}
-		t = t.AddDate(0, 0, 1)
-
-		if t.Day() == 1 {
-			goto WRAP
-		}
+	if y != year || m != month || d != day {
+		added = true
+		t = time.Date(y, m, d, 0, 0, 0, 0, t.Location())
 	}
 
 	for 1<<uint(t.Hour())&s.Hour == 0 {
@@ -110,7 +146,7 @@ WRAP:
 			added = true
 			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
 		}
-		t = t.Add(1 * time.Hour)
+		t = advance(t, s.Hour, hours, time.Hour, time.Time.Hour)
 
 		if t.Hour() == 0 {
 			goto WRAP
@@ -122,7 +158,7 @@ WRAP:
 			added = true
 			t = t.Truncate(time.Minute)
 		}
-		t = t.Add(1 * time.Minute)
+		t = advance(t, s.Minute, minutes, time.Minute, time.Time.Minute)
 
 		if t.Minute() == 0 {
 			goto WRAP
@@ -134,26 +170,131 @@ WRAP:
 			added = true
 			t = t.Truncate(time.Second)
 		}
-		t = t.Add(1 * time.Second)
+		t = advance(t, s.Second, seconds, time.Second, time.Time.Second)
 
 		if t.Second() == 0 {
 			goto WRAP
 		}
 	}
 
+	// Resume the search from the next second if the predicate rejects the
+	// activation.  Any field the step carries into was already matched, or
+	// is left at its start, as the loops above expect.
+	if predicate != nil && !predicate(t) {
+		added = true
+		t = t.Add(time.Second)
+		goto WRAP
+	}
+
 	return t
 }
 
-// dayMatches returns true if the schedule's day-of-week and day-of-month
-// restrictions are satisfied by the given time.
-func dayMatches(s *SpecSchedule, t time.Time) bool {
-	var (
-		domMatch bool = 1<<uint(t.Day())&s.Dom > 0
-		dowMatch bool = 1<<uint(t.Weekday())&s.Dow > 0
-	)
+// nextDate returns the first date from the given one, whose day of the
+// week is weekday, that satisfies the schedule's month and day restrictions,
+// or a zero year if there is none up to the end of yearLimit.
+func (s *SpecSchedule) nextDate(year int, month time.Month, day int, weekday time.Weekday, yearLimit int) (int, time.Month, int) {
+	// The weekday of the first of the month.
+	first := (uint(weekday) + 7 - uint(day-1)%7) % 7
+	for year <= yearLimit {
+		last := daysIn(month, year)
+		if 1<<uint(month)&s.Month > 0 {
+			if next := nextBit(s.dayMask(first), uint(day-1), uint(last)); next > 0 {
+				return year, month, int(next)
+			}
+		}
+		first = (first + uint(last)) % 7
+		day = 1
+		if month++; month > time.December {
+			month = time.January
+			year++
+		}
+	}
+	return 0, 0, 0
+}
 
+// dayMask returns the days of a month whose first falls on the given weekday
+// that satisfy the schedule's day-of-week and day-of-month restrictions, as
+// bits 1 to 31.
+func (s *SpecSchedule) dayMask(first uint) uint64 {
+	weekdays := weekdayDays[first][s.Dow&weekdayBits]
 	if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
-		return domMatch && dowMatch
+		return s.Dom & weekdays
+	}
+	return s.Dom | weekdays
+}
+
+// restrictsDays reports whether the day of month and the day of week
+// restrict the days the schedule activates on, and whether the days are
+// those both allow rather than either.  As in dayMask, if either field is
+// starred the days are those both allow, so a field allowing every day
+// leaves them to the other.  Otherwise they are those either allows, so a
+// field allowing every day allows every day.
+func (s *SpecSchedule) restrictsDays() (byDom, byDow, both bool) {
+	byDom, byDow = !isAll(s.Dom, dom), !isAll(s.Dow, dow)
+	both = s.Dom&starBit > 0 || s.Dow&starBit > 0
+	if !both && (!byDom || !byDow) {
+		return false, false, false
+	}
+	return byDom, byDow, both
+}
+
+// weekdayBits are the bits of the days of the week.
+const weekdayBits = 1<<7 - 1
+
+// weekdayDays is a table of the days of a month, as bits 1 to 31, that fall
+// on a set of weekdays, given as bits 0 to 6, for each weekday the month may
+// start on.
+var weekdayDays = func() (table [7][weekdayBits + 1]uint64) {
+	for first := range table {
+		for weekdays := range table[first] {
+			for day := uint(1); day <= 31; day++ {
+				if 1<<((uint(first)+day-1)%7)&weekdays > 0 {
+					table[first][weekdays] |= 1 << day
+				}
+			}
+		}
+	}
+	return table
+}()
+
+// daysIn returns the number of days in the month of the year.
+func daysIn(month time.Month, year int) int {
+	switch month {
+	case time.February:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+	return 31
+}
+
+// nextBit returns the lowest value after v and up to max set in set, or 0
+// if there is none.
+func nextBit(set uint64, v, max uint) uint {
+	after := set &^ (1<<(v+1) - 1) & (1<<(max+1) - 1)
+	if after == 0 {
+		return 0
+	}
+	return uint(bits.TrailingZeros64(after))
+}
+
+// advance moves t forward, a whole number of units, to the next value of the
+// field in set, or to its value 0 if there is none.  If a change of
+// offset in t's zone means that adding those units does not reach the
+// value, it moves t one unit only, so that the field's values are stepped
+// through as the wall clock shows them.
+func advance(t time.Time, set uint64, r bounds, unit time.Duration, field func(time.Time) int) time.Time {
+	v := uint(field(t))
+	want := nextBit(set, v, r.max)
+	until := want - v
+	if want == 0 {
+		until = r.max + 1 - v
+	}
+	if next := t.Add(time.Duration(until) * unit); uint(field(next)) == want {
+		return next
 	}
-	return domMatch || dowMatch
+	return t.Add(unit)
 }
@@ -147,6 +147,17 @@ func TestNext(t *testing.T) {
 		{"2012-11-04T00:00:00-0400", "0 0 3 * * ?", "2012-11-04T03:00:00-0500"},
 		{"2012-11-04T03:00:00-0500", "0 0 3 * * ?", "2012-11-05T03:00:00-0500"},
 
+		// Sparse
+		{"Mon Jul 9 23:35 2012", "0 0 0 29 Feb ?", "Thu Feb 29 00:00 2016"},
+		{"Wed Feb 29 00:00 2012", "0 0 0 29 Feb ?", "Mon Feb 29 00:00 2016"},
+		{"Mon Jul 9 23:35 2012", "59 59 23 31 Dec ?", "Mon Dec 31 23:59:59 2012"},
+		{"Mon Dec 31 23:59:59 2012", "59 59 23 31 Dec ?", "Tue Dec 31 23:59:59 2013"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 31 * ?", "Tue Jul 31 00:00 2012"},
+		{"Tue Jul 31 00:00 2012", "0 0 0 31 * ?", "Fri Aug 31 00:00 2012"},
+		{"Fri Aug 31 00:00 2012", "0 0 0 31 * ?", "Wed Oct 31 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 ? Dec Sun", "Sun Dec 2 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 13 * Fri", "Fri Jul 13 00:00 2012"},
+
 		// Unsatisfiable
 		{"Mon Jul 9 23:35 2012", "0 0 0 30 Feb ?", ""},
 		{"Mon Jul 9 23:35 2012", "0 0 0 31 Apr ?", ""},
@@ -247,3 +258,134 @@ func getTimeTZ(value string) time.Time {
 
 	return t
 }
+
+// The sparse specs Next is benchmarked with.  Without a week-of-year field,
+// the sparsest specs are those activating once per year, or once per leap
+// year, and those never activating, for which Next searches five years.
+var sparseSpecs = map[string]string{
+	"LeapDay":       "0 0 0 29 Feb ?",
+	"OncePerYear":   "59 59 23 31 Dec ?",
+	"SundayInDec":   "0 0 0 ? Dec Sun",
+	"FridayOr13th":  "0 0 0 13 * Fri",
+	"Unsatisfiable": "0 0 0 30 Feb ?",
+	"EverySecond":   "* * * * * ?",
+}
+
+func BenchmarkNextSparse(b *testing.B) {
+	start := time.Date(2012, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for name, spec := range sparseSpecs {
+		schedule, err := Parse(spec)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				schedule.Next(start)
+			}
+		})
+	}
+}
+
+// In zones whose clocks changed at midnight, such as Brazil's until 2019,
+// the days after a missing midnight must still be searched from their
+// beginning.
+func TestNextMidnightDST(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, c := range []struct{ spec, time, expected string }{
+		{"46 * * 29 * *", "2016-10-04T03:27:07", "2016-10-29T00:00:46"},
+		{"0 0 8 14 Nov *", "2018-09-26T07:55:11", "2018-11-14T08:00:00"},
+	} {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		from, _ := time.ParseInLocation("2006-01-02T15:04:05", c.time, saoPaulo)
+		expected, _ := time.ParseInLocation("2006-01-02T15:04:05", c.expected, saoPaulo)
+		if actual := sched.Next(from); !actual.Equal(expected) {
+			t.Errorf("%s from %v: expected %v, got %v", c.spec, from, expected, actual)
+		}
+	}
+}
+
+func FuzzNext(f *testing.F) {
+	for _, spec := range append(benchmarkSpecs, "0 0 0 29 Feb ?", "0 0 0 30 Feb ?", "@every 90s", "@yearly") {
+		f.Add(spec, int64(1341878100))
+	}
+	f.Add("* * * * * *", int64(0))
+	f.Add("59 59 23 31 Dec *", int64(4102444799))
+	f.Fuzz(func(t *testing.T, spec string, unix int64) {
+		schedule, err := Parse(spec)
+		if err != nil {
+			return
+		}
+		// Keep to the years between 1970 and 2200.
+		if unix < 0 || unix > 7258118400 {
+			unix = int64(uint64(unix) % 7258118400)
+		}
+		from := time.Unix(unix, 0).UTC()
+		next := schedule.Next(from)
+		if next.IsZero() {
+			return
+		}
+		if !next.After(from) {
+			t.Fatalf("%q: expected the activation after %v to follow it, got %v", spec, from, next)
+		}
+		if again := schedule.Next(from); !again.Equal(next) {
+			t.Fatalf("%q: expected the activation after %v to be %v every time, got %v", spec, from, next, again)
+		}
+		// No activation lies between from and next, so looking from just
+		// before next must find next.
+		if _, ok := schedule.(*SpecSchedule); ok {
+			if again := schedule.Next(next.Add(-time.Second)); !again.Equal(next) {
+				t.Fatalf("%q: expected the activation after %v to be %v, got %v", spec, next.Add(-time.Second), next, again)
+			}
+		}
+	})
+}
+
+func TestSpecScheduleString(t *testing.T) {
+	for spec, expected := range map[string]string{
+		"0 30 9 * * MON-FRI":      "0 30 9 * * 1-5",
+		"*/15 * * * * ?":          "*/15 * * * * *",
+		"0 0 0 1-31 * MON":        "0 0 0 1-31 * 1",
+		"0 0 0 */2 JAN,JUL,DEC *": "0 0 0 */2 1,7,12 *",
+		"*/60 0 0 * * *":          "0,*/60 0 0 * * *",
+		"@weekly":                 "0 0 0 * * 0",
+	} {
+		schedule, err := Parse(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := schedule.(*SpecSchedule)
+		if s.String() != expected {
+			t.Errorf("%s: expected %q, got %q", spec, expected, s.String())
+		}
+		if parsed, err := Parse(s.String()); err != nil || *parsed.(*SpecSchedule) != *s {
+			t.Errorf("%s: expected %q to parse to the same schedule, got %v, %v", spec, s.String(), parsed, err)
+		}
+	}
+}
+
+func TestDayMask(t *testing.T) {
+	for _, spec := range []string{"0 0 0 13 * FRI", "0 0 0 ? * MON,WED", "0 0 0 */3 * *", "0 0 0 1-7 * SUN", "0 0 0 * * *"} {
+		schedule, _ := Parse(spec)
+		s := schedule.(*SpecSchedule)
+		for m := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); m.Year() < 2026; m = m.AddDate(0, 1, 0) {
+			mask := s.dayMask(uint(m.Weekday()))
+			for d := m; d.Month() == m.Month(); d = d.AddDate(0, 0, 1) {
+				domMatch := 1<<uint(d.Day())&s.Dom > 0
+				dowMatch := 1<<uint(d.Weekday())&s.Dow > 0
+				expected := domMatch || dowMatch
+				if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
+					expected = domMatch && dowMatch
+				}
+				if actual := 1<<uint(d.Day())&mask > 0; actual != expected {
+					t.Errorf("%s on %s: expected %v, got %v", spec, d.Format("2006-01-02"), expected, actual)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"hash/fnv"
+	"strconv"
+	"time"
+)
+
+// WithSplay returns an Option that shifts the activations of every entry by
+// a fixed offset in [0, window), derived from a hash of the entry's name (or
+// of its ID, for unnamed entries).  Entries sharing a spec are thereby spread
+// across the window rather than activated together, without editing their
+// specs.  Since the offset is deterministic, a named entry keeps the same
+// offset across processes.
+func WithSplay(window time.Duration) Option {
+	return func(c *Cron) {
+		c.splay = window
+	}
+}
+
+// splayOffset returns the offset by which the entry's activations are
+// shifted.
+func (c *Cron) splayOffset(e *Entry) time.Duration {
+	if c.splay <= 0 {
+		return 0
+	}
+	key := e.Name
+	if key == "" {
+		key = strconv.Itoa(int(e.ID))
+	}
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return time.Duration(h.Sum64() % uint64(c.splay))
+}
+
+// next returns the entry's first activation after the given time, shifted by
+// its splay offset and moved to the start of its tick, see WithResolution.
+func (c *Cron) next(e *Entry, t time.Time) time.Time {
+	return c.nextOf(e, e.schedule(), t)
+}
+
+// nextOf is next, with the entry activated on the given schedule.
+func (c *Cron) nextOf(e *Entry, schedule Schedule, t time.Time) time.Time {
+	offset := c.splayOffset(e)
+	next := schedule.Next(c.tick(t).Add(-offset))
+	if next.IsZero() {
+		return next
+	}
+	next = next.Add(offset)
+	if c.resolution > 0 {
+		next = next.Truncate(c.resolution)
+	}
+	return next
+}
@@ -0,0 +1,41 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplayOffset(t *testing.T) {
+	cron := New(WithSplay(time.Minute))
+	a := &Entry{ID: 1, Name: "a"}
+	if offset := cron.splayOffset(a); offset < 0 || offset >= time.Minute {
+		t.Errorf("offset %v out of range", offset)
+	}
+	if cron.splayOffset(a) != cron.splayOffset(&Entry{ID: 2, Name: "a"}) {
+		t.Error("expected the offset to depend only on the name")
+	}
+	if cron.splayOffset(&Entry{ID: 1}) == cron.splayOffset(&Entry{ID: 2}) {
+		t.Error("expected unnamed entries to be spread by ID")
+	}
+	if offset := New().splayOffset(a); offset != 0 {
+		t.Errorf("expected no offset without splay, got %v", offset)
+	}
+}
+
+func TestSplayShiftsActivations(t *testing.T) {
+	cron := New(WithSplay(time.Hour))
+	cron.Start()
+	defer cron.Stop()
+
+	now := time.Now()
+	id, _ := cron.AddFunc("0 0 * * * ?", func() {}, WithName("report"))
+	entry := cron.Entry(id)
+	offset := cron.splayOffset(entry)
+
+	if entry.Next.Sub(now) > time.Hour || entry.Next.Before(now) {
+		t.Errorf("expected next activation within the hour, got %v", entry.Next)
+	}
+	if got := entry.Next.Add(-offset); got.Minute() != 0 || got.Second() != 0 {
+		t.Errorf("expected activation %v to be the top of the hour shifted by %v", entry.Next, offset)
+	}
+}
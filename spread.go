@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// Spread returns the schedule for a spec in the syntax of Parse in which the
+// value "H" in a field stands for one value of the field chosen by hashing
+// the key, as in Jenkins.  Schedules of different keys are thereby spread
+// across the window the spec leaves open, and that of a key stays the same
+// without storing it: "0 H * * * *" activates once an hour, at a minute of
+// the hour that depends on the key.
+//
+// "H" may be narrowed to a range, as in "H(0-29)", and stepped, as in "H/15",
+// which activates every 15 minutes from an offset below 15 chosen by the key,
+// or both.  In the day of month, "H" chooses among days 1 to 28, which every
+// month has.  The choice in each field is independent of the others.
+func Spread(key, windowSpec string) (Schedule, error) {
+	if strings.HasPrefix(windowSpec, "@") {
+		return Parse(windowSpec)
+	}
+	var fields [6]string
+	n := splitFields(windowSpec, &fields)
+	if n != 5 && n != 6 {
+		return nil, specErrorf(ErrFieldCount, "Expected 5 or 6 fields, found %d: %s", n, windowSpec)
+	}
+	fieldBounds := [6]bounds{seconds, minutes, hours, {1, 28, nil}, months, dow}
+	for i := 0; i < n; i++ {
+		items := strings.Split(fields[i], ",")
+		for j, item := range items {
+			if !strings.HasPrefix(item, "H") {
+				continue
+			}
+			var err error
+			if items[j], err = spreadItem(item, fieldBounds[i], spreadHash(key, i)); err != nil {
+				return nil, err
+			}
+		}
+		fields[i] = strings.Join(items, ",")
+	}
+	return Parse(strings.Join(fields[:n], " "))
+}
+
+// spreadItem returns the expression, in the syntax of Parse, for an item of
+// a field starting with "H", choosing its value or offset with hash.
+func spreadItem(item string, r bounds, hash uint64) (string, error) {
+	expr := item[1:]
+	if strings.HasPrefix(expr, "(") {
+		end := strings.IndexByte(expr, ')')
+		if end < 0 {
+			return "", specErrorf(ErrSyntax, "Missing ) in %s", item)
+		}
+		lowAndHigh := strings.Split(expr[1:end], "-")
+		if len(lowAndHigh) != 2 {
+			return "", specErrorf(ErrSyntax, "Expected a range in %s", item)
+		}
+		low, err := mustParseInt(lowAndHigh[0])
+		if err != nil {
+			return "", err
+		}
+		high, err := mustParseInt(lowAndHigh[1])
+		if err != nil {
+			return "", err
+		}
+		if low < r.min || high > r.max || low > high {
+			return "", specErrorf(ErrValueOutOfRange, "Range out of bounds (%d-%d): %s", r.min, r.max, item)
+		}
+		r.min, r.max = low, high
+		expr = expr[end+1:]
+	}
+	switch {
+	case expr == "":
+		return strconv.Itoa(int(r.min + uint(hash%uint64(r.max-r.min+1)))), nil
+	case strings.HasPrefix(expr, "/"):
+		step, err := mustParseInt(expr[1:])
+		if err != nil || step == 0 {
+			return "", specErrorf(ErrBadStep, "Step of range should be a positive number: %s", item)
+		}
+		offset := uint(hash % uint64(step))
+		if r.min+offset > r.max {
+			offset = 0
+		}
+		return strconv.Itoa(int(r.min+offset)) + "-" + strconv.Itoa(int(r.max)) + expr, nil
+	}
+	return "", specErrorf(ErrSyntax, "Unexpected %q after H: %s", expr, item)
+}
+
+// spreadHash returns the hash of the key for the field at index i.
+func spreadHash(key string, i int) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{0, byte(i)})
+	return h.Sum64()
+}
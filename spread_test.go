@@ -0,0 +1,111 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSpread(t *testing.T) {
+	chosen := make(map[uint64]bool)
+	for _, key := range []string{"acme", "globex", "initech", "umbrella", "hooli", "stark"} {
+		s, err := Spread(key, "0 H * * * *")
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, _ := Spread(key, "0 H * * * *")
+		spec, againSpec := s.(*SpecSchedule), again.(*SpecSchedule)
+		if *spec != *againSpec {
+			t.Errorf("%s: expected the same schedule, got %v and %v", key, spec, againSpec)
+		}
+		if n := len(values(spec.Minute, minutes)); n != 1 {
+			t.Errorf("%s: expected one minute, got %d", key, n)
+		}
+		if spec.Second != 1<<0 || spec.Hour != all(hours) {
+			t.Errorf("%s: expected the other fields to be kept, got %v", key, spec)
+		}
+		chosen[spec.Minute] = true
+	}
+	if len(chosen) < 3 {
+		t.Errorf("expected the keys to be spread over the hour, got %d minutes", len(chosen))
+	}
+}
+
+func TestSpreadForms(t *testing.T) {
+	for _, spec := range []string{
+		"H(10-19) 0 0 * * *",
+		"0 H/15 * * * *",
+		"0 H(30-59)/10 * * * *",
+		"0 0 H H * *",
+		"0 0 0 * * H,SUN",
+		"H H H H H",
+	} {
+		for _, key := range []string{"a", "b", "c", "d"} {
+			s, err := Spread(key, spec)
+			if err != nil {
+				t.Fatalf("%s: %v", spec, err)
+			}
+			if err := Validate(s); err != nil {
+				t.Errorf("%s, %s: %v", spec, key, err)
+			}
+			sched := s.(*SpecSchedule)
+			switch spec {
+			case "H(10-19) 0 0 * * *":
+				v := values(sched.Second, seconds)
+				if len(v) != 1 || v[0] < 10 || v[0] > 19 {
+					t.Errorf("%s, %s: got seconds %v", spec, key, v)
+				}
+			case "0 H/15 * * * *":
+				v := values(sched.Minute, minutes)
+				if len(v) != 4 || v[0] >= 15 || v[1] != v[0]+15 {
+					t.Errorf("%s, %s: got minutes %v", spec, key, v)
+				}
+			case "0 H(30-59)/10 * * * *":
+				v := values(sched.Minute, minutes)
+				if len(v) != 3 || v[0] < 30 || v[0] >= 40 {
+					t.Errorf("%s, %s: got minutes %v", spec, key, v)
+				}
+			case "0 0 H H * *":
+				v := values(sched.Dom, dom)
+				if len(v) != 1 || v[0] > 28 {
+					t.Errorf("%s, %s: got days %v", spec, key, v)
+				}
+			case "0 0 0 * * H,SUN":
+				if sched.Dow&(1<<time.Sunday) == 0 {
+					t.Errorf("%s, %s: expected Sunday to be kept, got %v", spec, key, sched)
+				}
+			}
+		}
+	}
+}
+
+func TestSpreadErrors(t *testing.T) {
+	for _, c := range []struct {
+		spec string
+		kind error
+	}{
+		{"0 H * *", ErrFieldCount},
+		{"0 H(0-60) * * * *", ErrValueOutOfRange},
+		{"0 H(20-10) * * * *", ErrValueOutOfRange},
+		{"0 H(0-10 * * * *", ErrSyntax},
+		{"0 H(10) * * * *", ErrSyntax},
+		{"0 H/0 * * * *", ErrBadStep},
+		{"0 Hx * * * *", ErrSyntax},
+		{"0 H 0 32 * *", ErrValueOutOfRange},
+	} {
+		if _, err := Spread("key", c.spec); !errors.Is(err, c.kind) {
+			t.Errorf("%s: expected %v, got %v", c.spec, c.kind, err)
+		}
+	}
+}
+
+func TestSpreadDescriptor(t *testing.T) {
+	s, err := Spread("key", "@hourly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := getTime("Mon Jan 1 01:00 2024")
+	if next := s.Next(getTime("Mon Jan 1 00:30 2024")); !next.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+}
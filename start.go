@@ -0,0 +1,34 @@
+package cron
+
+import "time"
+
+// StartAt returns an EntryOption that makes the entry's first activation the
+// first one of its schedule at or after t, rather than after the time the
+// entry is added or the Cron is started.
+func StartAt(t time.Time) EntryOption {
+	return func(e *Entry) {
+		e.StartAt = t
+	}
+}
+
+// InitialDelay returns an EntryOption that makes the entry's first activation
+// the first one of its schedule at least d after the entry is first
+// scheduled, that is, when it is added to a running Cron or the Cron is
+// started.  This avoids a burst of activations right after a deploy.
+func InitialDelay(d time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.InitialDelay = d
+	}
+}
+
+// start returns the earliest time the entry's first activation may occur, if
+// it is first scheduled at now.
+func (e *Entry) start(now time.Time) time.Time {
+	start := e.StartAt
+	if e.InitialDelay > 0 {
+		if delayed := now.Add(e.InitialDelay); delayed.After(start) {
+			start = delayed
+		}
+	}
+	return start
+}
@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartAt(t *testing.T) {
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	cron := New()
+	id, _ := cron.AddFunc("* * * * * ?", func() {}, StartAt(start))
+	cron.Start()
+	defer cron.Stop()
+
+	if next := cron.Entry(id).Next; !next.Equal(start) {
+		t.Errorf("expected first activation at %v, got %v", start, next)
+	}
+}
+
+func TestStartAtInPast(t *testing.T) {
+	cron := New()
+	id, _ := cron.AddFunc("* * * * * ?", func() {}, StartAt(time.Now().Add(-time.Hour)))
+	cron.Start()
+	defer cron.Stop()
+
+	if next := cron.Entry(id).Next; next.After(time.Now().Add(time.Second)) {
+		t.Errorf("expected first activation within a second, got %v", next)
+	}
+}
+
+func TestInitialDelay(t *testing.T) {
+	cron := New()
+	cron.Start()
+	defer cron.Stop()
+	before := time.Now()
+	id, _ := cron.AddFunc("* * * * * ?", func() {}, InitialDelay(time.Minute))
+
+	next := cron.Entry(id).Next
+	if next.Before(before.Add(time.Minute)) || next.After(time.Now().Add(time.Minute+time.Second)) {
+		t.Errorf("expected first activation a minute from now, got %v", next)
+	}
+}
@@ -0,0 +1,89 @@
+package cron
+
+import "time"
+
+// statsWindow is the number of an entry's latest runs its RunStats average
+// over.
+const statsWindow = 100
+
+// RunStats summarizes the runs of an entry since it was added, see
+// Entry.Stats.  Skipped runs are not counted.
+type RunStats struct {
+	// Runs counts the runs that finished, and Failures those of them that
+	// failed or panicked.
+	Runs, Failures int
+
+	// AvgDuration and MaxDuration are the mean and the longest time taken
+	// by the latest runs, up to 100 of them.
+	AvgDuration, MaxDuration time.Duration
+
+	// AvgDelay is the mean time by which the latest runs, up to 100 of
+	// them, started after they were scheduled, as delayed by jitter, rate
+	// limits and a busy worker pool.
+	AvgDelay time.Duration
+}
+
+// FailureRate returns the fraction of the runs that failed, or 0 if there
+// were none.
+func (s RunStats) FailureRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Failures) / float64(s.Runs)
+}
+
+// entryStats keeps the state behind an entry's RunStats: its counts, and the
+// durations and delays of its latest runs in rings.
+type entryStats struct {
+	runs, failures int
+	durations      [statsWindow]time.Duration
+	delays         [statsWindow]time.Duration
+}
+
+// add counts the finished run.
+func (s *entryStats) add(run Run) {
+	i := s.runs % statsWindow
+	s.durations[i] = run.End.Sub(run.Start)
+	s.delays[i] = run.Start.Sub(run.Scheduled)
+	s.runs++
+	if run.Outcome == OutcomeFailure || run.Outcome == OutcomePanic {
+		s.failures++
+	}
+}
+
+// stats returns the RunStats of the runs counted.
+func (s *entryStats) stats() RunStats {
+	stats := RunStats{Runs: s.runs, Failures: s.failures}
+	n := s.runs
+	if n > statsWindow {
+		n = statsWindow
+	}
+	if n == 0 {
+		return stats
+	}
+	var duration, delay time.Duration
+	for i := 0; i < n; i++ {
+		duration += s.durations[i]
+		delay += s.delays[i]
+		if s.durations[i] > stats.MaxDuration {
+			stats.MaxDuration = s.durations[i]
+		}
+	}
+	stats.AvgDuration = duration / time.Duration(n)
+	stats.AvgDelay = delay / time.Duration(n)
+	return stats
+}
+
+// withStats sets the Stats of the entries, which are snapshots.
+func (c *Cron) withStats(entries ...*Entry) {
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+	for _, e := range entries {
+		if e == nil {
+			continue
+		}
+		if h := c.health.entries[e.ID]; h != nil {
+			e.Stats = h.stats.stats()
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestEntryStats(t *testing.T) {
+	var s entryStats
+	if stats := s.stats(); stats != (RunStats{}) {
+		t.Errorf("expected no stats, got %+v", stats)
+	}
+	scheduled := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 3; i++ {
+		start := scheduled.Add(time.Duration(i) * time.Second)
+		outcome := OutcomeSuccess
+		if i == 2 {
+			outcome = OutcomePanic
+		}
+		s.add(Run{Scheduled: scheduled, Start: start, End: start.Add(time.Duration(i) * time.Minute), Outcome: outcome})
+	}
+	expected := RunStats{Runs: 3, Failures: 1, AvgDuration: 2 * time.Minute, MaxDuration: 3 * time.Minute, AvgDelay: 2 * time.Second}
+	if stats := s.stats(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+
+	// Only the latest runs are averaged.
+	for i := 0; i < statsWindow; i++ {
+		s.add(Run{Scheduled: scheduled, Start: scheduled, End: scheduled.Add(time.Second)})
+	}
+	expected = RunStats{Runs: 3 + statsWindow, Failures: 1, AvgDuration: time.Second, MaxDuration: time.Second}
+	if stats := s.stats(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestRunStatsFailureRate(t *testing.T) {
+	if r := (RunStats{}).FailureRate(); r != 0 {
+		t.Errorf("expected 0, got %v", r)
+	}
+	if r := (RunStats{Runs: 4, Failures: 1}).FailureRate(); r != 0.25 {
+		t.Errorf("expected 0.25, got %v", r)
+	}
+}
+
+func TestEntriesStats(t *testing.T) {
+	cron := New(WithExecutor(ExecutorFunc(func(ctx context.Context, e *Entry, run Run) error {
+		time.Sleep(10 * time.Millisecond)
+		return errors.New("failed")
+	})))
+	cron.ErrorLog = log.New(ioutil.Discard, "", 0)
+	id, _ := cron.AddFunc("@yearly", func() {})
+	other, _ := cron.AddFunc("@yearly", func() {})
+	cron.Trigger(id)
+	cron.Trigger(id)
+	<-cron.Stop().Done()
+
+	stats := cron.Entry(id).Stats
+	if stats.Runs != 2 || stats.Failures != 2 || stats.AvgDuration < 10*time.Millisecond || stats.MaxDuration < stats.AvgDuration {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	for _, e := range cron.Entries() {
+		if e.ID == id && e.Stats != stats {
+			t.Errorf("expected Entries to have the stats %+v, got %+v", stats, e.Stats)
+		}
+		if e.ID == other && e.Stats != (RunStats{}) {
+			t.Errorf("expected no stats for an entry that did not run, got %+v", e.Stats)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RunKey identifies a single activation of an entry. It is derived only from
+// the entry's ID and the activation's scheduled time, so the same activation
+// always produces the same key and downstream systems may use it to dedupe.
+type RunKey string
+
+// NewRunKey returns the RunKey for the activation of the given entry at the
+// given scheduled time.
+func NewRunKey(id EntryID, scheduled time.Time) RunKey {
+	return RunKey(fmt.Sprintf("%d@%s", id, scheduled.UTC().Format(time.RFC3339Nano)))
+}
+
+// Store persists the activations dispatched by a Cron.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Claim records that the activation identified by key has been executed.
+	// It returns false if the activation was already recorded, in which case
+	// the Cron does not dispatch it again.
+	Claim(key RunKey) (bool, error)
+
+	// Record adds a finished run to the history of its entry.
+	Record(run Run) error
+
+	// History returns the runs of the given entry scheduled at or after since,
+	// most recent first.  If limit is positive, at most limit runs are returned.
+	History(id EntryID, since time.Time, limit int) ([]Run, error)
+}
+
+const (
+	// DefaultClaimRetention is how long a MemoryStore keeps its claims by
+	// default, see WithClaimRetention.
+	DefaultClaimRetention = 24 * time.Hour
+
+	// DefaultHistoryLimit is how many runs of each entry a MemoryStore
+	// keeps by default, see WithHistoryLimit.
+	DefaultHistoryLimit = 1000
+)
+
+// Forgetter may be implemented by a Store to drop the records of removed
+// entries.  When the configured Store implements it, Forget is called with
+// the ID of each entry removed from the Cron.  IDs are never reused.
+type Forgetter interface {
+	// Forget drops the history of the given entry.
+	Forget(id EntryID) error
+}
+
+// MemoryStore is a Store that keeps its records in process memory.
+type MemoryStore struct {
+	mu   sync.Mutex
+	now  func() time.Time
+	runs map[EntryID][]Run
+	next map[string]persistedNext
+
+	// The claims made since rotated, and those made in the retention
+	// before; older ones are forgotten.
+	claimed, expiring map[RunKey]struct{}
+	rotated           time.Time
+	retention         time.Duration
+
+	historyLimit int
+}
+
+// MemoryStoreOption configures a MemoryStore.
+type MemoryStoreOption func(*MemoryStore)
+
+// WithClaimRetention returns a MemoryStoreOption that keeps each claim for at
+// least d after it is made, and at most twice that, so that the claims of
+// a long-running process do not grow without bound.  An activation claimed
+// longer ago may be claimed again, as by repeating a backfill.  With d zero
+// or less, every claim is kept.
+func WithClaimRetention(d time.Duration) MemoryStoreOption {
+	return func(s *MemoryStore) {
+		s.retention = d
+	}
+}
+
+// WithHistoryLimit returns a MemoryStoreOption that keeps the n most recently
+// recorded runs of each entry, forgetting older ones.  With n zero or less,
+// every run is kept.
+func WithHistoryLimit(n int) MemoryStoreOption {
+	return func(s *MemoryStore) {
+		s.historyLimit = n
+	}
+}
+
+// NewMemoryStore returns an empty MemoryStore.  Its claims are kept for
+// DefaultClaimRetention, and DefaultHistoryLimit runs of each entry, unless
+// an option says otherwise.  The history of an entry is dropped when it is
+// removed from the Cron.
+func NewMemoryStore(opts ...MemoryStoreOption) *MemoryStore {
+	s := &MemoryStore{
+		now:       time.Now,
+		claimed:   make(map[RunKey]struct{}),
+		runs:      make(map[EntryID][]Run),
+		next:      make(map[string]persistedNext),
+		retention: DefaultClaimRetention,
+
+		historyLimit: DefaultHistoryLimit,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.rotated = s.now()
+	return s
+}
+
+// Claim records the given activation, returning false if it was already
+// recorded.
+func (s *MemoryStore) Claim(key RunKey) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.claim(key), nil
+}
+
+// ClaimBatch records the given activations under a single lock, returning
+// false for each that was already recorded.
+func (s *MemoryStore) ClaimBatch(keys []RunKey) ([]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	oks := make([]bool, len(keys))
+	for i, key := range keys {
+		oks[i] = s.claim(key)
+	}
+	return oks, nil
+}
+
+// claim records the activation unless it was already recorded, reporting
+// whether it was not.  The caller must hold mu.
+func (s *MemoryStore) claim(key RunKey) bool {
+	if now := s.now(); s.retention > 0 && now.Sub(s.rotated) >= s.retention {
+		// Forget the claims made before the last rotation, and those made
+		// since if they too are past the retention.
+		s.expiring = s.claimed
+		if now.Sub(s.rotated) >= 2*s.retention {
+			s.expiring = nil
+		}
+		s.claimed, s.rotated = make(map[RunKey]struct{}), now
+	}
+	if _, ok := s.claimed[key]; ok {
+		return false
+	}
+	if _, ok := s.expiring[key]; ok {
+		return false
+	}
+	s.claimed[key] = struct{}{}
+	return true
+}
+
+// Record adds the run to the history of its entry.
+func (s *MemoryStore) Record(run Run) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runs := append(s.runs[run.EntryID], run)
+	// Trim the runs beyond the limit once they double it, so that each run
+	// is copied once on average; History skips them meanwhile.
+	if s.historyLimit > 0 && len(runs) >= 2*s.historyLimit {
+		runs = append([]Run(nil), runs[len(runs)-s.historyLimit:]...)
+	}
+	s.runs[run.EntryID] = runs
+	return nil
+}
+
+// Forget drops the history of the given entry.
+func (s *MemoryStore) Forget(id EntryID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.runs, id)
+	return nil
+}
+
+// History returns the recorded runs of the given entry scheduled at or after
+// since, most recent first.
+func (s *MemoryStore) History(id EntryID, since time.Time, limit int) ([]Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runs := s.runs[id]
+	if s.historyLimit > 0 && len(runs) > s.historyLimit {
+		runs = runs[len(runs)-s.historyLimit:]
+	}
+	var history []Run
+	for _, run := range runs {
+		if !run.Scheduled.Before(since) {
+			history = append(history, run)
+		}
+	}
+	sort.Sort(sort.Reverse(byScheduled(history)))
+	if limit > 0 && len(history) > limit {
+		history = history[:limit]
+	}
+	return history, nil
+}
+
+// persistedNext is a next activation time recorded by a MemoryStore.
+type persistedNext struct {
+	spec string
+	next time.Time
+}
+
+// SaveNext records the next activation time of the named entry and its
+// spec.
+func (s *MemoryStore) SaveNext(name, spec string, next time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next[name] = persistedNext{spec, next}
+	return nil
+}
+
+// LoadNext returns the recorded next activation time of the named entry and
+// its spec.
+func (s *MemoryStore) LoadNext(name string) (string, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.next[name]
+	return p.spec, p.next, nil
+}
@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunKeyDeterministic(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduled := time.Date(2016, 9, 14, 10, 0, 0, 0, time.UTC)
+
+	if NewRunKey(1, scheduled) != NewRunKey(1, scheduled.In(ny)) {
+		t.Error("expected the same key regardless of location")
+	}
+	if NewRunKey(1, scheduled) == NewRunKey(2, scheduled) {
+		t.Error("expected different keys for different entries")
+	}
+	if NewRunKey(1, scheduled) == NewRunKey(1, scheduled.Add(time.Second)) {
+		t.Error("expected different keys for different activations")
+	}
+}
+
+func TestMemoryStoreClaim(t *testing.T) {
+	store := NewMemoryStore()
+	key := NewRunKey(1, time.Now())
+
+	if ok, _ := store.Claim(key); !ok {
+		t.Error("expected first claim to succeed")
+	}
+	if ok, _ := store.Claim(key); ok {
+		t.Error("expected second claim to fail")
+	}
+}
+
+// Claims are forgotten after their retention, so that the store does not
+// grow without bound.
+func TestMemoryStoreClaimRetention(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore(WithClaimRetention(time.Hour))
+	store.now = func() time.Time { return now }
+	store.rotated = now
+
+	for i := 0; i < 100; i++ {
+		store.Claim(NewRunKey(1, now.Add(time.Duration(i)*time.Second)))
+	}
+	now = now.Add(time.Hour)
+	if ok, _ := store.Claim(NewRunKey(1, now)); !ok {
+		t.Error("expected a new activation to be claimed")
+	}
+	if ok, _ := store.Claim(NewRunKey(1, now.Add(-time.Hour))); ok {
+		t.Error("expected a claim within twice the retention to be kept")
+	}
+
+	now = now.Add(time.Hour)
+	store.Claim(NewRunKey(2, now))
+	if n := len(store.claimed) + len(store.expiring); n != 2 {
+		t.Errorf("expected the old claims to be forgotten, %d remain", n)
+	}
+	if ok, _ := store.Claim(NewRunKey(1, now.Add(-2*time.Hour))); !ok {
+		t.Error("expected a forgotten activation to be claimable again")
+	}
+}
+
+// With no retention, claims are never forgotten.
+func TestMemoryStoreClaimRetentionUnlimited(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore(WithClaimRetention(0))
+	store.now = func() time.Time { return now }
+	key := NewRunKey(1, now)
+	store.Claim(key)
+	now = now.AddDate(1, 0, 0)
+	if ok, _ := store.Claim(key); ok {
+		t.Error("expected the claim to be kept")
+	}
+}
+
+// Activations already recorded in the store are not dispatched again.
+func TestStoreRefusesClaimedActivation(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	for i := 1; i <= 3; i++ {
+		store.Claim(NewRunKey(1, now.Truncate(time.Second).Add(time.Duration(i)*time.Second)))
+	}
+
+	ran := make(chan struct{}, 1)
+	cron := New(WithStore(store))
+	cron.AddFunc("* * * * * ?", func() { ran <- struct{}{} })
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(ONE_SECOND):
+	case <-ran:
+		t.Error("expected the claimed activation not to run")
+	}
+}
+
+func TestRunKeyInContext(t *testing.T) {
+	keys := make(chan RunKey, 1)
+	cron := New()
+	id, _ := cron.AddContextFunc("* * * * * ?", func(ctx context.Context) error {
+		key, _ := RunKeyFromContext(ctx)
+		keys <- key
+		return nil
+	})
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected job to run")
+	case key := <-keys:
+		entry := cron.Entries()[0]
+		if key != NewRunKey(id, entry.Prev) {
+			t.Errorf("expected key %s, got %s", NewRunKey(id, entry.Prev), key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ExportOnCalendar renders the schedule, interpreted in the given location,
+// as a systemd OnCalendar expression, such as "Mon..Fri *-*-* 09:30:00".  A
+// LocationSchedule is exported in its own location.  A nil or Local
+// location leaves the time zone out, so that the timer runs in the system's.
+// It returns an error if OnCalendar cannot represent the schedule: it must be
+// a SpecSchedule, and restrict at most one of the day of month and the day of
+// week, as systemd cannot run on either.
+func ExportOnCalendar(schedule Schedule, location *time.Location) (string, error) {
+	if l, ok := schedule.(LocationSchedule); ok {
+		schedule, location = l.Schedule, l.Location
+	}
+	s, ok := schedule.(*SpecSchedule)
+	if !ok {
+		return "", fmt.Errorf("cron: OnCalendar cannot represent %s", Describe(schedule))
+	}
+	byDom, byDow, _ := s.restrictsDays()
+	if byDom && byDow {
+		return "", fmt.Errorf("cron: OnCalendar cannot restrict both the day of month and the day of week")
+	}
+
+	var expr string
+	if byDow {
+		expr = formatCalendarField(s.Dow, dow, func(v uint) string { return time.Weekday(v).String()[:3] }) + " "
+	}
+	expr += fmt.Sprintf("*-%s-%s %s:%s:%s",
+		formatCalendarField(s.Month, months, pad),
+		formatCalendarField(s.Dom, dom, pad),
+		formatCalendarField(s.Hour, hours, pad),
+		formatCalendarField(s.Minute, minutes, pad),
+		formatCalendarField(s.Second, seconds, pad))
+	if location != nil && location != time.Local {
+		expr += " " + location.String()
+	}
+	return expr, nil
+}
+
+// ExportTimerUnit renders the schedule as a systemd .timer unit with the
+// given description, triggering the service of the same name.  Schedules
+// are exported with ExportOnCalendar, except @every intervals, which become
+// a monotonic timer activating that long after the timer starts and after
+// each run.
+func ExportTimerUnit(schedule Schedule, location *time.Location, description string) (string, error) {
+	var timer string
+	if s, ok := schedule.(ConstantDelaySchedule); ok {
+		interval := strconv.Itoa(int(s.Delay/time.Second)) + "s"
+		timer = "OnActiveSec=" + interval + "\nOnUnitActiveSec=" + interval + "\n"
+	} else {
+		expr, err := ExportOnCalendar(schedule, location)
+		if err != nil {
+			return "", err
+		}
+		timer = "OnCalendar=" + expr + "\nPersistent=true\n"
+	}
+	return "[Unit]\nDescription=" + description + "\n\n[Timer]\n" + timer +
+		"\n[Install]\nWantedBy=timers.target\n", nil
+}
+
+// formatCalendarField renders a field's bit set in systemd's syntax, naming
+// each value with name.
+func formatCalendarField(bits uint64, r bounds, name func(uint) string) string {
+	return formatList(bits, r, "..", name)
+}
+
+// pad renders a value with at least two digits.
+func pad(v uint) string {
+	return fmt.Sprintf("%02d", v)
+}
@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportOnCalendar(t *testing.T) {
+	zone := time.FixedZone("Europe/Berlin", 60*60)
+	for _, test := range []struct {
+		spec     string
+		location *time.Location
+		expected string
+	}{
+		{"0 30 9 * * MON-FRI", nil, "Mon..Fri *-*-* 09:30:00"},
+		{"0 0 */6 1 * ?", nil, "*-*-01 00,06,12,18:00:00"},
+		{"30 15 10 * JAN-MAR,DEC *", time.UTC, "*-01..03,12-* 10:15:30 UTC"},
+		{"@hourly", zone, "*-*-* *:00:00 Europe/Berlin"},
+		{"0 0 0 * * SUN,SAT", time.Local, "Sun,Sat *-*-* 00:00:00"},
+		{"0 0 9 1-31 * MON", nil, "*-*-* 09:00:00"},
+	} {
+		expr, err := ExportOnCalendar(mustParse(test.spec), test.location)
+		if err != nil || expr != test.expected {
+			t.Errorf("%s: expected %q, got %q, %v", test.spec, test.expected, expr, err)
+		}
+	}
+
+	for _, schedule := range []Schedule{Every(time.Hour), mustParse("0 0 12 1 * MON")} {
+		if _, err := ExportOnCalendar(schedule, nil); err == nil {
+			t.Errorf("%s: expected an error", Describe(schedule))
+		}
+	}
+}
+
+func TestExportTimerUnit(t *testing.T) {
+	unit, err := ExportTimerUnit(mustParse("0 30 9 * * MON-FRI"), nil, "Daily report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"Description=Daily report", "OnCalendar=Mon..Fri *-*-* 09:30:00", "WantedBy=timers.target"} {
+		if !strings.Contains(unit, line+"\n") {
+			t.Errorf("expected %q in the unit:\n%s", line, unit)
+		}
+	}
+
+	unit, err = ExportTimerUnit(Every(90*time.Minute), nil, "Sync")
+	if err != nil || !strings.Contains(unit, "OnUnitActiveSec=5400s\n") {
+		t.Errorf("expected a monotonic timer, got %v:\n%s", err, unit)
+	}
+}
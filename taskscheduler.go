@@ -0,0 +1,187 @@
+//go:build !js && !wasip1 && !tinygo
+// +build !js,!wasip1,!tinygo
+
+package cron
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// maxTaskTriggers is the number of triggers Task Scheduler allows a task.
+const maxTaskTriggers = 48
+
+// ExportTaskScheduler renders the schedule as the Triggers element of a
+// Windows Task Scheduler task definition, with calendar triggers starting on
+// the date of start, in the local time of the machine running the task.
+//
+// A schedule activating at one time of day becomes a single trigger, and one
+// activating at evenly spaced times through the whole day, such as every 15
+// minutes, a trigger repeating at that interval.  Otherwise each time of day
+// needs a trigger, up to the 48 Task Scheduler allows.  ExportTaskScheduler
+// returns an error saying why if Task Scheduler cannot represent the
+// schedule, which is also the case if it restricts both the day of month and
+// the day of week, as Task Scheduler cannot run on either.
+func ExportTaskScheduler(s *SpecSchedule, start time.Time) (string, error) {
+	byDom, byDow, _ := s.restrictsDays()
+	if byDom && byDow {
+		return "", fmt.Errorf("cron: Task Scheduler cannot restrict both the day of month and the day of week")
+	}
+
+	var times []time.Duration
+	for _, h := range values(s.Hour, hours) {
+		for _, m := range values(s.Minute, minutes) {
+			for _, sec := range values(s.Second, seconds) {
+				times = append(times, time.Duration(h)*time.Hour+time.Duration(m)*time.Minute+time.Duration(sec)*time.Second)
+			}
+		}
+	}
+	var repetition *taskRepetition
+	if interval, ok := evenlySpaced(times); ok {
+		repetition = &taskRepetition{Interval: isoDuration(interval), Duration: "P1D"}
+		times = times[:1]
+	} else if len(times) > maxTaskTriggers {
+		return "", fmt.Errorf("cron: Task Scheduler cannot run at %d times of day", len(times))
+	}
+
+	var trigger taskTrigger
+	monthAll := s.Month&starBit > 0 || isAll(s.Month, months)
+	switch {
+	case byDow && monthAll:
+		trigger.ByWeek = &taskByWeek{DaysOfWeek: taskDays(s.Dow), WeeksInterval: 1}
+	case byDow:
+		trigger.ByMonthDayOfWeek = &taskByMonthDayOfWeek{
+			Weeks:      []string{"1", "2", "3", "4", "Last"},
+			DaysOfWeek: taskDays(s.Dow),
+			Months:     taskMonths(s.Month),
+		}
+	case byDom || !monthAll:
+		var days []int
+		for _, d := range values(s.Dom, dom) {
+			days = append(days, int(d))
+		}
+		trigger.ByMonth = &taskByMonth{Days: days, Months: taskMonths(s.Month)}
+	default:
+		trigger.ByDay = &taskByDay{DaysInterval: 1}
+	}
+
+	triggers := taskTriggers{}
+	date := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	for _, t := range times {
+		trigger.StartBoundary = date.Add(t).Format("2006-01-02T15:04:05")
+		trigger.Repetition = repetition
+		triggers.Calendar = append(triggers.Calendar, trigger)
+	}
+	b, err := xml.MarshalIndent(triggers, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// evenlySpaced returns the interval between the times of day, if there are
+// several and they repeat at that interval through the whole day.
+func evenlySpaced(times []time.Duration) (time.Duration, bool) {
+	if len(times) < 2 {
+		return 0, false
+	}
+	interval := times[1] - times[0]
+	if time.Duration(len(times))*interval != 24*time.Hour {
+		return 0, false
+	}
+	for i := 2; i < len(times); i++ {
+		if times[i]-times[i-1] != interval {
+			return 0, false
+		}
+	}
+	return interval, true
+}
+
+// isoDuration renders a duration of whole seconds in ISO 8601.
+func isoDuration(d time.Duration) string {
+	s := "PT"
+	if h := d / time.Hour; h > 0 {
+		s += fmt.Sprintf("%dH", h)
+	}
+	if m := d % time.Hour / time.Minute; m > 0 {
+		s += fmt.Sprintf("%dM", m)
+	}
+	if sec := d % time.Minute / time.Second; sec > 0 {
+		s += fmt.Sprintf("%dS", sec)
+	}
+	return s
+}
+
+// taskDays returns the Task Scheduler elements naming the days of the week.
+func taskDays(bits uint64) taskNames {
+	var names taskNames
+	for _, v := range values(bits, dow) {
+		names = append(names, time.Weekday(v).String())
+	}
+	return names
+}
+
+// taskMonths returns the Task Scheduler elements naming the months.
+func taskMonths(bits uint64) taskNames {
+	var names taskNames
+	for _, v := range values(bits, months) {
+		names = append(names, time.Month(v).String())
+	}
+	return names
+}
+
+type taskTriggers struct {
+	XMLName  xml.Name      `xml:"Triggers"`
+	Calendar []taskTrigger `xml:"CalendarTrigger"`
+}
+
+type taskTrigger struct {
+	StartBoundary    string
+	Repetition       *taskRepetition
+	ByDay            *taskByDay            `xml:"ScheduleByDay"`
+	ByWeek           *taskByWeek           `xml:"ScheduleByWeek"`
+	ByMonth          *taskByMonth          `xml:"ScheduleByMonth"`
+	ByMonthDayOfWeek *taskByMonthDayOfWeek `xml:"ScheduleByMonthDayOfWeek"`
+}
+
+type taskRepetition struct {
+	Interval string
+	Duration string
+}
+
+type taskByDay struct {
+	DaysInterval int
+}
+
+type taskByWeek struct {
+	DaysOfWeek    taskNames
+	WeeksInterval int
+}
+
+type taskByMonth struct {
+	Days   []int `xml:"DaysOfMonth>Day"`
+	Months taskNames
+}
+
+type taskByMonthDayOfWeek struct {
+	Weeks      []string `xml:"Weeks>Week"`
+	DaysOfWeek taskNames
+	Months     taskNames
+}
+
+// taskNames is a list of days or months, which Task Scheduler gives as empty
+// elements named after them.
+type taskNames []string
+
+func (n taskNames) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, name := range n {
+		if err := e.EncodeElement(struct{}{}, xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
@@ -0,0 +1,67 @@
+//go:build !js && !wasip1 && !tinygo
+// +build !js,!wasip1,!tinygo
+
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportTaskScheduler(t *testing.T) {
+	start := time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC)
+	for spec, expected := range map[string][]string{
+		"0 30 9 * * *": {
+			"<StartBoundary>2024-03-01T09:30:00</StartBoundary>",
+			"<ScheduleByDay>\n      <DaysInterval>1</DaysInterval>",
+		},
+		"0 30 9 * * MON-FRI": {
+			"<DaysOfWeek>\n        <Monday></Monday>",
+			"<Friday></Friday>\n      </DaysOfWeek>\n      <WeeksInterval>1</WeeksInterval>",
+		},
+		"0 0 0,9 1,15 * *": {
+			"<StartBoundary>2024-03-01T00:00:00</StartBoundary>",
+			"<StartBoundary>2024-03-01T09:00:00</StartBoundary>",
+			"<DaysOfMonth>\n        <Day>1</Day>\n        <Day>15</Day>\n      </DaysOfMonth>",
+			"<Months>\n        <January></January>",
+		},
+		"0 0 6 * JAN,JUL SUN": {
+			"<ScheduleByMonthDayOfWeek>\n      <Weeks>\n        <Week>1</Week>",
+			"<Week>Last</Week>",
+			"<DaysOfWeek>\n        <Sunday></Sunday>\n      </DaysOfWeek>",
+			"<Months>\n        <January></January>\n        <July></July>\n      </Months>",
+		},
+		"0 0 9 1-31 * MON": {
+			"<ScheduleByDay>\n      <DaysInterval>1</DaysInterval>",
+		},
+		"0 */15 * * * *": {
+			"<StartBoundary>2024-03-01T00:00:00</StartBoundary>\n    <Repetition>\n      <Interval>PT15M</Interval>\n      <Duration>P1D</Duration>",
+		},
+	} {
+		schedule, err := Parse(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		xml, err := ExportTaskScheduler(schedule.(*SpecSchedule), start)
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+			continue
+		}
+		for _, e := range expected {
+			if !strings.Contains(xml, e) {
+				t.Errorf("%s: expected %q in:\n%s", spec, e, xml)
+			}
+		}
+	}
+
+	for _, spec := range []string{
+		"0 0 12 1 * MON",
+		"0 0,7,13 * * * *",
+	} {
+		schedule, _ := Parse(spec)
+		if _, err := ExportTaskScheduler(schedule.(*SpecSchedule), start); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package cron
+
+import "time"
+
+// WithTimeScale returns an Option that makes time pass factor times faster
+// for the Cron than on its clock, the system clock or the one set with
+// WithClock, so that an integration test can play out a week of schedule
+// interactions in seconds while still running the real dispatch code: with
+// a factor of 3600, each second on the clock is an hour for the Cron.  The
+// Cron's time starts at the clock's when the Cron is created.
+//
+// Every wait of the Cron, from the activations of its entries to jitter,
+// rate limits, timeouts and SLAs, is scaled, and so are the times it
+// records, such as the start and end of runs.  The jobs themselves are not:
+// a job sleeping for a second takes factor seconds of the Cron's time.  A
+// factor of zero or less is ignored.
+func WithTimeScale(factor float64) Option {
+	return func(c *Cron) {
+		c.timeScale = factor
+	}
+}
+
+// scaledClock is a Clock running factor times faster than its base, from
+// origin.
+type scaledClock struct {
+	base   Clock
+	origin time.Time
+	factor float64
+}
+
+func newScaledClock(base Clock, factor float64) *scaledClock {
+	return &scaledClock{base: base, origin: base.Now(), factor: factor}
+}
+
+func (c *scaledClock) Now() time.Time {
+	now := c.base.Now()
+	return c.origin.Add(time.Duration(float64(now.Sub(c.origin)) * c.factor))
+}
+
+// wait returns the duration on the base clock of d on the scaled one.
+func (c *scaledClock) wait(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) / c.factor)
+}
+
+func (c *scaledClock) NewTimer(d time.Duration) Timer {
+	t := &scaledTimer{c: make(chan time.Time, 1)}
+	t.timer = c.base.AfterFunc(c.wait(d), func() {
+		t.c <- c.Now()
+	})
+	return t
+}
+
+func (c *scaledClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.base.AfterFunc(c.wait(d), f)
+}
+
+// scaledTimer is a Timer of a scaledClock, sending the scaled time.
+type scaledTimer struct {
+	timer Timer
+	c     chan time.Time
+}
+
+func (t *scaledTimer) C() <-chan time.Time { return t.c }
+
+func (t *scaledTimer) Stop() bool {
+	return t.timer.Stop()
+}
@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// An hourly entry runs many times a second at a factor of 100000, at its
+// scheduled times on the Cron's clock.
+func TestTimeScale(t *testing.T) {
+	var scheduled []time.Time
+	cron := New(WithTimeScale(100000))
+	start := cron.now()
+	cron.AddFunc("0 0 * * * ?", func() {})
+	events, cancel := cron.Subscribe(1000)
+	defer cancel()
+	cron.Start()
+
+	deadline := time.After(1500 * time.Millisecond)
+	for len(scheduled) < 10 {
+		select {
+		case ev := <-events:
+			if ev.Type == RunFinished {
+				scheduled = append(scheduled, ev.Run.Scheduled)
+			}
+		case <-deadline:
+			t.Fatalf("expected 10 runs, got %d", len(scheduled))
+		}
+	}
+	cron.Stop()
+
+	for i, s := range scheduled {
+		if s.Minute() != 0 || s.Second() != 0 || !s.After(start) {
+			t.Errorf("run %d scheduled at %v, want a whole hour after %v", i, s, start)
+		}
+		if i > 0 && s.Sub(scheduled[i-1]) != time.Hour {
+			t.Errorf("run %d scheduled at %v, want an hour after %v", i, s, scheduled[i-1])
+		}
+	}
+	if elapsed := cron.now().Sub(start); elapsed < 9*time.Hour {
+		t.Errorf("expected at least 9 hours to pass on the Cron's clock, got %v", elapsed)
+	}
+}
+
+func TestTimeScaleTimer(t *testing.T) {
+	c := newScaledClock(systemClock{}, 1000)
+	begin := time.Now()
+	timer := c.NewTimer(time.Minute)
+	fired := <-timer.C()
+	if real := time.Since(begin); real > time.Second {
+		t.Errorf("a minute took %v, want about 60ms", real)
+	}
+	if scaled := fired.Sub(c.origin); scaled < time.Minute {
+		t.Errorf("timer fired after %v on the scaled clock, want at least a minute", scaled)
+	}
+	if !c.NewTimer(time.Hour).Stop() {
+		t.Error("expected Stop to stop a pending timer")
+	}
+}
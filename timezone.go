@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// SetLocation changes the time zone the Cron schedules its entries in, and
+// recomputes the next activation of every scheduled entry from the current
+// time, sending an EntryRescheduled event for each whose next activation
+// changed.  Entries on an @every interval, which does not depend on the time
+// zone, keep their next activation.
+//
+// Passing a Location freshly loaded after the system's tz database was
+// updated lets a long-running process pick up changed DST rules, see
+// ReloadLocation.
+func (c *Cron) SetLocation(location *time.Location) {
+	c.do(func() {
+		c.location.Store(location)
+		if !c.running {
+			return
+		}
+		now := c.now()
+		for _, e := range c.queue.list() {
+			if e.Next.IsZero() {
+				continue
+			}
+			old := e.Next
+			if _, ok := e.Schedule.(ConstantDelaySchedule); ok {
+				e.Next = e.Next.In(location)
+			} else {
+				c.advance(e, now)
+			}
+			if !e.Next.Equal(old) {
+				c.emit(EntryRescheduled, e.Namespace, e.ID, nil)
+			}
+		}
+	})
+}
+
+// ReloadLocation reloads the Cron's time zone from the system's tz database
+// and applies it with SetLocation.  The Local time zone is reloaded from the
+// TZ environment variable as the time package does, or /etc/localtime if TZ
+// is unset.
+func (c *Cron) ReloadLocation() error {
+	location, err := reloadLocation(c.Location())
+	if err != nil {
+		return err
+	}
+	c.SetLocation(location)
+	return nil
+}
+
+// reloadLocation loads the given location afresh.  time.LoadLocation does
+// not cache zones other than Local, which is loaded once at startup.
+func reloadLocation(location *time.Location) (*time.Location, error) {
+	if location != time.Local {
+		return time.LoadLocation(location.String())
+	}
+	if tz, ok := os.LookupEnv("TZ"); ok {
+		if tz == "" {
+			return time.UTC, nil
+		}
+		return time.LoadLocation(tz)
+	}
+	data, err := ioutil.ReadFile("/etc/localtime")
+	if err != nil {
+		return nil, err
+	}
+	return time.LoadLocationFromTZData("Local", data)
+}
+
+// Floating returns the schedule without the fixed time zone of a
+// LocationSchedule, such as a CRON_TZ= prefix gives, so that it is
+// interpreted in the location of the time passed to its Next.  One floating
+// schedule thus serves users in many time zones:
+//
+//	s := cron.Floating(schedule)
+//	next := s.Next(time.Now().In(user.Location)) // in the user's zone
+//
+// SpecSchedules, such as Parse returns for specs without a time zone, and
+// ConstantDelaySchedules, which do not depend on one, are floating already,
+// and are returned as they are.  In a Cron, a floating schedule is
+// interpreted in the Cron's location.
+func Floating(schedule Schedule) Schedule {
+	for {
+		l, ok := schedule.(LocationSchedule)
+		if !ok {
+			return schedule
+		}
+		schedule = l.Schedule
+	}
+}
@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetLocation(t *testing.T) {
+	cron := NewWithLocation(time.UTC)
+	events, cancel := cron.Subscribe(100)
+	defer cancel()
+	noon, _ := cron.AddFunc("0 0 12 * * ?", func() {})
+	every, _ := cron.AddFunc("@every 1h", func() {})
+	cron.Start()
+	defer cron.Stop()
+	before := cron.Entry(every).Next
+
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	cron.SetLocation(zone)
+	if cron.Location() != zone {
+		t.Errorf("expected location %v, got %v", zone, cron.Location())
+	}
+	next := cron.Entry(noon).Next
+	if next.Location() != zone || next.Hour() != 12 {
+		t.Errorf("expected noon in the new zone, got %v", next)
+	}
+	if event := expectEvent(t, events, EntryRescheduled); event.EntryID != noon {
+		t.Errorf("expected entry %d rescheduled, got %d", noon, event.EntryID)
+	}
+	if after := cron.Entry(every).Next; !after.Equal(before) {
+		t.Errorf("expected the interval to keep its next activation %v, got %v", before, after)
+	}
+}
+
+func TestReloadLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tz database:", err)
+	}
+	cron := NewWithLocation(location)
+	if err := cron.ReloadLocation(); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded := cron.Location(); reloaded == location || reloaded.String() != location.String() {
+		t.Errorf("expected a fresh copy of %v, got %p", location, reloaded)
+	}
+}
+
+func TestFloating(t *testing.T) {
+	fixed, err := ParseRobfig("CRON_TZ=Asia/Tokyo 30 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := Floating(fixed)
+	if _, ok := s.(*SpecSchedule); !ok {
+		t.Fatalf("expected the SpecSchedule, got %T", s)
+	}
+
+	// One schedule activates at 09:30 in each user's zone.
+	now := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for _, name := range []string{"America/New_York", "Europe/Paris", "Asia/Kolkata", "Australia/Sydney"} {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next := s.Next(now.In(loc))
+		if next.Location() != loc || next.Hour() != 9 || next.Minute() != 30 {
+			t.Errorf("%s: expected 09:30 local time, got %v", name, next)
+		}
+	}
+
+	every := Every(time.Hour)
+	if Floating(every) != Schedule(every) {
+		t.Error("expected a ConstantDelaySchedule to be returned as it is")
+	}
+	nested := LocationSchedule{LocationSchedule{every, time.UTC}, time.UTC}
+	if Floating(nested) != Schedule(every) {
+		t.Error("expected nested LocationSchedules to be removed")
+	}
+}
@@ -0,0 +1,46 @@
+package cron
+
+import "time"
+
+// Upcoming returns up to n of the next activations of the entry with the
+// given ID on the Cron's clock, as the Cron would activate it: from its
+// current next activation, in its locations, and within its ValidUntil and
+// MaxRuns.  It returns none for a paused or expired entry, or if there is
+// no entry with the ID.  A job's hint or a misfire may change the
+// activations after the first.
+func (c *Cron) Upcoming(id EntryID, n int) []time.Time {
+	e := c.Entry(id)
+	if e == nil {
+		return nil
+	}
+	return c.upcoming(e, n, c.now())
+}
+
+// upcoming returns up to n of the next activations of the entry after now.
+func (c *Cron) upcoming(e *Entry, n int, now time.Time) []time.Time {
+	if e.Paused || e.expired {
+		return nil
+	}
+	if e.MaxRuns > 0 && e.MaxRuns-e.Runs < n {
+		n = e.MaxRuns - e.Runs
+	}
+	t := e.Next
+	if t.IsZero() || t.Before(now) {
+		// The entry is not scheduled yet, so its first activation is
+		// computed as advance would.
+		from := now
+		if start := e.start(now); !e.scheduled && start.After(from) {
+			from = start.Add(-time.Nanosecond)
+		}
+		if !e.ValidFrom.IsZero() && from.Before(e.ValidFrom) {
+			from = e.ValidFrom.Add(-time.Nanosecond)
+		}
+		t = c.next(e, from)
+	}
+	var times []time.Time
+	for len(times) < n && !t.IsZero() && (e.ValidUntil.IsZero() || !t.After(e.ValidUntil)) {
+		times = append(times, t)
+		t = c.next(e, t)
+	}
+	return times
+}
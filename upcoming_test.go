@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUpcoming(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+	c := NewWithLocation(time.UTC)
+	daily, _ := c.AddFunc("0 0 9 * * *", func() {})
+	limited, _ := c.AddFunc("0 0 9 * * *", func() {}, MaxRuns(2, false), ValidFrom(at(3, 0)))
+	until, _ := c.AddFunc("0 0 9 * * *", func() {}, ValidUntil(at(3, 9), false))
+	paused, _ := c.AddFunc("0 0 9 * * *", func() {})
+	c.Pause(paused)
+
+	for _, test := range []struct {
+		id   EntryID
+		want []time.Time
+	}{
+		{daily, []time.Time{at(2, 9), at(3, 9), at(4, 9)}},
+		{limited, []time.Time{at(3, 9), at(4, 9)}},
+		{until, []time.Time{at(2, 9), at(3, 9)}},
+		{paused, nil},
+	} {
+		got := c.upcoming(c.Entry(test.id), 3, now)
+		if len(got) != len(test.want) {
+			t.Errorf("entry %d: expected %v, got %v", test.id, test.want, got)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equal(test.want[i]) {
+				t.Errorf("entry %d: expected %v, got %v", test.id, test.want, got)
+				break
+			}
+		}
+	}
+
+	// A rescheduled entry's next activation comes first.
+	e := c.Entry(daily)
+	e.Next = at(1, 12)
+	if got := c.upcoming(e, 2, now); len(got) != 2 || !got[0].Equal(at(1, 12)) || !got[1].Equal(at(2, 9)) {
+		t.Errorf("expected the next activation, then the schedule's, got %v", got)
+	}
+}
+
+// Upcoming takes the current time from the Cron's clock.
+func TestUpcomingClock(t *testing.T) {
+	c := New(WithClock(shiftedClock{shift: -365 * 24 * time.Hour}))
+	id, _ := c.AddFunc("@hourly", func() {})
+	next := c.Upcoming(id, 1)
+	if now := c.now(); len(next) != 1 || next[0].Before(now) || next[0].After(now.Add(time.Hour)) {
+		t.Errorf("expected an activation within the hour after %v, got %v", now, next)
+	}
+	if next := c.Upcoming(999, 1); next != nil {
+		t.Errorf("expected no activations of an unknown entry, got %v", next)
+	}
+}
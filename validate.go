@@ -0,0 +1,98 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validate returns an error wrapping ErrUnsatisfiableSpec if the schedule is
+// a SpecSchedule, possibly in a LocationSchedule, that never activates
+// because none of its months has a day satisfying its day restrictions.  The
+// error names the dates that do not exist, see Unreachable.  Parse accepts
+// such specs, whose schedules' Next returns the zero time.
+func Validate(schedule Schedule) error {
+	s, ok := specOf(schedule)
+	if !ok {
+		return nil
+	}
+	for month := months.min; month <= months.max; month++ {
+		if 1<<month&s.Month == 0 {
+			continue
+		}
+		// February in a leap year has the most days.
+		last := uint(daysIn(time.Month(month), 2000))
+		for first := uint(0); first < 7; first++ {
+			if nextBit(s.dayMask(first), 0, last) > 0 {
+				return nil
+			}
+		}
+	}
+	unreachable := Unreachable(schedule)
+	if len(unreachable) == 0 {
+		return specErrorf(ErrUnsatisfiableSpec, "cron: no day in the months of the schedule satisfies its day restrictions")
+	}
+	clauses := make([]string, len(unreachable))
+	for i, u := range unreachable {
+		clauses[i] = u.String()
+	}
+	return specErrorf(ErrUnsatisfiableSpec, "cron: the schedule never activates: %s", strings.Join(clauses, ", "))
+}
+
+// MonthDays is a month of a schedule with the days of the month it selects.
+type MonthDays struct {
+	Month time.Month
+	Days  []int
+}
+
+// String describes the month as lacking the days, e.g. "April has no day 31".
+func (m MonthDays) String() string {
+	days := make([]string, len(m.Days))
+	for i, d := range m.Days {
+		days[i] = strconv.Itoa(d)
+	}
+	if n := len(days); n > 1 {
+		days = append(days[:n-2], days[n-2]+" or "+days[n-1])
+	}
+	return m.Month.String() + " has no day " + strings.Join(days, ", ")
+}
+
+// Unreachable returns the months of a SpecSchedule, possibly in a
+// LocationSchedule, in which it never activates because it selects only days
+// of the month that the month does not have, such as day 31 in April.  Each
+// is returned with the days it selects, in order.  A schedule that also
+// restricts the day of the week, which it may activate on instead, has no
+// such months, and neither does one selecting February 29, which occurs in
+// leap years.
+func Unreachable(schedule Schedule) []MonthDays {
+	s, ok := specOf(schedule)
+	if !ok || s.Dom&starBit > 0 || s.Dow&starBit == 0 {
+		return nil
+	}
+	var unreachable []MonthDays
+	for month := months.min; month <= months.max; month++ {
+		if 1<<month&s.Month == 0 {
+			continue
+		}
+		last := uint(daysIn(time.Month(month), 2000))
+		if nextBit(s.Dom, 0, last) > 0 {
+			continue
+		}
+		u := MonthDays{Month: time.Month(month)}
+		for _, d := range values(s.Dom, dom) {
+			u.Days = append(u.Days, int(d))
+		}
+		unreachable = append(unreachable, u)
+	}
+	return unreachable
+}
+
+// specOf returns the SpecSchedule of the schedule, unwrapping a
+// LocationSchedule, if it is one.
+func specOf(schedule Schedule) (*SpecSchedule, bool) {
+	if l, ok := schedule.(LocationSchedule); ok {
+		schedule = l.Schedule
+	}
+	s, ok := schedule.(*SpecSchedule)
+	return s, ok
+}
@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	entries := []struct {
+		spec          string
+		unsatisfiable bool
+	}{
+		{"0 0 30 Feb *", true},
+		{"0 0 31 Apr,Jun *", true},
+		{"TZ=UTC 0 0 31 Sep *", true},
+		{"0 0 29 Feb *", false},
+		{"0 0 31 Apr,May *", false},
+		{"0 0 30 Feb Mon", false},
+		{"TZ=UTC 0 0 * * *", false},
+		{"@every 5m", false},
+	}
+	for _, c := range entries {
+		sched, err := ParseRobfig(c.spec)
+		if err != nil {
+			t.Fatalf("%q: %v", c.spec, err)
+		}
+		err = Validate(sched)
+		if c.unsatisfiable != errors.Is(err, ErrUnsatisfiableSpec) {
+			t.Errorf("%q: expected unsatisfiable %v, got %v", c.spec, c.unsatisfiable, err)
+		}
+		if !c.unsatisfiable && err != nil {
+			t.Errorf("%q: unexpected error %v", c.spec, err)
+		}
+	}
+
+	// Next agrees that the unsatisfiable schedules never activate.
+	sched, _ := Parse("0 0 0 30 Feb *")
+	if next := sched.Next(time.Now()); !next.IsZero() {
+		t.Errorf("expected no activation, got %v", next)
+	}
+
+	sched, _ = ParseRobfig("0 0 31 Apr,Jun *")
+	const expected = "cron: the schedule never activates: April has no day 31, June has no day 31"
+	if err := Validate(sched); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	entries := []struct {
+		spec     string
+		expected []MonthDays
+	}{
+		{"0 0 31 * *", []MonthDays{
+			{time.February, []int{31}},
+			{time.April, []int{31}},
+			{time.June, []int{31}},
+			{time.September, []int{31}},
+			{time.November, []int{31}},
+		}},
+		{"0 0 30,31 Feb,Mar *", []MonthDays{{time.February, []int{30, 31}}}},
+		{"TZ=UTC 0 0 31 Apr,Jun *", []MonthDays{{time.April, []int{31}}, {time.June, []int{31}}}},
+		{"0 0 29 Feb *", nil},
+		{"0 0 30 Feb Mon", nil},
+		{"0 0 */2 Feb *", nil},
+		{"0 0 * * *", nil},
+		{"@every 5m", nil},
+	}
+	for _, c := range entries {
+		sched, err := ParseRobfig(c.spec)
+		if err != nil {
+			t.Fatalf("%q: %v", c.spec, err)
+		}
+		if actual := Unreachable(sched); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%q: expected %v, got %v", c.spec, c.expected, actual)
+		}
+	}
+}
+
+func TestMonthDaysString(t *testing.T) {
+	entries := []struct {
+		m        MonthDays
+		expected string
+	}{
+		{MonthDays{time.April, []int{31}}, "April has no day 31"},
+		{MonthDays{time.February, []int{30, 31}}, "February has no day 30 or 31"},
+		{MonthDays{time.February, []int{29, 30, 31}}, "February has no day 29, 30 or 31"},
+	}
+	for _, c := range entries {
+		if actual := c.m.String(); actual != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, actual)
+		}
+	}
+}
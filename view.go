@@ -0,0 +1,65 @@
+package cron
+
+import "sync/atomic"
+
+// entryView is a read-only snapshot of a running Cron's entries, published
+// by the scheduler goroutine so that Entries and Entry can be served without
+// waiting on it.
+type entryView struct {
+	version uint64
+	entries []*Entry
+	byID    map[EntryID]*Entry
+}
+
+// entryView returns the published view of the entries, or nil if there is
+// none or the entries changed since it was published.
+func (c *Cron) entryView() *entryView {
+	v, _ := c.view.Load().(*entryView)
+	if v == nil || v.version != atomic.LoadUint64(&c.version) {
+		return nil
+	}
+	return v
+}
+
+// changed replaces any published view by an up to date one, so that once
+// Entries or Entry has been called, readers keep being served without
+// waiting on the scheduler goroutine.  It is called by the scheduler
+// goroutine after each change to the entries, including their activations,
+// before the change is acknowledged to the caller.
+func (c *Cron) changed() {
+	atomic.AddUint64(&c.version, 1)
+	if v, _ := c.view.Load().(*entryView); v != nil {
+		c.publish()
+	}
+}
+
+// publish takes a view of the entries and publishes it.
+func (c *Cron) publish() *entryView {
+	v := &entryView{
+		version: atomic.LoadUint64(&c.version),
+		entries: c.entrySnapshot(),
+		byID:    make(map[EntryID]*Entry, len(c.byID)),
+	}
+	for _, e := range v.entries {
+		v.byID[e.ID] = e
+	}
+	c.view.Store(v)
+	return v
+}
+
+// copy returns a copy of the entries, which the caller may modify.
+func (v *entryView) copy() []*Entry {
+	entries := make([]*Entry, len(v.entries))
+	for i, e := range v.entries {
+		entries[i] = e.snapshot()
+	}
+	return entries
+}
+
+// entry returns a copy of the given entry, or nil if there is none.
+func (v *entryView) entry(id EntryID) *Entry {
+	if e, ok := v.byID[id]; ok {
+		return e.snapshot()
+	}
+	return nil
+}
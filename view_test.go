@@ -0,0 +1,101 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// Entries and Entry are served from the published view while the scheduler
+// goroutine is busy.
+func TestEntriesWhileSchedulerBusy(t *testing.T) {
+	cron := New()
+	id := cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+	cron.Start()
+	defer cron.Stop()
+	cron.Entries()
+
+	release := make(chan struct{})
+	busy := make(chan struct{})
+	go cron.do(func() {
+		close(busy)
+		<-release
+	})
+	<-busy
+	defer close(release)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if n := len(cron.Entries()); n != 1 {
+			t.Errorf("expected 1 entry, got %d", n)
+		}
+		if e := cron.Entry(id); e == nil || e.ID != id {
+			t.Errorf("expected entry %d, got %v", id, e)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Entries not to wait on the scheduler")
+	}
+}
+
+// Changes are visible as soon as the call making them returns.
+func TestEntriesReflectChanges(t *testing.T) {
+	cron := New()
+	cron.Start()
+	defer cron.Stop()
+
+	for i := 0; i < 10; i++ {
+		id := cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+		if cron.Entry(id) == nil {
+			t.Fatalf("expected entry %d after adding it", id)
+		}
+		if n := len(cron.Entries()); n != 1 {
+			t.Fatalf("expected 1 entry, got %d", n)
+		}
+		cron.Pause(id)
+		if e := cron.Entry(id); e == nil || !e.Paused {
+			t.Fatalf("expected entry %d paused", id)
+		}
+		cron.Remove(id)
+		if cron.Entry(id) != nil || len(cron.Entries()) != 0 {
+			t.Fatalf("expected entry %d removed", id)
+		}
+	}
+}
+
+// Callers may modify the entries they are given.
+func TestEntriesAreCopies(t *testing.T) {
+	cron := New()
+	cron.Schedule(Every(time.Hour), FuncJob(func() {}), WithTags("a"))
+	cron.Start()
+	defer cron.Stop()
+
+	cron.Entries()[0].Tags[0] = "b"
+	if tag := cron.Entries()[0].Tags[0]; tag != "a" {
+		t.Errorf("expected tag a, got %s", tag)
+	}
+}
+
+// The view stays published as entries are activated, so readers need not
+// wait on the scheduler after the first.
+func TestEntriesViewSurvivesActivations(t *testing.T) {
+	cron := New()
+	id, _ := cron.AddFunc("* * * * * ?", func() {})
+	cron.Start()
+	defer cron.Stop()
+	first := cron.Entry(id).Next
+
+	deadline := time.Now().Add(2 * ONE_SECOND)
+	for time.Now().Before(deadline) {
+		if cron.entryView() == nil {
+			t.Fatal("expected the view to stay published")
+		}
+		if next := cron.Entry(id).Next; next.After(first) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("expected the view to reflect the activation")
+}
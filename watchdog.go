@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// Watchdog flags runs that have been running for too long.
+type Watchdog struct {
+	// Factor flags a run once it has been running for Factor times the
+	// average duration of its entry's latest runs, as given by the entry's
+	// Stats.  It applies only to entries that have finished a run.  Zero
+	// disables it.
+	Factor float64
+
+	// Threshold flags a run once it has been running for this long.  Zero
+	// disables it.  If both Factor and Threshold are set, a run is flagged
+	// when it exceeds the shorter of the two.
+	Threshold time.Duration
+
+	// Alert, if not nil, is called in its own goroutine with each flagged
+	// run and the duration it exceeded.
+	Alert func(run Run, limit time.Duration)
+
+	// Cancel cancels the context of flagged runs.  Only jobs implementing
+	// ContextJob can notice.
+	Cancel bool
+}
+
+// WithWatchdog returns an Option that watches every run with the given
+// Watchdog.  Flagged runs are also logged.
+func WithWatchdog(w Watchdog) Option {
+	return func(c *Cron) {
+		c.watchdog = &w
+	}
+}
+
+// watchLimit returns how long a run of the entry may last before being
+// flagged, or 0 if it is not limited.
+func (c *Cron) watchLimit(id EntryID) time.Duration {
+	w := c.watchdog
+	limit := w.Threshold
+	if w.Factor <= 0 {
+		return limit
+	}
+	c.health.mu.Lock()
+	var stats RunStats
+	if h := c.health.entries[id]; h != nil {
+		stats = h.stats.stats()
+	}
+	c.health.mu.Unlock()
+	if stats.Runs > 0 {
+		avg := time.Duration(float64(stats.AvgDuration) * w.Factor)
+		if limit <= 0 || avg < limit {
+			limit = avg
+		}
+	}
+	return limit
+}
+
+// watch arms the watchdog for the run just started, returning the context to
+// run it with and a function to call once it has finished.  Flagged runs are
+// logged to rlog.
+func (c *Cron) watch(ctx context.Context, run *Run, rlog runLog) (context.Context, func()) {
+	w := c.watchdog
+	if w == nil {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	var timer Timer
+	if limit := c.watchLimit(run.EntryID); limit > 0 {
+		flagged := *run
+		timer = c.clock.AfterFunc(limit, func() {
+			rlog.logf("cron: run %s still running after %s", rlog.name, limit)
+			if w.Cancel {
+				cancel()
+			}
+			if w.Alert != nil {
+				w.Alert(flagged, limit)
+			}
+		})
+	}
+	return ctx, func() {
+		if timer != nil {
+			timer.Stop()
+		}
+		cancel()
+	}
+}
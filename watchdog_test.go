@@ -0,0 +1,82 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWatchdogThreshold(t *testing.T) {
+	alerts := make(chan Run, 10)
+	cancelled := make(chan struct{}, 10)
+	cron := New(WithWatchdog(Watchdog{
+		Threshold: 100 * time.Millisecond,
+		Cancel:    true,
+		Alert:     func(run Run, limit time.Duration) { alerts <- run },
+	}))
+	id, _ := cron.AddContextFunc("* * * * * ?", func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			cancelled <- struct{}{}
+		case <-time.After(time.Second):
+		}
+		return ctx.Err()
+	})
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case run := <-alerts:
+		if run.EntryID != id || run.Start.IsZero() {
+			t.Errorf("unexpected flagged run %+v", run)
+		}
+	case <-time.After(2 * ONE_SECOND):
+		t.Fatal("expected the run to be flagged")
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("expected the run to be cancelled")
+	}
+}
+
+func TestWatchdogFactor(t *testing.T) {
+	cron := New(WithWatchdog(Watchdog{Factor: 3}))
+	if limit := cron.watchLimit(1); limit != 0 {
+		t.Errorf("expected no limit without history, got %v", limit)
+	}
+	start := time.Now()
+	cron.health.finished(Run{EntryID: 1, Start: start, End: start.Add(time.Second)})
+	cron.health.finished(Run{EntryID: 1, Start: start, End: start.Add(3 * time.Second)})
+	if limit := cron.watchLimit(1); limit != 6*time.Second {
+		t.Errorf("expected 3x the 2s average, got %v", limit)
+	}
+
+	cron.watchdog.Threshold = 5 * time.Second
+	if limit := cron.watchLimit(1); limit != 5*time.Second {
+		t.Errorf("expected the shorter threshold, got %v", limit)
+	}
+
+	// The statistics of a removed entry are forgotten with it.
+	cron.health.forget(1)
+	if limit := cron.watchLimit(1); limit != 5*time.Second {
+		t.Errorf("expected only the threshold after removal, got %v", limit)
+	}
+}
+
+// Runs finishing in time are not flagged.
+func TestWatchdogQuietRun(t *testing.T) {
+	alerts := make(chan Run, 10)
+	cron := New(WithWatchdog(Watchdog{
+		Threshold: 500 * time.Millisecond,
+		Alert:     func(run Run, limit time.Duration) { alerts <- run },
+	}))
+	cron.AddFunc("* * * * * ?", func() {})
+	cron.Start()
+	time.Sleep(ONE_SECOND + 600*time.Millisecond)
+	<-cron.Stop().Done()
+
+	if len(alerts) != 0 {
+		t.Errorf("expected no alerts, got %d", len(alerts))
+	}
+}
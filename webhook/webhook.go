@@ -0,0 +1,182 @@
+// Package webhook provides a cron.Executor that performs an HTTP request at
+// each activation instead of running code in-process.
+//
+//	x, err := webhook.NewExecutor(webhook.Webhook{
+//		URL:     "https://billing.internal/jobs/invoice",
+//		Body:    `{"scheduled": "{{.Scheduled.Format "2006-01-02T15:04:05Z07:00"}}", "account": "{{index .Metadata "account"}}"}`,
+//		Header:  http.Header{"Content-Type": {"application/json"}},
+//		Timeout: 30 * time.Second,
+//		Retry:   webhook.Retry{Attempts: 3, Backoff: time.Second},
+//	})
+//	c := cron.New(cron.WithExecutor(x))
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+// Retry is the retry policy of a Webhook.
+type Retry struct {
+	// Attempts is the maximum number of requests made for a run.  It is at
+	// least 1.
+	Attempts int
+
+	// Backoff is the delay before the first retry.  It doubles after each
+	// further attempt, up to MaxBackoff if that is set.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Webhook describes the request made for each run.
+type Webhook struct {
+	// Method is the request's method.  It defaults to POST.
+	Method string
+
+	// URL is the request's URL.
+	URL string
+
+	// Header is added to the request's headers.  The request also carries
+	// the run's key and ID, in the X-Cron-Run-Key and X-Cron-Run-ID headers.
+	Header http.Header
+
+	// Body is a text/template for the request's body, executed with the
+	// run's Data.  It may be empty.
+	Body string
+
+	// Timeout bounds each attempt.  Zero means no timeout beyond that of the
+	// run's context and of Client.
+	Timeout time.Duration
+
+	// Retry is the retry policy for failed attempts.
+	Retry Retry
+
+	// Success reports whether a response status code means success.  It
+	// defaults to accepting the 2xx codes.
+	Success func(status int) bool
+
+	// Client makes the requests.  It defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Data is the data the body template is executed with.
+type Data struct {
+	EntryID   cron.EntryID
+	Name      string
+	Scheduled time.Time
+	RunID     string
+	RunKey    cron.RunKey
+	Host      string
+	Metadata  map[string]string
+}
+
+// StatusError is the error of an attempt whose response status is not a
+// success.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook: unexpected status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+type executor struct {
+	Webhook
+	body *template.Template
+}
+
+// NewExecutor returns a cron.Executor that makes the Webhook's request for
+// each run.  A run succeeds once an attempt gets a successful response, and
+// fails with the last attempt's error once the attempts are exhausted or the
+// run's context is done.  The entries' jobs are never run, so they may be
+// nil.  It returns an error if the body template cannot be parsed.
+func NewExecutor(w Webhook) (cron.Executor, error) {
+	body, err := template.New("body").Parse(w.Body)
+	if err != nil {
+		return nil, err
+	}
+	if w.Method == "" {
+		w.Method = http.MethodPost
+	}
+	if w.Retry.Attempts < 1 {
+		w.Retry.Attempts = 1
+	}
+	if w.Success == nil {
+		w.Success = func(status int) bool { return status >= 200 && status < 300 }
+	}
+	if w.Client == nil {
+		w.Client = http.DefaultClient
+	}
+	return &executor{Webhook: w, body: body}, nil
+}
+
+func (x *executor) Execute(ctx context.Context, entry *cron.Entry, run cron.Run) error {
+	var body bytes.Buffer
+	err := x.body.Execute(&body, Data{
+		EntryID:   run.EntryID,
+		Name:      entry.Name,
+		Scheduled: run.Scheduled,
+		RunID:     run.ID,
+		RunKey:    run.Key,
+		Host:      run.Host,
+		Metadata:  entry.Metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	backoff := x.Retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err = x.attempt(ctx, run, body.Bytes())
+		if err == nil || attempt >= x.Retry.Attempts {
+			return err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+		if x.Retry.MaxBackoff > 0 && backoff > x.Retry.MaxBackoff {
+			backoff = x.Retry.MaxBackoff
+		}
+	}
+}
+
+// attempt makes one request.
+func (x *executor) attempt(ctx context.Context, run cron.Run, body []byte) error {
+	if x.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, x.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequest(x.Method, x.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range x.Header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	req.Header.Set("X-Cron-Run-Key", string(run.Key))
+	req.Header.Set("X-Cron-Run-ID", run.ID)
+
+	resp, err := x.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	if !x.Success(resp.StatusCode) {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
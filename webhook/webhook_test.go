@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/webconnex/cron"
+)
+
+var (
+	scheduled = time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+	entry     = &cron.Entry{Name: "invoice", Metadata: map[string]string{"account": "42"}}
+	run       = cron.Run{EntryID: 1, ID: "abc", Key: cron.NewRunKey(1, scheduled), Scheduled: scheduled}
+)
+
+func TestExecutor(t *testing.T) {
+	type request struct {
+		method, body, token, runID string
+	}
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{r.Method, string(body), r.Header.Get("Authorization"), r.Header.Get("X-Cron-Run-ID")}
+	}))
+	defer server.Close()
+
+	x, err := NewExecutor(Webhook{
+		URL:    server.URL,
+		Header: http.Header{"Authorization": {"Bearer secret"}},
+		Body:   `{{.Name}} {{index .Metadata "account"}} {{.Scheduled.Format "15:04"}}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := x.Execute(context.Background(), entry, run); err != nil {
+		t.Fatal(err)
+	}
+	expected := request{"POST", "invoice 42 09:30", "Bearer secret", "abc"}
+	if r := <-requests; r != expected {
+		t.Errorf("expected request %+v, got %+v", expected, r)
+	}
+}
+
+func TestExecutorRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	x, _ := NewExecutor(Webhook{URL: server.URL, Retry: Retry{Attempts: 3, Backoff: time.Millisecond}})
+	if err := x.Execute(context.Background(), entry, run); err != nil {
+		t.Errorf("expected the third attempt to succeed, got %v", err)
+	}
+
+	atomic.StoreInt32(&attempts, 0)
+	x, _ = NewExecutor(Webhook{URL: server.URL, Retry: Retry{Attempts: 2, Backoff: time.Millisecond}})
+	err := x.Execute(context.Background(), entry, run)
+	if se, ok := err.(*StatusError); !ok || se.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected a status error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+}
+
+func TestExecutorSuccessCriteria(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	x, _ := NewExecutor(Webhook{
+		Method:  http.MethodDelete,
+		URL:     server.URL,
+		Success: func(status int) bool { return status < 300 || status == http.StatusNotFound },
+	})
+	if err := x.Execute(context.Background(), entry, run); err != nil {
+		t.Errorf("expected 404 to count as success, got %v", err)
+	}
+}
+
+func TestExecutorTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	x, _ := NewExecutor(Webhook{URL: server.URL, Timeout: 50 * time.Millisecond})
+	if err := x.Execute(context.Background(), entry, run); err == nil {
+		t.Error("expected the attempt to time out")
+	}
+}
+
+func TestNewExecutorBadTemplate(t *testing.T) {
+	if _, err := NewExecutor(Webhook{Body: "{{.Name"}); err == nil {
+		t.Error("expected an error for a malformed body template")
+	}
+}
@@ -0,0 +1,196 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	wheelBits   = 6
+	wheelSlots  = 1 << wheelBits
+	wheelMask   = wheelSlots - 1
+	wheelLevels = 6
+
+	// idleBucket holds the entries that have no Next time.
+	idleBucket = wheelLevels * wheelSlots
+)
+
+// WithTimingWheel returns an Option that keeps the Cron's entries in a
+// hierarchical timing wheel rather than a heap.  Adding, removing or
+// rescheduling an entry then costs O(1) rather than O(log n), which pays off
+// for very large numbers of mostly-interval entries.  In exchange, entries
+// more than a minute away are only ordered to within their slot of the wheel
+// until they draw near; activations themselves are as exact as with the heap.
+func WithTimingWheel() Option {
+	return func(c *Cron) {
+		c.wheel = true
+	}
+}
+
+// timingWheel is a hierarchical timing wheel with one-second resolution.
+// Level 0 has a slot for each second of the current minute-long block, and
+// each level above has slots 64 times as wide as the one below.  An entry is
+// kept in the lowest level whose current block contains its Next time, and
+// moves down a level each time the wheel reaches its slot.
+type timingWheel struct {
+	buckets [idleBucket + 1][]*Entry
+	cur     int64 // the current second, in Unix time
+}
+
+func newTimingWheel(now time.Time) *timingWheel {
+	return &timingWheel{cur: now.Unix()}
+}
+
+// place puts the entry into the bucket for its Next time.
+func (w *timingWheel) place(e *Entry) {
+	b := idleBucket
+	if !e.Next.IsZero() {
+		t := e.Next.Unix()
+		if t < w.cur {
+			t = w.cur
+		}
+		top := uint(wheelBits * wheelLevels)
+		if t>>top != w.cur>>top {
+			// Beyond the wheel: park in the last slot, to be placed again
+			// once the wheel gets there.
+			t = (w.cur>>top+1)<<top - 1
+		}
+		for level := 0; level < wheelLevels; level++ {
+			shift := uint(wheelBits * level)
+			if t>>(shift+wheelBits) == w.cur>>(shift+wheelBits) {
+				b = level*wheelSlots + int(t>>shift&wheelMask)
+				break
+			}
+		}
+	}
+	e.bucket = b
+	e.index = len(w.buckets[b])
+	w.buckets[b] = append(w.buckets[b], e)
+}
+
+func (w *timingWheel) push(e *Entry) { w.place(e) }
+
+func (w *timingWheel) remove(e *Entry) {
+	bucket := w.buckets[e.bucket]
+	last := len(bucket) - 1
+	bucket[e.index] = bucket[last]
+	bucket[e.index].index = e.index
+	bucket[last] = nil
+	w.buckets[e.bucket] = bucket[:last]
+	e.index = -1
+}
+
+func (w *timingWheel) fix(e *Entry) {
+	w.remove(e)
+	w.place(e)
+}
+
+func (w *timingWheel) wakeup() time.Time {
+	for s := int(w.cur & wheelMask); s < wheelSlots; s++ {
+		var next time.Time
+		for _, e := range w.buckets[s] {
+			if next.IsZero() || e.Next.Before(next) {
+				next = e.Next
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	if t, ok := w.nextCascade(); ok {
+		return time.Unix(t, 0)
+	}
+	return time.Time{}
+}
+
+// nextCascade returns the start of the earliest occupied slot above level 0.
+func (w *timingWheel) nextCascade() (int64, bool) {
+	for level := 1; level < wheelLevels; level++ {
+		shift := uint(wheelBits * level)
+		block := w.cur >> (shift + wheelBits) << (shift + wheelBits)
+		for s := int(w.cur>>shift&wheelMask) + 1; s < wheelSlots; s++ {
+			if len(w.buckets[level*wheelSlots+s]) > 0 {
+				return block + int64(s)<<shift, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// cascade moves the entries in the slots that start at the current second
+// down to the levels below.
+func (w *timingWheel) cascade() {
+	for level := wheelLevels - 1; level > 0; level-- {
+		shift := uint(wheelBits * level)
+		if w.cur&(1<<shift-1) != 0 {
+			continue
+		}
+		b := level*wheelSlots + int(w.cur>>shift&wheelMask)
+		entries := w.buckets[b]
+		w.buckets[b] = nil
+		for _, e := range entries {
+			w.place(e)
+		}
+	}
+}
+
+func (w *timingWheel) due(now time.Time) []*Entry {
+	var due []*Entry
+	t := now.Unix()
+	if t < w.cur {
+		// The clock went back: only the entries placed in the current slot
+		// for being overdue can be due.
+		due = w.sweep(int(w.cur&wheelMask), now, due)
+		sort.Sort(byTime(due))
+		return due
+	}
+	for {
+		block := w.cur &^ wheelMask
+		last := wheelMask
+		if t < block+wheelSlots {
+			last = int(t - block)
+		}
+		for s := int(w.cur & wheelMask); s <= last; s++ {
+			due = w.sweep(s, now, due)
+		}
+		if t < block+wheelSlots {
+			if t > w.cur {
+				w.cur = t
+			}
+			break
+		}
+		// Level 0 is empty: skip ahead to the next occupied slot above it.
+		next, ok := w.nextCascade()
+		if !ok || next > t {
+			w.cur = t
+			break
+		}
+		w.cur = next
+		w.cascade()
+	}
+	sort.Sort(byTime(due))
+	return due
+}
+
+// sweep removes the entries of the level 0 slot that are due at now, and
+// appends them to due.
+func (w *timingWheel) sweep(slot int, now time.Time, due []*Entry) []*Entry {
+	for i := 0; i < len(w.buckets[slot]); {
+		e := w.buckets[slot][i]
+		if e.Next.After(now) {
+			i++
+			continue
+		}
+		w.remove(e)
+		due = append(due, e)
+	}
+	return due
+}
+
+func (w *timingWheel) list() []*Entry {
+	var entries []*Entry
+	for _, bucket := range w.buckets {
+		entries = append(entries, bucket...)
+	}
+	return entries
+}
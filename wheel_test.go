@@ -0,0 +1,126 @@
+package cron
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestTimingWheelMatchesHeap feeds the same entries through a timing wheel and
+// a heap, and checks that they come due at the same times.
+func TestTimingWheelMatchesHeap(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	w, h := newTimingWheel(start), &entryHeap{}
+	for i := 0; i < 2000; i++ {
+		next := start.Add(time.Duration(rand.Int63n(int64(90 * 24 * time.Hour))))
+		if i%10 == 0 {
+			next = time.Time{}
+		}
+		w.push(&Entry{ID: EntryID(i), Next: next})
+		h.push(&Entry{ID: EntryID(i), Next: next})
+	}
+
+	now := start
+	for n := 0; n < 5000; n++ {
+		wake := w.wakeup()
+		if wake.IsZero() {
+			break
+		}
+		if next := h.wakeup(); next.Before(wake) {
+			t.Fatalf("wheel wakes at %v, after the next entry at %v", wake, next)
+		}
+		// Sometimes oversleep, as after a suspend.
+		now = wake
+		if n%7 == 0 {
+			now = now.Add(time.Duration(rand.Int63n(int64(time.Hour))))
+		}
+		got, want := w.due(now), h.due(now)
+		if len(got) != len(want) {
+			t.Fatalf("at %v: wheel has %d entries due, expected %d", now, len(got), len(want))
+		}
+		sortDue(got)
+		sortDue(want)
+		for i := range got {
+			if got[i].ID != want[i].ID {
+				t.Fatalf("at %v: wheel has entry %d due, expected %d", now, got[i].ID, want[i].ID)
+			}
+		}
+	}
+	if n := len(h.list()); n != 200 {
+		t.Fatalf("expected only the 200 idle entries to remain, got %d", n)
+	}
+	if n := len(w.list()); n != 200 {
+		t.Fatalf("expected only the 200 idle entries to remain in the wheel, got %d", n)
+	}
+}
+
+// sortDue sorts due entries by their next activation, then their ID.
+func sortDue(due []*Entry) {
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].Next.Equal(due[j].Next) {
+			return due[i].Next.Before(due[j].Next)
+		}
+		return due[i].ID < due[j].ID
+	})
+}
+
+func TestTimingWheelFixAndRemove(t *testing.T) {
+	now := time.Now()
+	w := newTimingWheel(now)
+	a := &Entry{ID: 1, Next: now.Add(time.Hour)}
+	b := &Entry{ID: 2, Next: now.Add(2 * time.Hour)}
+	w.push(a)
+	w.push(b)
+
+	b.Next = now.Add(time.Second)
+	w.fix(b)
+	w.remove(a)
+	if due := w.due(now.Add(2 * time.Second)); len(due) != 1 || due[0] != b {
+		t.Fatalf("expected entry 2 due, got %v", due)
+	}
+	if len(w.list()) != 0 {
+		t.Fatal("expected an empty wheel")
+	}
+}
+
+func TestTimingWheelCron(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+
+	cron := New(WithTimingWheel())
+	cron.AddFunc("0 0 0 1 1 ?", func() {})
+	cron.AddFunc("* * * * * ?", func() { wg.Done() })
+	cron.Schedule(Every(time.Hour), FuncJob(func() {}))
+	cron.Start()
+	defer cron.Stop()
+	cron.Schedule(Every(time.Second), FuncJob(func() { wg.Done() }))
+
+	select {
+	case <-time.After(2 * ONE_SECOND):
+		t.FailNow()
+	case <-wait(wg):
+	}
+	if n := len(cron.Entries()); n != 4 {
+		t.Fatalf("expected 4 entries, got %d", n)
+	}
+}
+
+// Entries overdue when the wheel's clock is ahead still come due.
+func TestTimingWheelBehindClock(t *testing.T) {
+	now := time.Now()
+	w := newTimingWheel(now.Add(time.Hour))
+	e := &Entry{ID: 1, Next: now.Add(time.Second)}
+	w.push(e)
+
+	if wake := w.wakeup(); !wake.Equal(e.Next) {
+		t.Errorf("expected to wake at %v, got %v", e.Next, wake)
+	}
+	if due := w.due(now); len(due) != 0 {
+		t.Errorf("expected nothing due yet, got %v", due)
+	}
+	if due := w.due(now.Add(time.Second)); len(due) != 1 || due[0] != e {
+		t.Errorf("expected entry 1 due, got %v", due)
+	}
+}
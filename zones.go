@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// WithLocations returns an EntryOption that evaluates the entry's schedule in
+// each of the locations, rather than in the Cron's, and runs its job once
+// for each location in which it activates, so that a single "0 0 9 * * *"
+// entry runs at 09:00 in each regional office.  The job finds the location
+// it runs for with LocationFromContext.
+//
+// Locations that share an activation, as those with the same offset do, each
+// get a run at that time, with RunKeys suffixed by the location's name to
+// tell them apart.  Runs started with Trigger, or at a time set with
+// RescheduleAt, run once, for no location.
+func WithLocations(locations ...*time.Location) EntryOption {
+	return func(e *Entry) {
+		e.Locations = locations
+	}
+}
+
+// LocationFromContext returns the location the run the context was created
+// for runs for, if its entry has several, see WithLocations.
+func LocationFromContext(ctx context.Context) (*time.Location, bool) {
+	loc, ok := ctx.Value(locationContextKey).(*time.Location)
+	return loc, ok
+}
+
+// schedule returns the schedule the entry is activated on: the earliest of
+// its schedule's activations in each of its locations, if it has any.
+func (e *Entry) schedule() Schedule {
+	if len(e.Locations) == 0 {
+		return e.Schedule
+	}
+	return zonedSchedule{e.Schedule, e.Locations}
+}
+
+// zonedSchedule activates whenever the schedule activates in any of the
+// locations.
+type zonedSchedule struct {
+	schedule  Schedule
+	locations []*time.Location
+}
+
+func (s zonedSchedule) Next(t time.Time) time.Time {
+	var next time.Time
+	for _, loc := range s.locations {
+		n := s.in(loc).Next(t)
+		if !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
+
+// in returns the schedule in the location.
+func (s zonedSchedule) in(loc *time.Location) Schedule {
+	return LocationSchedule{Schedule: Floating(s.schedule), Location: loc}
+}
+
+// locationsAt returns the locations of the entry in which its activation
+// at its Next time is due, or a single nil location if it has none or the
+// activation is in none of them.
+func (c *Cron) locationsAt(e *Entry) []*time.Location {
+	if len(e.Locations) == 0 {
+		return []*time.Location{nil}
+	}
+	zoned := zonedSchedule{e.Schedule, e.Locations}
+	var due []*time.Location
+	for _, loc := range e.Locations {
+		if c.nextOf(e, zoned.in(loc), e.Next.Add(-time.Nanosecond)).Equal(e.Next) {
+			due = append(due, loc)
+		}
+	}
+	if len(due) == 0 {
+		return []*time.Location{nil}
+	}
+	return due
+}
@@ -0,0 +1,65 @@
+package cron
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestZonedScheduleNext(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	london, _ := time.LoadLocation("Europe/London")
+	schedule, _ := Parse("0 0 9 * * *")
+	e := &Entry{Schedule: schedule, Locations: []*time.Location{newYork, london}}
+
+	// 09:00 in London is 08:00 UTC in summer, and 09:00 in New York 13:00.
+	next := e.schedule().Next(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC))
+	if expected := time.Date(2026, 7, 1, 8, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+	next = e.schedule().Next(next)
+	if expected := time.Date(2026, 7, 1, 13, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+
+	c := NewWithLocation(time.UTC)
+	e.Next = next
+	if due := c.locationsAt(e); len(due) != 1 || due[0] != newYork {
+		t.Errorf("expected the activation to be due in New York, got %v", due)
+	}
+}
+
+func TestWithLocations(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	london, _ := time.LoadLocation("Europe/London")
+	runs := make(chan string, 10)
+	keys := make(chan RunKey, 10)
+	cron := New()
+	cron.AddContextFunc("* * * * * ?", func(ctx context.Context) error {
+		loc, _ := LocationFromContext(ctx)
+		key, _ := RunKeyFromContext(ctx)
+		runs <- loc.String()
+		keys <- key
+		return nil
+	}, WithLocations(newYork, london))
+	cron.Start()
+	defer cron.Stop()
+
+	var got []string
+	for len(got) < 2 {
+		select {
+		case loc := <-runs:
+			got = append(got, loc)
+		case <-time.After(2 * ONE_SECOND):
+			t.Fatalf("expected a run in each location, got %v", got)
+		}
+	}
+	sort.Strings(got)
+	if got[0] != "America/New_York" || got[1] != "Europe/London" {
+		t.Errorf("expected a run for each location, got %v", got)
+	}
+	if first, second := <-keys, <-keys; first == second {
+		t.Errorf("expected the runs to have distinct keys, got %v", first)
+	}
+}